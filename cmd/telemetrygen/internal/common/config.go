@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+var (
+	errFormatOTLPAttributes       = fmt.Errorf("value should be of the format key=\"value\"")
+	errDoubleQuotesOTLPAttributes = fmt.Errorf("value should be a string wrapped in double quotes")
+)
+
+const (
+	defaultGRPCEndpoint = "localhost:4317"
+	defaultHTTPEndpoint = "localhost:4318"
+
+	// AttributeKey is the attribute whose number of distinct values is
+	// controlled by the `attribute-cardinality` flag.
+	AttributeKey = "telemetrygen.index"
+)
+
+// KeyValue is a flag value holding key="value" pairs.
+type KeyValue map[string]string
+
+var _ pflag.Value = (*KeyValue)(nil)
+
+func (v *KeyValue) String() string {
+	return ""
+}
+
+func (v *KeyValue) Set(s string) error {
+	kv := strings.SplitN(s, "=", 2)
+	if len(kv) != 2 {
+		return errFormatOTLPAttributes
+	}
+	val := kv[1]
+	if len(val) < 2 || !strings.HasPrefix(val, "\"") || !strings.HasSuffix(val, "\"") {
+		return errDoubleQuotesOTLPAttributes
+	}
+
+	(*v)[kv[0]] = val[1 : len(val)-1]
+	return nil
+}
+
+func (v *KeyValue) Type() string {
+	return "map[string]string"
+}
+
+// Config describes the settings shared by all the signal generators.
+type Config struct {
+	WorkerCount          int
+	Rate                 int64
+	TotalDuration        time.Duration
+	ServiceName          string
+	AttributeCardinality int
+
+	// OTLP config
+	Endpoint           string
+	Insecure           bool
+	UseHTTP            bool
+	HTTPPath           string
+	Headers            KeyValue
+	ResourceAttributes KeyValue
+}
+
+// OTLPEndpoint returns the configured endpoint, or the default OTLP endpoint for
+// the selected transport if none was given.
+func (c *Config) OTLPEndpoint() string {
+	if c.Endpoint != "" {
+		return c.Endpoint
+	}
+	if c.UseHTTP {
+		return defaultHTTPEndpoint
+	}
+	return defaultGRPCEndpoint
+}
+
+// ResourceAttributeSet returns the attributes describing the generating
+// process: the service name followed by any custom resource attributes,
+// which may override it.
+func (c *Config) ResourceAttributeSet() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(c.ServiceName)}
+	for k, v := range c.ResourceAttributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return attrs
+}
+
+// IndexAttribute returns the value of the AttributeKey attribute for the i-th
+// generated item, cycling through `AttributeCardinality` distinct values. It
+// returns false if no such attribute should be added.
+func (c *Config) IndexAttribute(i int) (attribute.KeyValue, bool) {
+	if c.AttributeCardinality <= 0 {
+		return attribute.KeyValue{}, false
+	}
+	return attribute.String(AttributeKey, fmt.Sprintf("value-%d", i%c.AttributeCardinality)), true
+}
+
+// CommonFlags registers the flags shared by all the signal generators.
+func (c *Config) CommonFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.WorkerCount, "workers", 1, "Number of workers (goroutines) to run")
+	fs.Int64Var(&c.Rate, "rate", 0, "Approximately how many items per second each worker should generate. Zero means no throttling.")
+	fs.DurationVar(&c.TotalDuration, "duration", 0, "For how long to run the test")
+	fs.StringVar(&c.ServiceName, "service", "telemetrygen", "Service name to use")
+	fs.IntVar(&c.AttributeCardinality, "attribute-cardinality", 0, "Number of distinct values of the '"+AttributeKey+"' attribute added to each item. Zero means the attribute is not added.")
+
+	fs.StringVar(&c.Endpoint, "otlp-endpoint", "", "Target to which the exporter is going to send data. Defaults to "+defaultGRPCEndpoint+" for gRPC and "+defaultHTTPEndpoint+" for HTTP.")
+	fs.BoolVar(&c.Insecure, "otlp-insecure", false, "Whether to enable client transport security for the exporter's grpc or http connection")
+	fs.BoolVar(&c.UseHTTP, "otlp-http", false, "Whether to use HTTP exporter rather than a gRPC one")
+	fs.StringVar(&c.HTTPPath, "otlp-http-url-path", "", "Which URL path to write to when using the HTTP exporter. Defaults to the OTLP path of the signal.")
+
+	// custom headers
+	c.Headers = make(map[string]string)
+	fs.Var(&c.Headers, "otlp-header", "Custom header to be passed along with each OTLP request. The value is expected in the format key=\"value\". "+
+		"Flag may be repeated to set multiple headers (e.g --otlp-header key1=\"value1\" --otlp-header key2=\"value2\")")
+
+	// custom resource attributes
+	c.ResourceAttributes = make(map[string]string)
+	fs.Var(&c.ResourceAttributes, "otlp-attributes", "Custom resource attributes to use. The value is expected in the format key=\"value\". "+
+		"Flag may be repeated to set multiple attributes (e.g --otlp-attributes key1=\"value1\" --otlp-attributes key2=\"value2\")")
+}
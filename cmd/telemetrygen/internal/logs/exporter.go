@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	defaultHTTPPath = "/v1/logs"
+	exportTimeout   = 10 * time.Second
+)
+
+// exporter sends the generated logs to the configured endpoint. The OTel Go
+// SDK does not provide a logs exporter, so the OTLP requests are built from
+// pdata directly.
+type exporter interface {
+	export(plog.Logs) error
+	shutdown() error
+}
+
+type gRPCClientExporter struct {
+	conn    *grpc.ClientConn
+	client  plogotlp.GRPCClient
+	headers metadata.MD
+}
+
+func newGRPCExporter(cfg *Config) (exporter, error) {
+	creds := insecure.NewCredentials()
+	if !cfg.Insecure {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, cfg.OTLPEndpoint(), grpc.WithTransportCredentials(creds), grpc.WithBlock())
+	if err != nil {
+		return nil, err
+	}
+	return &gRPCClientExporter{
+		conn:    conn,
+		client:  plogotlp.NewGRPCClient(conn),
+		headers: metadata.New(cfg.Headers),
+	}, nil
+}
+
+func (e *gRPCClientExporter) export(logs plog.Logs) error {
+	ctx, cancel := context.WithTimeout(metadata.NewOutgoingContext(context.Background(), e.headers), exportTimeout)
+	defer cancel()
+	_, err := e.client.Export(ctx, plogotlp.NewExportRequestFromLogs(logs))
+	return err
+}
+
+func (e *gRPCClientExporter) shutdown() error {
+	return e.conn.Close()
+}
+
+type httpClientExporter struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+func newHTTPExporter(cfg *Config) exporter {
+	scheme := "https"
+	if cfg.Insecure {
+		scheme = "http"
+	}
+	path := cfg.HTTPPath
+	if path == "" {
+		path = defaultHTTPPath
+	}
+	return &httpClientExporter{
+		client:  &http.Client{Timeout: exportTimeout},
+		url:     fmt.Sprintf("%s://%s%s", scheme, cfg.OTLPEndpoint(), path),
+		headers: cfg.Headers,
+	}
+}
+
+func (e *httpClientExporter) export(logs plog.Logs) error {
+	body, err := plogotlp.NewExportRequestFromLogs(logs).MarshalProto()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to export logs to %s: %s", e.url, resp.Status)
+	}
+	return nil
+}
+
+func (e *httpClientExporter) shutdown() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/logs"
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// Start sets up the OTLP exporter and runs the test scenario.
+func Start(cfg *Config, logger *zap.Logger) error {
+	var exp exporter
+	var err error
+	if cfg.UseHTTP {
+		logger.Info("starting HTTP exporter")
+		exp = newHTTPExporter(cfg)
+	} else {
+		logger.Info("starting gRPC exporter")
+		exp, err = newGRPCExporter(cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to obtain OTLP exporter: %w", err)
+	}
+	defer func() {
+		logger.Info("stopping the exporter")
+		if tempError := exp.shutdown(); tempError != nil {
+			logger.Error("failed to stop the exporter", zap.Error(tempError))
+		}
+	}()
+
+	return Run(cfg, exp, logger)
+}
+
+// Run executes the test scenario.
+func Run(c *Config, exp exporter, logger *zap.Logger) error {
+	if c.TotalDuration > 0 {
+		c.NumLogs = 0
+	} else if c.NumLogs <= 0 {
+		return fmt.Errorf("either `logs` or `duration` must be greater than 0")
+	}
+	if c.SeverityNumber < 1 || c.SeverityNumber > 24 {
+		return fmt.Errorf("`severity-number` must be between 1 and 24")
+	}
+
+	limit := rate.Limit(c.Rate)
+	if c.Rate == 0 {
+		limit = rate.Inf
+		logger.Info("generation of logs isn't being throttled")
+	} else {
+		logger.Info("generation of logs is limited", zap.Float64("per-second", float64(limit)))
+	}
+
+	wg := sync.WaitGroup{}
+	running := atomic.NewBool(true)
+
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		w := worker{
+			numLogs:        c.NumLogs,
+			cfg:            c,
+			exporter:       exp,
+			limitPerSecond: limit,
+			totalDuration:  c.TotalDuration,
+			running:        running,
+			wg:             &wg,
+			logger:         logger.With(zap.Int("worker", i)),
+			index:          i,
+		}
+
+		go w.simulateLogs()
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		running.Store(false)
+	}
+	wg.Wait()
+	return nil
+}
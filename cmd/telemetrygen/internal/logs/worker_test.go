@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logs
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+func testConfig() *Config {
+	return &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+			ServiceName: "telemetrygen",
+		},
+		Body:           "the message",
+		SeverityText:   "Info",
+		SeverityNumber: 9,
+	}
+}
+
+func TestFixedNumberOfLogs(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := testConfig()
+	cfg.WorkerCount = 2
+	cfg.NumLogs = 5
+	cfg.AttributeCardinality = 2
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	logs := exp.getLogs()
+	require.Len(t, logs, 10)
+	rl := logs[0].ResourceLogs().At(0)
+	serviceName, ok := rl.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "telemetrygen", serviceName.Str())
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "the message", lr.Body().Str())
+	assert.Equal(t, plog.SeverityNumberInfo, lr.SeverityNumber())
+	_, ok = lr.Attributes().Get(common.AttributeKey)
+	assert.True(t, ok)
+}
+
+func TestRateOfLogs(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := testConfig()
+	cfg.Rate = 10
+	cfg.TotalDuration = time.Second / 2
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	// the minimum acceptable number of logs for the rate of 10/sec for half a second
+	assert.True(t, len(exp.getLogs()) >= 3, "there should have been 3 or more logs, had %d", len(exp.getLogs()))
+	// the maximum acceptable number of logs for the rate of 10/sec for half a second
+	assert.True(t, len(exp.getLogs()) <= 10, "there should have been less than 10 logs, had %d", len(exp.getLogs()))
+}
+
+func TestInvalidSeverityNumber(t *testing.T) {
+	cfg := testConfig()
+	cfg.NumLogs = 1
+	cfg.SeverityNumber = 25
+	assert.Error(t, Run(cfg, &mockExporter{}, zap.NewNop()))
+}
+
+func TestHTTPExporter(t *testing.T) {
+	var received plogotlp.ExportRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, defaultHTTPPath, r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		received = plogotlp.NewExportRequest()
+		assert.NoError(t, received.UnmarshalProto(body))
+	}))
+	defer srv.Close()
+
+	cfg := testConfig()
+	cfg.NumLogs = 1
+	cfg.UseHTTP = true
+	cfg.Insecure = true
+	cfg.Endpoint = strings.TrimPrefix(srv.URL, "http://")
+	cfg.Headers = common.KeyValue{"Authorization": "secret"}
+
+	exp := newHTTPExporter(cfg)
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+	require.NoError(t, exp.shutdown())
+
+	assert.Equal(t, 1, received.Logs().LogRecordCount())
+}
+
+var _ exporter = (*mockExporter)(nil)
+
+type mockExporter struct {
+	mu   sync.Mutex
+	logs []plog.Logs
+}
+
+func (m *mockExporter) export(logs plog.Logs) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.logs = append(m.logs, logs)
+	return nil
+}
+
+func (m *mockExporter) shutdown() error {
+	return nil
+}
+
+func (m *mockExporter) getLogs() []plog.Logs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.logs
+}
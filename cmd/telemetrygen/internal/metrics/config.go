@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+// metricType is the type of the generated metric.
+type metricType string
+
+const (
+	metricTypeGauge     metricType = "Gauge"
+	metricTypeSum       metricType = "Sum"
+	metricTypeHistogram metricType = "Histogram"
+)
+
+var _ pflag.Value = (*metricType)(nil)
+
+func (t *metricType) String() string {
+	return string(*t)
+}
+
+func (t *metricType) Set(s string) error {
+	switch metricType(s) {
+	case metricTypeGauge, metricTypeSum, metricTypeHistogram:
+		*t = metricType(s)
+		return nil
+	default:
+		return fmt.Errorf("metric type %q is not supported, must be one of %q, %q or %q", s, metricTypeGauge, metricTypeSum, metricTypeHistogram)
+	}
+}
+
+func (t *metricType) Type() string {
+	return "metricType"
+}
+
+// Config describes the test scenario.
+type Config struct {
+	common.Config
+	NumMetrics int
+	MetricType metricType
+}
+
+// Flags registers config flags.
+func (c *Config) Flags(fs *pflag.FlagSet) {
+	c.CommonFlags(fs)
+	fs.IntVar(&c.NumMetrics, "metrics", 1, "Number of metrics to generate in each worker (ignored if duration is provided)")
+
+	c.MetricType = metricTypeGauge
+	fs.Var(&c.MetricType, "metric-type", "Metric type enum. must be one of 'Gauge', 'Sum' or 'Histogram'")
+}
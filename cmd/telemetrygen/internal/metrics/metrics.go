@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+)
+
+// Start sets up the OTLP exporter and runs the test scenario.
+func Start(cfg *Config, logger *zap.Logger) error {
+	grpcExpOpt := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(cfg.OTLPEndpoint()),
+		otlpmetricgrpc.WithDialOption(
+			grpc.WithBlock(),
+		),
+	}
+
+	httpExpOpt := []otlpmetrichttp.Option{
+		otlpmetrichttp.WithEndpoint(cfg.OTLPEndpoint()),
+	}
+	if cfg.HTTPPath != "" {
+		httpExpOpt = append(httpExpOpt, otlpmetrichttp.WithURLPath(cfg.HTTPPath))
+	}
+
+	if cfg.Insecure {
+		grpcExpOpt = append(grpcExpOpt, otlpmetricgrpc.WithInsecure())
+		httpExpOpt = append(httpExpOpt, otlpmetrichttp.WithInsecure())
+	}
+
+	if len(cfg.Headers) > 0 {
+		grpcExpOpt = append(grpcExpOpt, otlpmetricgrpc.WithHeaders(cfg.Headers))
+		httpExpOpt = append(httpExpOpt, otlpmetrichttp.WithHeaders(cfg.Headers))
+	}
+
+	var exp sdkmetric.Exporter
+	var err error
+	if cfg.UseHTTP {
+		logger.Info("starting HTTP exporter")
+		exp, err = otlpmetrichttp.New(context.Background(), httpExpOpt...)
+	} else {
+		logger.Info("starting gRPC exporter")
+		exp, err = otlpmetricgrpc.New(context.Background(), grpcExpOpt...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to obtain OTLP exporter: %w", err)
+	}
+	defer func() {
+		logger.Info("stopping the exporter")
+		if tempError := exp.Shutdown(context.Background()); tempError != nil {
+			logger.Error("failed to stop the exporter", zap.Error(tempError))
+		}
+	}()
+
+	return Run(cfg, exp, logger)
+}
+
+// Run executes the test scenario.
+func Run(c *Config, exp sdkmetric.Exporter, logger *zap.Logger) error {
+	if c.TotalDuration > 0 {
+		c.NumMetrics = 0
+	} else if c.NumMetrics <= 0 {
+		return fmt.Errorf("either `metrics` or `duration` must be greater than 0")
+	}
+
+	limit := rate.Limit(c.Rate)
+	if c.Rate == 0 {
+		limit = rate.Inf
+		logger.Info("generation of metrics isn't being throttled")
+	} else {
+		logger.Info("generation of metrics is limited", zap.Float64("per-second", float64(limit)))
+	}
+
+	res := resource.NewWithAttributes(semconv.SchemaURL, c.ResourceAttributeSet()...)
+
+	wg := sync.WaitGroup{}
+	running := atomic.NewBool(true)
+
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		w := worker{
+			numMetrics:     c.NumMetrics,
+			metricType:     c.MetricType,
+			cfg:            &c.Config,
+			resource:       res,
+			exporter:       exp,
+			limitPerSecond: limit,
+			totalDuration:  c.TotalDuration,
+			running:        running,
+			wg:             &wg,
+			logger:         logger.With(zap.Int("worker", i)),
+			index:          i,
+		}
+
+		go w.simulateMetrics()
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		running.Store(false)
+	}
+	wg.Wait()
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/metrics"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type worker struct {
+	running        *atomic.Bool       // pointer to shared flag that indicates it's time to stop the test
+	metricType     metricType         // type of metric to generate
+	numMetrics     int                // how many metrics the worker has to generate (only when duration==0)
+	cfg            *common.Config     // shared settings, used to build the per-item attributes
+	resource       *resource.Resource // resource attached to the generated metrics
+	exporter       sdkmetric.Exporter // exporter the generated metrics are sent to
+	totalDuration  time.Duration      // how long to run the test for (overrides `numMetrics`)
+	limitPerSecond rate.Limit         // how many metrics per second to generate
+	wg             *sync.WaitGroup    // notify when done
+	logger         *zap.Logger
+	index          int // worker index
+}
+
+// histogramBounds are the explicit bucket boundaries of generated histograms.
+var histogramBounds = []float64{1, 2, 5, 10}
+
+// series holds the cumulative state of the points generated for one attribute set.
+type series struct {
+	sum       int64
+	histogram metricdata.HistogramDataPoint
+}
+
+func (w worker) simulateMetrics() {
+	limiter := rate.NewLimiter(w.limitPerSecond, 1)
+	startTime := time.Now()
+	scope := instrumentation.Scope{Name: "telemetrygen"}
+	workerAttr := attribute.Int("worker", w.index)
+
+	var i int
+	seriesByAttrs := make(map[attribute.Distinct]*series)
+	for w.running.Load() {
+		if err := limiter.Wait(context.Background()); err != nil {
+			w.logger.Fatal("limiter waited failed, retry", zap.Error(err))
+		}
+
+		attrs := []attribute.KeyValue{workerAttr}
+		if kv, ok := w.cfg.IndexAttribute(i); ok {
+			attrs = append(attrs, kv)
+		}
+		set := attribute.NewSet(attrs...)
+		s, ok := seriesByAttrs[set.Equivalent()]
+		if !ok {
+			s = &series{histogram: metricdata.HistogramDataPoint{
+				Attributes:   set,
+				StartTime:    startTime,
+				Bounds:       histogramBounds,
+				BucketCounts: make([]uint64, len(histogramBounds)+1),
+			}}
+			seriesByAttrs[set.Equivalent()] = s
+		}
+		now := time.Now()
+		// each generated point records a value between 0 and 10
+		value := int64(i % 11)
+
+		var data metricdata.Aggregation
+		switch w.metricType {
+		case metricTypeSum:
+			s.sum += value
+			data = metricdata.Sum[int64]{
+				Temporality: metricdata.CumulativeTemporality,
+				IsMonotonic: true,
+				DataPoints: []metricdata.DataPoint[int64]{{
+					Attributes: set,
+					StartTime:  startTime,
+					Time:       now,
+					Value:      s.sum,
+				}},
+			}
+		case metricTypeHistogram:
+			s.histogram.Count++
+			s.histogram.Sum += float64(value)
+			s.histogram.BucketCounts[bucketIndex(float64(value))]++
+			dp := s.histogram
+			dp.Time = now
+			dp.BucketCounts = append([]uint64(nil), s.histogram.BucketCounts...)
+			data = metricdata.Histogram{
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints:  []metricdata.HistogramDataPoint{dp},
+			}
+		default:
+			data = metricdata.Gauge[int64]{
+				DataPoints: []metricdata.DataPoint[int64]{{
+					Attributes: set,
+					Time:       now,
+					Value:      value,
+				}},
+			}
+		}
+
+		rm := metricdata.ResourceMetrics{
+			Resource: w.resource,
+			ScopeMetrics: []metricdata.ScopeMetrics{{
+				Scope: scope,
+				Metrics: []metricdata.Metrics{{
+					Name: "gen",
+					Data: data,
+				}},
+			}},
+		}
+		if err := w.exporter.Export(context.Background(), rm); err != nil {
+			w.logger.Fatal("exporter failed", zap.Error(err))
+		}
+
+		i++
+		if w.numMetrics != 0 {
+			if i >= w.numMetrics {
+				break
+			}
+		}
+	}
+	w.logger.Info("metrics generated", zap.Int("metrics", i))
+	w.wg.Done()
+}
+
+// bucketIndex returns the index of the histogram bucket value falls into.
+func bucketIndex(value float64) int {
+	for i, bound := range histogramBounds {
+		if value <= bound {
+			return i
+		}
+	}
+	return len(histogramBounds)
+}
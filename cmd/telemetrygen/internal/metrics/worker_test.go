@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+func TestFixedNumberOfMetrics(t *testing.T) {
+	for _, mt := range []metricType{metricTypeGauge, metricTypeSum, metricTypeHistogram} {
+		t.Run(string(mt), func(t *testing.T) {
+			// prepare
+			exp := &mockExporter{}
+			cfg := &Config{
+				Config: common.Config{
+					WorkerCount: 2,
+				},
+				NumMetrics: 5,
+				MetricType: mt,
+			}
+
+			// test
+			require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+			// verify
+			rms := exp.getMetrics()
+			require.Len(t, rms, 10)
+			for _, rm := range rms {
+				data := rm.ScopeMetrics[0].Metrics[0].Data
+				switch mt {
+				case metricTypeGauge:
+					assert.IsType(t, metricdata.Gauge[int64]{}, data)
+				case metricTypeSum:
+					assert.IsType(t, metricdata.Sum[int64]{}, data)
+				case metricTypeHistogram:
+					assert.IsType(t, metricdata.Histogram{}, data)
+				}
+			}
+		})
+	}
+}
+
+func TestCumulativeHistogram(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumMetrics: 3,
+		MetricType: metricTypeHistogram,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	rms := exp.getMetrics()
+	require.Len(t, rms, 3)
+	last := rms[2].ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram).DataPoints[0]
+	assert.Equal(t, uint64(3), last.Count)
+	assert.Equal(t, float64(0+1+2), last.Sum)
+	assert.Equal(t, []uint64{2, 1, 0, 0, 0}, last.BucketCounts)
+	// earlier points are not modified by later observations
+	first := rms[0].ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram).DataPoints[0]
+	assert.Equal(t, []uint64{1, 0, 0, 0, 0}, first.BucketCounts)
+}
+
+func TestCumulativeSumPerAttributeSet(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:          1,
+			AttributeCardinality: 2,
+		},
+		NumMetrics: 4,
+		MetricType: metricTypeSum,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	rms := exp.getMetrics()
+	require.Len(t, rms, 4)
+	var values []int64
+	for _, rm := range rms {
+		values = append(values, rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64]).DataPoints[0].Value)
+	}
+	// the points alternate between the two attribute sets, each accumulating its own values
+	assert.Equal(t, []int64{0, 1, 0 + 2, 1 + 3}, values)
+}
+
+func TestRateOfMetrics(t *testing.T) {
+	// prepare
+	exp := &mockExporter{}
+	cfg := &Config{
+		Config: common.Config{
+			Rate:          10,
+			TotalDuration: time.Second / 2,
+			WorkerCount:   1,
+		},
+		MetricType: metricTypeGauge,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, exp, zap.NewNop()))
+
+	// verify
+	// the minimum acceptable number of metrics for the rate of 10/sec for half a second
+	assert.True(t, len(exp.getMetrics()) >= 3, "there should have been 3 or more metrics, had %d", len(exp.getMetrics()))
+	// the maximum acceptable number of metrics for the rate of 10/sec for half a second
+	assert.True(t, len(exp.getMetrics()) <= 10, "there should have been less than 10 metrics, had %d", len(exp.getMetrics()))
+}
+
+func TestMetricTypeSet(t *testing.T) {
+	var mt metricType
+	assert.NoError(t, mt.Set("Sum"))
+	assert.Equal(t, metricTypeSum, mt)
+	assert.Error(t, mt.Set("Summary"))
+}
+
+var _ sdkmetric.Exporter = (*mockExporter)(nil)
+
+type mockExporter struct {
+	mu      sync.Mutex
+	metrics []metricdata.ResourceMetrics
+}
+
+func (m *mockExporter) Temporality(sdkmetric.InstrumentKind) metricdata.Temporality {
+	return metricdata.CumulativeTemporality
+}
+
+func (m *mockExporter) Aggregation(sdkmetric.InstrumentKind) aggregation.Aggregation {
+	return aggregation.Default{}
+}
+
+func (m *mockExporter) Export(_ context.Context, rm metricdata.ResourceMetrics) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = append(m.metrics, rm)
+	return nil
+}
+
+func (m *mockExporter) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (m *mockExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (m *mockExporter) getMetrics() []metricdata.ResourceMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.metrics
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/traces"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+)
+
+// Start sets up the OTLP exporter and the global tracer provider, and runs
+// the test scenario.
+func Start(cfg *Config, logger *zap.Logger) error {
+	grpcExpOpt := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint()),
+		otlptracegrpc.WithDialOption(
+			grpc.WithBlock(),
+		),
+	}
+
+	httpExpOpt := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(cfg.OTLPEndpoint()),
+	}
+	if cfg.HTTPPath != "" {
+		httpExpOpt = append(httpExpOpt, otlptracehttp.WithURLPath(cfg.HTTPPath))
+	}
+
+	if cfg.Insecure {
+		grpcExpOpt = append(grpcExpOpt, otlptracegrpc.WithInsecure())
+		httpExpOpt = append(httpExpOpt, otlptracehttp.WithInsecure())
+	}
+
+	if len(cfg.Headers) > 0 {
+		grpcExpOpt = append(grpcExpOpt, otlptracegrpc.WithHeaders(cfg.Headers))
+		httpExpOpt = append(httpExpOpt, otlptracehttp.WithHeaders(cfg.Headers))
+	}
+
+	var exp *otlptrace.Exporter
+	var err error
+	if cfg.UseHTTP {
+		logger.Info("starting HTTP exporter")
+		exp, err = otlptracehttp.New(context.Background(), httpExpOpt...)
+	} else {
+		logger.Info("starting gRPC exporter")
+		exp, err = otlptracegrpc.New(context.Background(), grpcExpOpt...)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to obtain OTLP exporter: %w", err)
+	}
+	defer func() {
+		logger.Info("stopping the exporter")
+		if tempError := exp.Shutdown(context.Background()); tempError != nil {
+			logger.Error("failed to stop the exporter", zap.Error(tempError))
+		}
+	}()
+
+	ssp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithBatchTimeout(time.Second))
+	defer func() {
+		logger.Info("stop the batch span processor")
+		if tempError := ssp.Shutdown(context.Background()); tempError != nil {
+			logger.Error("failed to stop the batch span processor", zap.Error(tempError))
+		}
+	}()
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, cfg.ResourceAttributeSet()...)),
+	)
+	tracerProvider.RegisterSpanProcessor(ssp)
+	otel.SetTracerProvider(tracerProvider)
+
+	return Run(cfg, logger)
+}
+
+// Run executes the test scenario.
+func Run(c *Config, logger *zap.Logger) error {
+	if c.TotalDuration > 0 {
+		c.NumTraces = 0
+	} else if c.NumTraces <= 0 {
+		return fmt.Errorf("either `traces` or `duration` must be greater than 0")
+	}
+	if c.SpanDepth < 1 {
+		return fmt.Errorf("`span-depth` must be at least 1")
+	}
+
+	limit := rate.Limit(c.Rate)
+	if c.Rate == 0 {
+		limit = rate.Inf
+		logger.Info("generation of traces isn't being throttled")
+	} else {
+		logger.Info("generation of traces is limited", zap.Float64("per-second", float64(limit)))
+	}
+
+	wg := sync.WaitGroup{}
+	running := atomic.NewBool(true)
+
+	for i := 0; i < c.WorkerCount; i++ {
+		wg.Add(1)
+		w := worker{
+			numTraces:        c.NumTraces,
+			propagateContext: c.PropagateContext,
+			spanDepth:        c.SpanDepth,
+			cfg:              &c.Config,
+			limitPerSecond:   limit,
+			totalDuration:    c.TotalDuration,
+			running:          running,
+			wg:               &wg,
+			logger:           logger.With(zap.Int("worker", i)),
+		}
+
+		go w.simulateTraces()
+	}
+	if c.TotalDuration > 0 {
+		time.Sleep(c.TotalDuration)
+		running.Store(false)
+	}
+	wg.Wait()
+	return nil
+}
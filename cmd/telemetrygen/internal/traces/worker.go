@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/traces"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+type worker struct {
+	running          *atomic.Bool    // pointer to shared flag that indicates it's time to stop the test
+	numTraces        int             // how many traces the worker has to generate (only when duration==0)
+	propagateContext bool            // whether the worker needs to propagate the trace context via HTTP headers
+	spanDepth        int             // how many nested spans each trace contains
+	cfg              *common.Config  // shared settings, used to build the per-item attributes
+	totalDuration    time.Duration   // how long to run the test for (overrides `numTraces`)
+	limitPerSecond   rate.Limit      // how many traces per second to generate
+	wg               *sync.WaitGroup // notify when done
+	logger           *zap.Logger
+}
+
+const (
+	fakeIP string = "1.2.3.4"
+
+	fakeSpanDuration = 123 * time.Microsecond
+)
+
+func (w worker) simulateTraces() {
+	tracer := otel.Tracer("telemetrygen")
+	limiter := rate.NewLimiter(w.limitPerSecond, 1)
+	var i int
+	for w.running.Load() {
+		attrs := []attribute.KeyValue{
+			attribute.String("span.kind", "client"), // is there a semantic convention for this?
+			semconv.NetPeerIPKey.String(fakeIP),
+			semconv.PeerServiceKey.String("telemetrygen-server"),
+		}
+		if kv, ok := w.cfg.IndexAttribute(i); ok {
+			attrs = append(attrs, kv)
+		}
+
+		ctx, root := tracer.Start(context.Background(), "lets-go", trace.WithAttributes(attrs...))
+		spans := []trace.Span{root}
+
+		if w.spanDepth > 1 && w.propagateContext {
+			header := propagation.HeaderCarrier{}
+			// simulates going remote
+			otel.GetTextMapPropagator().Inject(ctx, header)
+
+			// simulates getting a request from a client
+			ctx = otel.GetTextMapPropagator().Extract(ctx, header)
+		}
+
+		attrs[0] = attribute.String("span.kind", "server")
+		attrs[2] = semconv.PeerServiceKey.String("telemetrygen-client")
+		for depth := 1; depth < w.spanDepth; depth++ {
+			var child trace.Span
+			ctx, child = tracer.Start(ctx, "okey-dokey", trace.WithAttributes(attrs...))
+			spans = append(spans, child)
+		}
+
+		if err := limiter.Wait(context.Background()); err != nil {
+			w.logger.Fatal("limiter waited failed, retry", zap.Error(err))
+		}
+
+		// end the spans from the innermost one outwards
+		opt := trace.WithTimestamp(time.Now().Add(fakeSpanDuration))
+		for j := len(spans) - 1; j >= 0; j-- {
+			spans[j].End(opt)
+		}
+
+		i++
+		if w.numTraces != 0 {
+			if i >= w.numTraces {
+				break
+			}
+		}
+	}
+	w.logger.Info("traces generated", zap.Int("traces", i))
+	w.wg.Done()
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/telemetrygen/internal/common"
+)
+
+func setupSyncer() *mockSyncer {
+	syncer := &mockSyncer{}
+
+	tracerProvider := sdktrace.NewTracerProvider()
+	sp := sdktrace.NewSimpleSpanProcessor(syncer)
+	tracerProvider.RegisterSpanProcessor(sp)
+	otel.SetTracerProvider(tracerProvider)
+	return syncer
+}
+
+func TestFixedNumberOfTraces(t *testing.T) {
+	// prepare
+	syncer := setupSyncer()
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumTraces: 1,
+		SpanDepth: 2,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, zap.NewNop()))
+
+	// verify
+	assert.Len(t, syncer.getSpans(), 2) // each trace has two spans
+}
+
+func TestSpanDepth(t *testing.T) {
+	// prepare
+	syncer := setupSyncer()
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 2,
+		},
+		NumTraces:        3,
+		SpanDepth:        4,
+		PropagateContext: true,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, zap.NewNop()))
+
+	// verify
+	spans := syncer.getSpans()
+	require.Len(t, spans, 2*3*4)
+	byID := map[[8]byte]sdktrace.ReadOnlySpan{}
+	for _, span := range spans {
+		byID[span.SpanContext().SpanID()] = span
+	}
+	deepest := 0
+	for _, span := range spans {
+		depth := 1
+		for parent := span.Parent(); parent.IsValid(); parent = byID[parent.SpanID()].Parent() {
+			depth++
+		}
+		assert.LessOrEqual(t, depth, 4)
+		if depth == 4 {
+			deepest++
+		}
+	}
+	assert.Equal(t, 2*3, deepest) // one innermost span per trace
+}
+
+func TestAttributeCardinality(t *testing.T) {
+	// prepare
+	syncer := setupSyncer()
+
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount:          1,
+			AttributeCardinality: 3,
+		},
+		NumTraces: 10,
+		SpanDepth: 1,
+	}
+
+	// test
+	require.NoError(t, Run(cfg, zap.NewNop()))
+
+	// verify
+	values := map[string]struct{}{}
+	for _, span := range syncer.getSpans() {
+		for _, kv := range span.Attributes() {
+			if kv.Key == common.AttributeKey {
+				values[kv.Value.AsString()] = struct{}{}
+			}
+		}
+	}
+	assert.Len(t, values, 3)
+}
+
+func TestRateOfSpans(t *testing.T) {
+	// prepare
+	syncer := setupSyncer()
+
+	cfg := &Config{
+		Config: common.Config{
+			Rate:          10,
+			TotalDuration: time.Second / 2,
+			WorkerCount:   1,
+		},
+		SpanDepth: 2,
+	}
+
+	// sanity check
+	require.Len(t, syncer.getSpans(), 0)
+
+	// test
+	require.NoError(t, Run(cfg, zap.NewNop()))
+
+	// verify
+	// the minimum acceptable number of spans for the rate of 10/sec for half a second
+	assert.True(t, len(syncer.getSpans()) >= 6, "there should have been more than 6 spans, had %d", len(syncer.getSpans()))
+	// the maximum acceptable number of spans for the rate of 10/sec for half a second
+	assert.True(t, len(syncer.getSpans()) <= 20, "there should have been less than 20 spans, had %d", len(syncer.getSpans()))
+}
+
+func TestInvalidSpanDepth(t *testing.T) {
+	cfg := &Config{
+		Config: common.Config{
+			WorkerCount: 1,
+		},
+		NumTraces: 1,
+	}
+	assert.Error(t, Run(cfg, zap.NewNop()))
+}
+
+var _ sdktrace.SpanExporter = (*mockSyncer)(nil)
+
+type mockSyncer struct {
+	mu    sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (m *mockSyncer) ExportSpans(_ context.Context, spanData []sdktrace.ReadOnlySpan) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spans = append(m.spans, spanData...)
+	return nil
+}
+
+func (m *mockSyncer) Shutdown(context.Context) error {
+	panic("implement me")
+}
+
+func (m *mockSyncer) getSpans() []sdktrace.ReadOnlySpan {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.spans
+}
@@ -0,0 +1,545 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/ClickHouse/clickhouse-go/v2" // For register database driver.
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+)
+
+// metricsTableSuffixes maps every supported metric type to the suffix of the
+// table its data points are written to, e.g. `otel_metrics_gauge`.
+var metricsTableSuffixes = map[pmetric.MetricType]string{
+	pmetric.MetricTypeGauge:                "_gauge",
+	pmetric.MetricTypeSum:                  "_sum",
+	pmetric.MetricTypeHistogram:            "_histogram",
+	pmetric.MetricTypeExponentialHistogram: "_exponential_histogram",
+	pmetric.MetricTypeSummary:              "_summary",
+}
+
+type metricsExporter struct {
+	client     *sql.DB
+	insertSQLs map[pmetric.MetricType]string
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*metricsExporter, error) {
+
+	if err := createDatabase(cfg); err != nil {
+		return nil, err
+	}
+
+	client, err := newClickhouseClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = createMetricsTables(cfg, client); err != nil {
+		return nil, multierr.Append(err, client.Close())
+	}
+
+	return &metricsExporter{
+		client:     client,
+		insertSQLs: renderInsertMetricsSQLs(cfg),
+		logger:     logger,
+		cfg:        cfg,
+	}, nil
+}
+
+// Shutdown will shutdown the exporter.
+func (e *metricsExporter) Shutdown(_ context.Context) error {
+	if e.client != nil {
+		return e.client.Close()
+	}
+	return nil
+}
+
+func (e *metricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	start := time.Now()
+	err := doWithTx(ctx, e.client, func(tx *sql.Tx) error {
+		// statements are prepared lazily, so that a batch only touches the
+		// tables of the metric types it contains.
+		statements := make(map[pmetric.MetricType]*sql.Stmt, len(e.insertSQLs))
+		defer func() {
+			for _, statement := range statements {
+				_ = statement.Close()
+			}
+		}()
+		statementFor := func(metricType pmetric.MetricType) (*sql.Stmt, error) {
+			if statement, ok := statements[metricType]; ok {
+				return statement, nil
+			}
+			statement, err := tx.PrepareContext(ctx, e.insertSQLs[metricType])
+			if err != nil {
+				return nil, fmt.Errorf("PrepareContext:%w", err)
+			}
+			statements[metricType] = statement
+			return statement, nil
+		}
+
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			metrics := md.ResourceMetrics().At(i)
+			res := metrics.Resource()
+			var serviceName string
+			if v, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
+				serviceName = v.Str()
+			}
+			for j := 0; j < metrics.ScopeMetrics().Len(); j++ {
+				scopeMetrics := metrics.ScopeMetrics().At(j)
+				meta := metricMeta{
+					serviceName:  serviceName,
+					resAttr:      attributesToMap(res.Attributes()),
+					resURL:       metrics.SchemaUrl(),
+					scopeName:    scopeMetrics.Scope().Name(),
+					scopeVersion: scopeMetrics.Scope().Version(),
+					scopeAttr:    attributesToMap(scopeMetrics.Scope().Attributes()),
+					scopeURL:     scopeMetrics.SchemaUrl(),
+				}
+				rs := scopeMetrics.Metrics()
+				for k := 0; k < rs.Len(); k++ {
+					r := rs.At(k)
+					if _, ok := e.insertSQLs[r.Type()]; !ok {
+						e.logger.Debug("unsupported metric type", zap.String("name", r.Name()), zap.String("type", r.Type().String()))
+						continue
+					}
+					statement, err := statementFor(r.Type())
+					if err != nil {
+						return err
+					}
+					if err = insertMetric(ctx, statement, meta, r); err != nil {
+						return fmt.Errorf("ExecContext:%w", err)
+					}
+				}
+			}
+		}
+		return nil
+	})
+	duration := time.Since(start)
+	e.logger.Info("insert metrics", zap.Int("records", md.DataPointCount()),
+		zap.String("cost", duration.String()))
+	return err
+}
+
+// metricMeta holds the values shared by all data points of a scope.
+type metricMeta struct {
+	serviceName  string
+	resAttr      map[string]string
+	resURL       string
+	scopeName    string
+	scopeVersion string
+	scopeAttr    map[string]string
+	scopeURL     string
+}
+
+// values returns the leading insert arguments common to every metrics table.
+func (m metricMeta) values(metric pmetric.Metric, attrs pcommon.Map, startTime, timestamp pcommon.Timestamp) []interface{} {
+	return []interface{}{
+		m.resAttr,
+		m.resURL,
+		m.scopeName,
+		m.scopeVersion,
+		m.scopeAttr,
+		m.scopeURL,
+		m.serviceName,
+		metric.Name(),
+		metric.Description(),
+		metric.Unit(),
+		attributesToMap(attrs),
+		startTime.AsTime(),
+		timestamp.AsTime(),
+	}
+}
+
+func insertMetric(ctx context.Context, statement *sql.Stmt, meta metricMeta, metric pmetric.Metric) error {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			values := meta.values(metric, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp())
+			values = append(values, numberValue(dp), uint32(dp.Flags()))
+			values = append(values, convertExemplars(dp.Exemplars())...)
+			if _, err := statement.ExecContext(ctx, values...); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricTypeSum:
+		sum := metric.Sum()
+		dps := sum.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			values := meta.values(metric, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp())
+			values = append(values, numberValue(dp), uint32(dp.Flags()))
+			values = append(values, convertExemplars(dp.Exemplars())...)
+			values = append(values, int32(sum.AggregationTemporality()), sum.IsMonotonic())
+			if _, err := statement.ExecContext(ctx, values...); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricTypeHistogram:
+		histogram := metric.Histogram()
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			values := meta.values(metric, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp())
+			values = append(values,
+				dp.Count(),
+				dp.Sum(),
+				dp.BucketCounts().AsRaw(),
+				dp.ExplicitBounds().AsRaw(),
+			)
+			values = append(values, convertExemplars(dp.Exemplars())...)
+			values = append(values,
+				uint32(dp.Flags()),
+				dp.Min(),
+				dp.Max(),
+				int32(histogram.AggregationTemporality()),
+			)
+			if _, err := statement.ExecContext(ctx, values...); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := metric.ExponentialHistogram()
+		dps := histogram.DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			values := meta.values(metric, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp())
+			values = append(values,
+				dp.Count(),
+				dp.Sum(),
+				dp.Scale(),
+				dp.ZeroCount(),
+				dp.Positive().Offset(),
+				dp.Positive().BucketCounts().AsRaw(),
+				dp.Negative().Offset(),
+				dp.Negative().BucketCounts().AsRaw(),
+			)
+			values = append(values, convertExemplars(dp.Exemplars())...)
+			values = append(values,
+				uint32(dp.Flags()),
+				dp.Min(),
+				dp.Max(),
+				int32(histogram.AggregationTemporality()),
+			)
+			if _, err := statement.ExecContext(ctx, values...); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			quantiles, quantileValues := convertValueAtQuantiles(dp.QuantileValues())
+			values := meta.values(metric, dp.Attributes(), dp.StartTimestamp(), dp.Timestamp())
+			values = append(values,
+				dp.Count(),
+				dp.Sum(),
+				quantiles,
+				quantileValues,
+				uint32(dp.Flags()),
+			)
+			if _, err := statement.ExecContext(ctx, values...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// numberValue returns the value of a gauge or sum data point as a float.
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		return dp.DoubleValue()
+	case pmetric.NumberDataPointValueTypeInt:
+		return float64(dp.IntValue())
+	}
+	return 0
+}
+
+// convertExemplars returns the values of the Exemplars nested columns.
+func convertExemplars(exemplars pmetric.ExemplarSlice) []interface{} {
+	var (
+		attrs    []map[string]string
+		times    []time.Time
+		values   []float64
+		traceIDs []string
+		spanIDs  []string
+	)
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
+		attrs = append(attrs, attributesToMap(exemplar.FilteredAttributes()))
+		times = append(times, exemplar.Timestamp().AsTime())
+		switch exemplar.ValueType() {
+		case pmetric.ExemplarValueTypeInt:
+			values = append(values, float64(exemplar.IntValue()))
+		default:
+			values = append(values, exemplar.DoubleValue())
+		}
+		traceIDs = append(traceIDs, traceutil.TraceIDToHexOrEmptyString(exemplar.TraceID()))
+		spanIDs = append(spanIDs, traceutil.SpanIDToHexOrEmptyString(exemplar.SpanID()))
+	}
+	return []interface{}{attrs, times, values, traceIDs, spanIDs}
+}
+
+func convertValueAtQuantiles(valueAtQuantiles pmetric.SummaryDataPointValueAtQuantileSlice) ([]float64, []float64) {
+	var (
+		quantiles []float64
+		values    []float64
+	)
+	for i := 0; i < valueAtQuantiles.Len(); i++ {
+		value := valueAtQuantiles.At(i)
+		quantiles = append(quantiles, value.Quantile())
+		values = append(values, value.Value())
+	}
+	return quantiles, values
+}
+
+const (
+	// metricsCommonColumns are the leading columns of every metrics table.
+	// language=ClickHouse SQL
+	metricsCommonColumns = `
+     ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     ResourceSchemaUrl String CODEC(ZSTD(1)),
+     ScopeName String CODEC(ZSTD(1)),
+     ScopeVersion String CODEC(ZSTD(1)),
+     ScopeAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     ScopeSchemaUrl String CODEC(ZSTD(1)),
+     ServiceName LowCardinality(String) CODEC(ZSTD(1)),
+     MetricName String CODEC(ZSTD(1)),
+     MetricDescription String CODEC(ZSTD(1)),
+     MetricUnit String CODEC(ZSTD(1)),
+     Attributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     StartTimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),
+     TimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),`
+	// language=ClickHouse SQL
+	metricsExemplarsColumn = `
+     Exemplars Nested (
+         FilteredAttributes Map(LowCardinality(String), String),
+         TimeUnix DateTime64(9),
+         Value Float64,
+         TraceId String,
+         SpanId String
+     ) CODEC(ZSTD(1)),`
+	// language=ClickHouse SQL
+	metricsTableSettings = `
+     INDEX idx_res_attr_key mapKeys(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_res_attr_value mapValues(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_attr_key mapKeys(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_attr_value mapValues(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1
+) ENGINE MergeTree()
+%s
+PARTITION BY toDate(TimeUnix)
+ORDER BY (ServiceName, MetricName, Attributes, toUnixTimestamp64Nano(TimeUnix))
+SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
+`
+	metricsCommonInsertColumns = `ResourceAttributes,
+                        ResourceSchemaUrl,
+                        ScopeName,
+                        ScopeVersion,
+                        ScopeAttributes,
+                        ScopeSchemaUrl,
+                        ServiceName,
+                        MetricName,
+                        MetricDescription,
+                        MetricUnit,
+                        Attributes,
+                        StartTimeUnix,
+                        TimeUnix,`
+	metricsExemplarsInsertColumns = `
+                        Exemplars.FilteredAttributes,
+                        Exemplars.TimeUnix,
+                        Exemplars.Value,
+                        Exemplars.TraceId,
+                        Exemplars.SpanId`
+)
+
+const (
+	// language=ClickHouse SQL
+	createGaugeTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (` + metricsCommonColumns + `
+     Value Float64 CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),` + metricsExemplarsColumn + metricsTableSettings
+	// language=ClickHouse SQL
+	insertGaugeSQLTemplate = `INSERT INTO %s (
+                        ` + metricsCommonInsertColumns + `
+                        Value,
+                        Flags,` + metricsExemplarsInsertColumns + `
+                        ) VALUES (%s)`
+
+	// language=ClickHouse SQL
+	createSumTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (` + metricsCommonColumns + `
+     Value Float64 CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),` + metricsExemplarsColumn + `
+     AggTemp Int32 CODEC(ZSTD(1)),
+     IsMonotonic Boolean CODEC(Delta, ZSTD(1)),` + metricsTableSettings
+	// language=ClickHouse SQL
+	insertSumSQLTemplate = `INSERT INTO %s (
+                        ` + metricsCommonInsertColumns + `
+                        Value,
+                        Flags,` + metricsExemplarsInsertColumns + `,
+                        AggTemp,
+                        IsMonotonic
+                        ) VALUES (%s)`
+
+	// language=ClickHouse SQL
+	createHistogramTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (` + metricsCommonColumns + `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     BucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     ExplicitBounds Array(Float64) CODEC(ZSTD(1)),` + metricsExemplarsColumn + `
+     Flags UInt32 CODEC(ZSTD(1)),
+     Min Float64 CODEC(ZSTD(1)),
+     Max Float64 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),` + metricsTableSettings
+	// language=ClickHouse SQL
+	insertHistogramSQLTemplate = `INSERT INTO %s (
+                        ` + metricsCommonInsertColumns + `
+                        Count,
+                        Sum,
+                        BucketCounts,
+                        ExplicitBounds,` + metricsExemplarsInsertColumns + `,
+                        Flags,
+                        Min,
+                        Max,
+                        AggTemp
+                        ) VALUES (%s)`
+
+	// language=ClickHouse SQL
+	createExponentialHistogramTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (` + metricsCommonColumns + `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     Scale Int32 CODEC(ZSTD(1)),
+     ZeroCount UInt64 CODEC(ZSTD(1)),
+     PositiveOffset Int32 CODEC(ZSTD(1)),
+     PositiveBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     NegativeOffset Int32 CODEC(ZSTD(1)),
+     NegativeBucketCounts Array(UInt64) CODEC(ZSTD(1)),` + metricsExemplarsColumn + `
+     Flags UInt32 CODEC(ZSTD(1)),
+     Min Float64 CODEC(ZSTD(1)),
+     Max Float64 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),` + metricsTableSettings
+	// language=ClickHouse SQL
+	insertExponentialHistogramSQLTemplate = `INSERT INTO %s (
+                        ` + metricsCommonInsertColumns + `
+                        Count,
+                        Sum,
+                        Scale,
+                        ZeroCount,
+                        PositiveOffset,
+                        PositiveBucketCounts,
+                        NegativeOffset,
+                        NegativeBucketCounts,` + metricsExemplarsInsertColumns + `,
+                        Flags,
+                        Min,
+                        Max,
+                        AggTemp
+                        ) VALUES (%s)`
+
+	// language=ClickHouse SQL
+	createSummaryTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (` + metricsCommonColumns + `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     ValueAtQuantiles Nested(
+         Quantile Float64,
+         Value Float64
+     ) CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),` + metricsTableSettings
+	// language=ClickHouse SQL
+	insertSummarySQLTemplate = `INSERT INTO %s (
+                        ` + metricsCommonInsertColumns + `
+                        Count,
+                        Sum,
+                        ValueAtQuantiles.Quantile,
+                        ValueAtQuantiles.Value,
+                        Flags
+                        ) VALUES (%s)`
+)
+
+// metricsSQLTemplates holds, for every metric type, the create table template
+// and the insert template along with its number of columns.
+var metricsSQLTemplates = map[pmetric.MetricType]struct {
+	create  string
+	insert  string
+	columns int
+}{
+	pmetric.MetricTypeGauge:                {createGaugeTableSQL, insertGaugeSQLTemplate, 20},
+	pmetric.MetricTypeSum:                  {createSumTableSQL, insertSumSQLTemplate, 22},
+	pmetric.MetricTypeHistogram:            {createHistogramTableSQL, insertHistogramSQLTemplate, 26},
+	pmetric.MetricTypeExponentialHistogram: {createExponentialHistogramTableSQL, insertExponentialHistogramSQLTemplate, 30},
+	pmetric.MetricTypeSummary:              {createSummaryTableSQL, insertSummarySQLTemplate, 18},
+}
+
+func createMetricsTables(cfg *Config, db *sql.DB) error {
+	for metricType, sqlTemplate := range metricsSQLTemplates {
+		if _, err := db.Exec(renderCreateMetricsTableSQL(cfg, sqlTemplate.create, metricType)); err != nil {
+			return fmt.Errorf("exec create %s metrics table sql: %w", metricType, err)
+		}
+	}
+	return nil
+}
+
+func metricsTableName(cfg *Config, metricType pmetric.MetricType) string {
+	return cfg.MetricsTableName + metricsTableSuffixes[metricType]
+}
+
+func renderCreateMetricsTableSQL(cfg *Config, template string, metricType pmetric.MetricType) string {
+	var ttlExpr string
+	if cfg.TTLDays > 0 {
+		ttlExpr = fmt.Sprintf(`TTL toDateTime(TimeUnix) + toIntervalDay(%d)`, cfg.TTLDays)
+	}
+	return fmt.Sprintf(template, metricsTableName(cfg, metricType), ttlExpr)
+}
+
+func renderInsertMetricsSQLs(cfg *Config) map[pmetric.MetricType]string {
+	sqls := make(map[pmetric.MetricType]string, len(metricsSQLTemplates))
+	for metricType, sqlTemplate := range metricsSQLTemplates {
+		sqls[metricType] = fmt.Sprintf(sqlTemplate.insert, metricsTableName(cfg, metricType), placeholders(sqlTemplate.columns))
+	}
+	return sqls
+}
+
+// placeholders returns n comma separated `?` bind parameters.
+func placeholders(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	b := make([]byte, 0, 2*n-1)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, '?')
+	}
+	return string(b)
+}
@@ -0,0 +1,159 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExporter_pushMetricsData(t *testing.T) {
+	t.Run("push success", func(t *testing.T) {
+		items := map[string]int{}
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				table := strings.Fields(query)[2]
+				items[table]++
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+		mustPushMetricsData(t, exporter, simpleMetrics(2))
+
+		require.Equal(t, map[string]int{
+			"otel_metrics_gauge":                 3,
+			"otel_metrics_sum":                   3,
+			"otel_metrics_histogram":             3,
+			"otel_metrics_exponential_histogram": 3,
+			"otel_metrics_summary":               3,
+		}, items)
+	})
+	t.Run("values match columns", func(t *testing.T) {
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			require.Equal(t, strings.Count(query, "?"), len(values))
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+	})
+}
+
+func TestRenderCreateMetricsTableSQL(t *testing.T) {
+	cfg := withDefaultConfig()
+	for metricType, sqlTemplate := range metricsSQLTemplates {
+		query := renderCreateMetricsTableSQL(cfg, sqlTemplate.create, metricType)
+		require.Contains(t, query, "CREATE TABLE IF NOT EXISTS "+metricsTableName(cfg, metricType)+" (")
+		require.Contains(t, query, "TTL toDateTime(TimeUnix) + toIntervalDay(7)")
+	}
+
+	cfg.TTLDays = 0
+	query := renderCreateMetricsTableSQL(cfg, createGaugeTableSQL, pmetric.MetricTypeGauge)
+	require.NotContains(t, query, "TTL")
+}
+
+func TestRenderInsertMetricsSQLs(t *testing.T) {
+	cfg := withDefaultConfig()
+	for metricType, query := range renderInsertMetricsSQLs(cfg) {
+		columns := query[strings.Index(query, "(")+1 : strings.Index(query, ")")]
+		require.Equal(t, metricsSQLTemplates[metricType].columns, len(strings.Split(columns, ",")), metricType.String())
+	}
+}
+
+func newTestMetricsExporter(t *testing.T, dsn string, fns ...func(*Config)) *metricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+// simpleMetrics returns count data points of every supported metric type.
+func simpleMetrics(count int) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, "demo 1")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("Scope name 1")
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("gauge metrics")
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("sum metrics")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("histogram metrics")
+	expHistogram := sm.Metrics().AppendEmpty()
+	expHistogram.SetName("exponential histogram metrics")
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("summary metrics")
+
+	gaugeDps := gauge.SetEmptyGauge().DataPoints()
+	histogramDps := histogram.SetEmptyHistogram().DataPoints()
+	expHistogramDps := expHistogram.SetEmptyExponentialHistogram().DataPoints()
+	summaryDps := summary.SetEmptySummary().DataPoints()
+	for i := 0; i < count; i++ {
+		dp := gaugeDps.AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetIntValue(int64(i))
+		exemplar := dp.Exemplars().AppendEmpty()
+		exemplar.SetDoubleValue(1.5)
+		exemplar.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+		sdp := sum.Sum().DataPoints().AppendEmpty()
+		sdp.SetTimestamp(now)
+		sdp.SetDoubleValue(float64(i))
+
+		hdp := histogramDps.AppendEmpty()
+		hdp.SetTimestamp(now)
+		hdp.SetCount(2)
+		hdp.SetSum(3)
+		hdp.ExplicitBounds().FromRaw([]float64{1})
+		hdp.BucketCounts().FromRaw([]uint64{1, 1})
+
+		edp := expHistogramDps.AppendEmpty()
+		edp.SetTimestamp(now)
+		edp.SetCount(2)
+		edp.SetScale(1)
+		edp.Positive().SetOffset(1)
+		edp.Positive().BucketCounts().FromRaw([]uint64{1, 1})
+
+		qdp := summaryDps.AppendEmpty()
+		qdp.SetTimestamp(now)
+		qdp.SetCount(1)
+		qdp.SetSum(1)
+		quantile := qdp.QuantileValues().AppendEmpty()
+		quantile.SetQuantile(0.5)
+		quantile.SetValue(1)
+	}
+	return metrics
+}
+
+func mustPushMetricsData(t *testing.T, exporter *metricsExporter, md pmetric.Metrics) {
+	err := exporter.pushMetricsData(context.TODO(), md)
+	require.NoError(t, err)
+}
@@ -15,8 +15,10 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"fmt"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
 )
 
@@ -25,6 +27,9 @@ type routingKey int
 const (
 	traceIDRouting routingKey = iota
 	svcRouting
+	resourceRouting
+	metricNameRouting
+	spanRouting
 )
 
 // Config defines configuration for the exporter.
@@ -32,6 +37,32 @@ type Config struct {
 	Protocol   Protocol         `mapstructure:"protocol"`
 	Resolver   ResolverSettings `mapstructure:"resolver"`
 	RoutingKey string           `mapstructure:"routing_key"`
+
+	// RoutingAttributes are the names of the attributes whose values are used for routing, when routing by "attributes"
+	RoutingAttributes []string `mapstructure:"routing_attributes"`
+	// RoutingExpression is the OTTL expression whose value is used for routing, when routing by "ottl"
+	RoutingExpression string `mapstructure:"routing_expression"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks that the routing key is supported by any of the signals and that the settings it relies on
+// are specified. Whether the routing key is supported by the signal of the pipeline is checked on creation.
+func (cfg *Config) Validate() error {
+	switch cfg.RoutingKey {
+	case "", "traceID", "service", "resource", "metric":
+	case "attributes":
+		if len(cfg.RoutingAttributes) == 0 {
+			return errNoRoutingAttributes
+		}
+	case "ottl":
+		if cfg.RoutingExpression == "" {
+			return errNoRoutingExpression
+		}
+	default:
+		return fmt.Errorf("unsupported routing_key: %s", cfg.RoutingKey)
+	}
+	return nil
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -43,6 +74,7 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	K8sSvc *K8sSvcResolver `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -57,3 +89,10 @@ type DNSResolver struct {
 	Interval time.Duration `mapstructure:"interval"`
 	Timeout  time.Duration `mapstructure:"timeout"`
 }
+
+// K8sSvcResolver defines the configuration for the resolver watching the endpoints of a Kubernetes service
+type K8sSvcResolver struct {
+	// Service is the name of the service, optionally followed by its namespace, as in "lb-svc.observability"
+	Service string  `mapstructure:"service"`
+	Ports   []int32 `mapstructure:"ports"`
+}
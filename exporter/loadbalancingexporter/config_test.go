@@ -15,9 +15,11 @@
 package loadbalancingexporter
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -33,4 +35,70 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 	require.NotNil(t, cfg)
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(component.NewIDWithName(typeStr, "4").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	assert.Equal(t, &K8sSvcResolver{
+		Service: "lb-svc.kube-public",
+		Ports:   []int32{15317, 16317},
+	}, cfg.(*Config).Resolver.K8sSvc)
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(component.NewIDWithName(typeStr, "5").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	assert.Equal(t, "attributes", cfg.(*Config).RoutingKey)
+	assert.Equal(t, []string{"tenant.id", "k8s.pod.name"}, cfg.(*Config).RoutingAttributes)
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(component.NewIDWithName(typeStr, "6").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	assert.Equal(t, "ottl", cfg.(*Config).RoutingKey)
+	assert.Equal(t, `Concat([resource.attributes["tenant.id"], attributes["http.route"]], "/")`, cfg.(*Config).RoutingExpression)
+}
+
+func TestConfigValidate(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"default",
+			&Config{},
+			nil,
+		},
+		{
+			"metric",
+			&Config{RoutingKey: "metric"},
+			nil,
+		},
+		{
+			"attributes",
+			&Config{RoutingKey: "attributes", RoutingAttributes: []string{"tenant.id"}},
+			nil,
+		},
+		{
+			"attributes without routing attributes",
+			&Config{RoutingKey: "attributes"},
+			errNoRoutingAttributes,
+		},
+		{
+			"ottl without routing expression",
+			&Config{RoutingKey: "ottl"},
+			errNoRoutingExpression,
+		},
+		{
+			"unknown routing key",
+			&Config{RoutingKey: "span"},
+			errors.New("unsupported routing_key: span"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.err, tt.config.Validate())
+		})
+	}
 }
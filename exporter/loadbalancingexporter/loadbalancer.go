@@ -24,6 +24,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 const (
@@ -37,6 +39,9 @@ var (
 
 var _ loadBalancer = (*loadBalancerImp)(nil)
 
+// newK8sClient creates the client used by the k8s resolver, and can be overridden in tests
+var newK8sClient = k8sconfig.MakeClient
+
 type componentFactory func(ctx context.Context, endpoint string) (component.Component, error)
 
 type loadBalancer interface {
@@ -63,7 +68,7 @@ type loadBalancerImp struct {
 func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, factory componentFactory) (*loadBalancerImp, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Resolver.DNS != nil && oCfg.Resolver.Static != nil {
+	if countResolvers(oCfg.Resolver) > 1 {
 		return nil, errMultipleResolversProvided
 	}
 
@@ -85,6 +90,19 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 		}
 	}
 
+	if oCfg.Resolver.K8sSvc != nil {
+		k8sLogger := params.Logger.With(zap.String("resolver", "k8s service"))
+
+		clt, err := newK8sClient(k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount})
+		if err != nil {
+			return nil, err
+		}
+		res, err = newK8sResolver(clt, k8sLogger, oCfg.Resolver.K8sSvc.Service, oCfg.Resolver.K8sSvc.Ports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
@@ -97,6 +115,20 @@ func newLoadBalancer(params exporter.CreateSettings, cfg component.Config, facto
 	}, nil
 }
 
+func countResolvers(settings ResolverSettings) int {
+	count := 0
+	if settings.Static != nil {
+		count++
+	}
+	if settings.DNS != nil {
+		count++
+	}
+	if settings.K8sSvc != nil {
+		count++
+	}
+	return count
+}
+
 func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
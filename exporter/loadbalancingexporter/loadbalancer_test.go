@@ -26,6 +26,10 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 func TestNewLoadBalancerNoResolver(t *testing.T) {
@@ -154,6 +158,54 @@ func TestMultipleResolvers(t *testing.T) {
 	assert.Equal(t, errMultipleResolversProvided, err)
 }
 
+func TestWithK8sResolver(t *testing.T) {
+	// prepare
+	newK8sClient = func(k8sconfig.APIConfig) (kubernetes.Interface, error) {
+		return fake.NewSimpleClientset(newTestEndpoints("lb", "observability", "10.0.0.1")), nil
+	}
+	defer func() { newK8sClient = k8sconfig.MakeClient }()
+
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			K8sSvc: &K8sSvcResolver{
+				Service: "lb.observability",
+			},
+		},
+	}
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// test
+	res, ok := p.res.(*k8sResolver)
+
+	// verify
+	assert.NotNil(t, res)
+	assert.True(t, ok)
+	assert.Equal(t, "lb", res.svcName)
+	assert.Equal(t, "observability", res.svcNs)
+}
+
+func TestMultipleResolversK8s(t *testing.T) {
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			DNS: &DNSResolver{
+				Hostname: "service-1",
+			},
+			K8sSvc: &K8sSvcResolver{
+				Service: "lb.observability",
+			},
+		},
+	}
+
+	// test
+	p, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, nil)
+
+	// verify
+	assert.Nil(t, p)
+	assert.Equal(t, errMultipleResolversProvided, err)
+}
+
 func TestStartFailureStaticResolver(t *testing.T) {
 	// prepare
 	cfg := simpleConfig()
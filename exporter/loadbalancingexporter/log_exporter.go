@@ -38,6 +38,7 @@ var _ exporter.Logs = (*logExporterImp)(nil)
 
 type logExporterImp struct {
 	loadBalancer loadBalancer
+	logKey       logRoutingKeyFunc
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -55,9 +56,19 @@ func newLogsExporter(params exporter.CreateSettings, cfg component.Config) (*log
 		return nil, err
 	}
 
-	return &logExporterImp{
-		loadBalancer: lb,
-	}, nil
+	logExporter := logExporterImp{loadBalancer: lb}
+
+	switch cfg.(*Config).RoutingKey {
+	case "attributes", "ottl":
+		logExporter.logKey, err = newLogRoutingKeyFunc(cfg.(*Config), params.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+	case "traceID", "":
+	default:
+		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
+	}
+	return &logExporter, nil
 }
 
 func (e *logExporterImp) Capabilities() consumer.Capabilities {
@@ -75,6 +86,10 @@ func (e *logExporterImp) Shutdown(context.Context) error {
 }
 
 func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if e.logKey != nil {
+		return e.consumeLogsByLogKey(ctx, ld)
+	}
+
 	var errs error
 	batches := batchpersignal.SplitLogs(ld)
 	for _, batch := range batches {
@@ -95,6 +110,67 @@ func (e *logExporterImp) consumeLog(ctx context.Context, ld plog.Logs) error {
 	}
 
 	endpoint := e.loadBalancer.Endpoint(balancingKey[:])
+	return e.exportLogs(ctx, endpoint, ld)
+}
+
+// consumeLogsByLogKey routes each log record individually, by the key returned for it
+func (e *logExporterImp) consumeLogsByLogKey(ctx context.Context, ld plog.Logs) error {
+	var errs error
+	batches := make(map[string]plog.Logs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			// the destination scope logs in the batch of each endpoint
+			scopes := make(map[string]plog.ScopeLogs)
+			logs := sl.LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				log := logs.At(k)
+				key, err := e.logKey(ctx, log, sl.Scope(), rl.Resource())
+				if err != nil {
+					errs = multierr.Append(errs, err)
+					key = ""
+				}
+				if key == "" {
+					// log records without a routing key, including those for which it couldn't be
+					// determined, are routed as if no routing key was configured
+					balancingKey := log.TraceID()
+					if balancingKey == pcommon.NewTraceIDEmpty() {
+						balancingKey = random()
+					}
+					key = string(balancingKey[:])
+				}
+
+				endpoint := e.loadBalancer.Endpoint([]byte(key))
+				scope, ok := scopes[endpoint]
+				if !ok {
+					batch, ok := batches[endpoint]
+					if !ok {
+						batch = plog.NewLogs()
+						batches[endpoint] = batch
+					}
+					dstRl := batch.ResourceLogs().AppendEmpty()
+					rl.Resource().CopyTo(dstRl.Resource())
+					dstRl.SetSchemaUrl(rl.SchemaUrl())
+					scope = dstRl.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(scope.Scope())
+					scope.SetSchemaUrl(sl.SchemaUrl())
+					scopes[endpoint] = scope
+				}
+				log.CopyTo(scope.LogRecords().AppendEmpty())
+			}
+		}
+	}
+
+	for endpoint, batch := range batches {
+		errs = multierr.Append(errs, e.exportLogs(ctx, endpoint, batch))
+	}
+	return errs
+}
+
+func (e *logExporterImp) exportLogs(ctx context.Context, endpoint string, ld plog.Logs) error {
 	exp, err := e.loadBalancer.Exporter(endpoint)
 	if err != nil {
 		return err
@@ -48,6 +48,30 @@ func TestNewLogsExporter(t *testing.T) {
 			simpleConfig(),
 			nil,
 		},
+		{
+			"attributes without routing attributes",
+			&Config{
+				Resolver:   ResolverSettings{Static: &StaticResolver{Hostnames: []string{"endpoint-1"}}},
+				RoutingKey: "attributes",
+			},
+			errNoRoutingAttributes,
+		},
+		{
+			"ottl without routing expression",
+			&Config{
+				Resolver:   ResolverSettings{Static: &StaticResolver{Hostnames: []string{"endpoint-1"}}},
+				RoutingKey: "ottl",
+			},
+			errNoRoutingExpression,
+		},
+		{
+			"unsupported routing key",
+			&Config{
+				Resolver:   ResolverSettings{Static: &StaticResolver{Hostnames: []string{"endpoint-1"}}},
+				RoutingKey: "service",
+			},
+			errors.New("unsupported routing_key: service"),
+		},
 		{
 			"empty",
 			&Config{},
@@ -234,6 +258,65 @@ func TestLogBatchWithTwoTraces(t *testing.T) {
 	assert.Len(t, sink.AllLogs(), 2)
 }
 
+func TestConsumeLogsAttributeBased(t *testing.T) {
+	sinks := map[string]*consumertest.LogsSink{}
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		sinks[endpoint] = new(consumertest.LogsSink)
+		return newMockLogsExporter(sinks[endpoint].ConsumeLogs), nil
+	}
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2", "endpoint-3"}},
+		},
+		RoutingKey:        "attributes",
+		RoutingAttributes: []string{"k8s.pod.name"},
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newLogsExporter(exportertest.NewNopCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// the log streams of 20 pods, each with a couple of records
+	ld := plog.NewLogs()
+	for i := 0; i < 40; i++ {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("k8s.pod.name", fmt.Sprintf("pod-%d", i%20))
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(fmt.Sprintf("record-%d", i))
+	}
+
+	// test
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+
+	// verify
+	pods := map[string]string{}
+	var total int
+	for endpoint, sink := range sinks {
+		for _, received := range sink.AllLogs() {
+			total += received.LogRecordCount()
+			rls := received.ResourceLogs()
+			for i := 0; i < rls.Len(); i++ {
+				pod, _ := rls.At(i).Resource().Attributes().Get("k8s.pod.name")
+				if previous, ok := pods[pod.Str()]; ok {
+					assert.Equal(t, previous, endpoint)
+				}
+				pods[pod.Str()] = endpoint
+			}
+		}
+	}
+	assert.Len(t, pods, 20)
+	assert.Equal(t, 40, total)
+}
+
 func TestNoLogsInBatch(t *testing.T) {
 	for _, tt := range []struct {
 		desc  string
@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/pdatautil"
+)
+
+var _ exporter.Metrics = (*metricExporterImp)(nil)
+
+type metricExporterImp struct {
+	loadBalancer loadBalancer
+	routingKey   routingKey
+}
+
+// Create new metrics exporter
+func newMetricsExporter(params exporter.CreateSettings, cfg component.Config) (*metricExporterImp, error) {
+	exporterFactory := otlpexporter.NewFactory()
+
+	lb, err := newLoadBalancer(params, cfg, func(ctx context.Context, endpoint string) (component.Component, error) {
+		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
+		return exporterFactory.CreateMetricsExporter(ctx, params, &oCfg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metricExporter := metricExporterImp{loadBalancer: lb, routingKey: resourceRouting}
+
+	switch cfg.(*Config).RoutingKey {
+	case "service":
+		metricExporter.routingKey = svcRouting
+	case "metric":
+		metricExporter.routingKey = metricNameRouting
+	case "resource", "":
+	default:
+		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
+	}
+	return &metricExporter, nil
+}
+
+func (e *metricExporterImp) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	return e.loadBalancer.Start(ctx, host)
+}
+
+func (e *metricExporterImp) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *metricExporterImp) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var batches map[string]pmetric.Metrics
+	var err error
+	if e.routingKey == metricNameRouting {
+		batches = e.splitMetricsByStream(md)
+	} else {
+		batches, err = e.splitMetricsByResource(md)
+		if err != nil {
+			return err
+		}
+	}
+
+	var errs error
+	for endpoint, batch := range batches {
+		errs = multierr.Append(errs, e.consumeMetric(ctx, endpoint, batch))
+	}
+
+	return errs
+}
+
+func (e *metricExporterImp) consumeMetric(ctx context.Context, endpoint string, md pmetric.Metrics) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	me, ok := exp.(exporter.Metrics)
+	if !ok {
+		return fmt.Errorf("unable to export metrics, unexpected exporter type: expected exporter.Metrics but got %T", exp)
+	}
+
+	start := time.Now()
+	err = me.ConsumeMetrics(ctx, md)
+	duration := time.Since(start)
+	if err == nil {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	}
+
+	return err
+}
+
+// splitMetricsByResource groups the resource metrics by the endpoint their resource is routed to
+func (e *metricExporterImp) splitMetricsByResource(md pmetric.Metrics) (map[string]pmetric.Metrics, error) {
+	batches := make(map[string]pmetric.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		id, err := resourceIdentifier(rm.Resource(), e.routingKey)
+		if err != nil {
+			return nil, err
+		}
+
+		endpoint := e.loadBalancer.Endpoint(id)
+		batch, ok := batches[endpoint]
+		if !ok {
+			batch = pmetric.NewMetrics()
+			batches[endpoint] = batch
+		}
+		rm.CopyTo(batch.ResourceMetrics().AppendEmpty())
+	}
+	return batches, nil
+}
+
+// splitMetricsByStream groups the data points by the endpoint their stream, identified
+// by the metric name and the data point attributes, is routed to
+func (e *metricExporterImp) splitMetricsByStream(md pmetric.Metrics) map[string]pmetric.Metrics {
+	batches := make(map[string]pmetric.Metrics)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			// the destination scope metrics in the batch of each endpoint
+			scopes := make(map[string]pmetric.ScopeMetrics)
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				// the destination metric in the batch of each endpoint
+				metrics := make(map[string]pmetric.Metric)
+				target := func(attrs pcommon.Map) pmetric.Metric {
+					endpoint := e.loadBalancer.Endpoint(streamIdentifier(m.Name(), attrs))
+					if dst, ok := metrics[endpoint]; ok {
+						return dst
+					}
+					scope, ok := scopes[endpoint]
+					if !ok {
+						batch, ok := batches[endpoint]
+						if !ok {
+							batch = pmetric.NewMetrics()
+							batches[endpoint] = batch
+						}
+						dstRm := batch.ResourceMetrics().AppendEmpty()
+						rm.Resource().CopyTo(dstRm.Resource())
+						dstRm.SetSchemaUrl(rm.SchemaUrl())
+						scope = dstRm.ScopeMetrics().AppendEmpty()
+						sm.Scope().CopyTo(scope.Scope())
+						scope.SetSchemaUrl(sm.SchemaUrl())
+						scopes[endpoint] = scope
+					}
+					dst := scope.Metrics().AppendEmpty()
+					copyMetricDescriptor(m, dst)
+					metrics[endpoint] = dst
+					return dst
+				}
+
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).CopyTo(target(dps.At(l).Attributes()).Gauge().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricTypeSum:
+					dps := m.Sum().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).CopyTo(target(dps.At(l).Attributes()).Sum().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricTypeHistogram:
+					dps := m.Histogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).CopyTo(target(dps.At(l).Attributes()).Histogram().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := m.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).CopyTo(target(dps.At(l).Attributes()).ExponentialHistogram().DataPoints().AppendEmpty())
+					}
+				case pmetric.MetricTypeSummary:
+					dps := m.Summary().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).CopyTo(target(dps.At(l).Attributes()).Summary().DataPoints().AppendEmpty())
+					}
+				}
+			}
+		}
+	}
+	return batches
+}
+
+// copyMetricDescriptor copies everything but the data points of the metric
+func copyMetricDescriptor(src, dst pmetric.Metric) {
+	dst.SetName(src.Name())
+	dst.SetDescription(src.Description())
+	dst.SetUnit(src.Unit())
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		dst.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		sum := dst.SetEmptySum()
+		sum.SetAggregationTemporality(src.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		dst.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dst.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		dst.SetEmptySummary()
+	}
+}
+
+func resourceIdentifier(res pcommon.Resource, key routingKey) ([]byte, error) {
+	if key == svcRouting {
+		svc, ok := res.Attributes().Get(conventions.AttributeServiceName)
+		if !ok {
+			return nil, errors.New("unable to get service name")
+		}
+		return []byte(svc.Str()), nil
+	}
+	id := make([]byte, 8)
+	binary.BigEndian.PutUint64(id, pdatautil.MapHash(res.Attributes()))
+	return id, nil
+}
+
+func streamIdentifier(name string, attrs pcommon.Map) []byte {
+	id := make([]byte, len(name)+8)
+	copy(id, name)
+	binary.BigEndian.PutUint64(id[len(name):], pdatautil.MapHash(attrs))
+	return id
+}
@@ -0,0 +1,382 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			nil,
+		},
+		{
+			"metric",
+			metricRoutingConfig(),
+			nil,
+		},
+		{
+			"unsupported routing key",
+			&Config{
+				Resolver:   ResolverSettings{Static: &StaticResolver{Hostnames: []string{"endpoint-1"}}},
+				RoutingKey: "traceID",
+			},
+			errors.New("unsupported routing_key: traceID"),
+		},
+		{
+			"empty",
+			&Config{},
+			errNoResolver,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newMetricsExporter(exportertest.NewNopCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestMetricsExporterStart(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		me   *metricExporterImp
+		err  error
+	}{
+		{
+			"ok",
+			func() *metricExporterImp {
+				p, _ := newMetricsExporter(exportertest.NewNopCreateSettings(), simpleConfig())
+				return p
+			}(),
+			nil,
+		},
+		{
+			"error",
+			func() *metricExporterImp {
+				// prepare
+				lb, _ := newLoadBalancer(exportertest.NewNopCreateSettings(), simpleConfig(), nil)
+				p, _ := newMetricsExporter(exportertest.NewNopCreateSettings(), simpleConfig())
+
+				lb.res = &mockResolver{
+					onStart: func(context.Context) error {
+						return errors.New("some expected err")
+					},
+				}
+				p.loadBalancer = lb
+
+				return p
+			}(),
+			errors.New("some expected err"),
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := tt.me
+
+			// test
+			res := p.Start(context.Background(), componenttest.NewNopHost())
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			// verify
+			require.Equal(t, tt.err, res)
+		})
+	}
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newTestMetricsExporter(t, simpleConfig(), []string{"endpoint-1"}, func(string) consumer.ConsumeMetricsFunc {
+		return sink.ConsumeMetrics
+	})
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics())
+
+	// verify
+	assert.Nil(t, res)
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, simpleMetrics(), sink.AllMetrics()[0])
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.addMissingExporters(context.Background(), []string{"endpoint-1"})
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics())
+
+	// verify
+	assert.EqualError(t, res, fmt.Sprintf("unable to export metrics, unexpected exporter type: expected exporter.Metrics but got %T", newNopMockExporter()))
+}
+
+func TestConsumeMetricsResourceBased(t *testing.T) {
+	sinks := map[string]*consumertest.MetricsSink{}
+	p := newTestMetricsExporter(t, simpleConfig(), []string{"endpoint-1", "endpoint-2", "endpoint-3"}, func(endpoint string) consumer.ConsumeMetricsFunc {
+		sinks[endpoint] = new(consumertest.MetricsSink)
+		return sinks[endpoint].ConsumeMetrics
+	})
+
+	md := pmetric.NewMetrics()
+	for i := 0; i < 20; i++ {
+		appendSimpleMetrics(md, fmt.Sprintf("service-%d", i), "requests", "GET", "POST")
+	}
+
+	// test
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	// verify
+	services := map[string]string{}
+	var total int
+	for endpoint, sink := range sinks {
+		for _, received := range sink.AllMetrics() {
+			total += received.DataPointCount()
+			rms := received.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				// every resource is sent as a whole to a single endpoint
+				assert.Equal(t, 2, rms.At(i).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().Len())
+				svc, _ := rms.At(i).Resource().Attributes().Get(conventions.AttributeServiceName)
+				if previous, ok := services[svc.Str()]; ok {
+					assert.Equal(t, previous, endpoint)
+				}
+				services[svc.Str()] = endpoint
+			}
+		}
+	}
+	assert.Len(t, services, 20)
+	assert.Equal(t, 2*md.DataPointCount(), total)
+}
+
+func TestConsumeMetricsStreamBased(t *testing.T) {
+	sinks := map[string]*consumertest.MetricsSink{}
+	p := newTestMetricsExporter(t, metricRoutingConfig(), []string{"endpoint-1", "endpoint-2", "endpoint-3"}, func(endpoint string) consumer.ConsumeMetricsFunc {
+		sinks[endpoint] = new(consumertest.MetricsSink)
+		return sinks[endpoint].ConsumeMetrics
+	})
+
+	md := pmetric.NewMetrics()
+	methods := make([]string, 20)
+	for i := range methods {
+		methods[i] = fmt.Sprintf("method-%d", i)
+	}
+	appendSimpleMetrics(md, "service-1", "requests", methods...)
+	appendSimpleMetrics(md, "service-2", "requests", methods...)
+
+	// test
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+
+	// verify
+	streams := map[string]string{}
+	var total int
+	for endpoint, sink := range sinks {
+		for _, received := range sink.AllMetrics() {
+			total += received.DataPointCount()
+			rms := received.ResourceMetrics()
+			for i := 0; i < rms.Len(); i++ {
+				m := rms.At(i).ScopeMetrics().At(0).Metrics().At(0)
+				assert.Equal(t, "requests", m.Name())
+				assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+				assert.True(t, m.Sum().IsMonotonic())
+				dps := m.Sum().DataPoints()
+				for j := 0; j < dps.Len(); j++ {
+					method, _ := dps.At(j).Attributes().Get("method")
+					// the same stream of different resources is sent to the same endpoint
+					if previous, ok := streams[method.Str()]; ok {
+						assert.Equal(t, previous, endpoint)
+					}
+					streams[method.Str()] = endpoint
+				}
+			}
+		}
+	}
+	assert.Len(t, streams, 20)
+	assert.Equal(t, md.DataPointCount(), total)
+	assert.Greater(t, len(sinks), 1)
+	for _, sink := range sinks {
+		assert.NotEmpty(t, sink.AllMetrics())
+	}
+}
+
+func TestConsumeMetricsServiceBasedWithoutServiceName(t *testing.T) {
+	cfg := simpleConfig()
+	cfg.RoutingKey = "service"
+	p := newTestMetricsExporter(t, cfg, []string{"endpoint-1"}, func(string) consumer.ConsumeMetricsFunc {
+		return new(consumertest.MetricsSink).ConsumeMetrics
+	})
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), md)
+
+	// verify
+	assert.EqualError(t, res, "unable to get service name")
+}
+
+func TestCopyMetricDescriptor(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		src  func(pmetric.Metric)
+	}{
+		{"gauge", func(m pmetric.Metric) { m.SetEmptyGauge() }},
+		{"sum", func(m pmetric.Metric) {
+			m.SetEmptySum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			m.Sum().SetIsMonotonic(true)
+		}},
+		{"histogram", func(m pmetric.Metric) {
+			m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		}},
+		{"exponential histogram", func(m pmetric.Metric) {
+			m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		}},
+		{"summary", func(m pmetric.Metric) { m.SetEmptySummary() }},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			src := pmetric.NewMetric()
+			src.SetName("name")
+			src.SetDescription("description")
+			src.SetUnit("1")
+			tt.src(src)
+
+			// test
+			dst := pmetric.NewMetric()
+			copyMetricDescriptor(src, dst)
+
+			// verify
+			assert.Equal(t, src, dst)
+		})
+	}
+}
+
+// newTestMetricsExporter creates a started metrics exporter balancing across the given endpoints,
+// whose exporters consume the metrics with the function returned by consumeFn for each endpoint
+func newTestMetricsExporter(t *testing.T, cfg *Config, endpoints []string, consumeFn func(endpoint string) consumer.ConsumeMetricsFunc) *metricExporterImp {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newMockMetricsExporter(consumeFn(endpoint)), nil
+	}
+	cfg.Resolver = ResolverSettings{Static: &StaticResolver{Hostnames: endpoints}}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(exportertest.NewNopCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	})
+	return p
+}
+
+func simpleMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	appendSimpleMetrics(md, "service-name-1", "requests", "GET")
+	return md
+}
+
+// appendSimpleMetrics appends a resource with a cumulative sum, with one data point for each method
+func appendSimpleMetrics(md pmetric.Metrics, service string, name string, methods ...string) {
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, service)
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	for i, method := range methods {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("method", method)
+		dp.SetIntValue(int64(i))
+	}
+}
+
+func metricRoutingConfig() *Config {
+	return &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1"}},
+		},
+		RoutingKey: "metric",
+	}
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	ConsumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pmetric.Metrics) error) exporter.Metrics {
+	return &mockMetricsExporter{
+		Component:        mockComponent{},
+		ConsumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.ConsumeMetricsFn == nil {
+		return nil
+	}
+	return e.ConsumeMetricsFn(ctx, md)
+}
@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace     = "default"
+	defaultK8sSyncTimeout   = 10 * time.Second
+	defaultK8sResyncPeriod  = 0 // rely on watch events only
+	k8sServiceNameSeparator = "."
+)
+
+var (
+	errNoSvc = errors.New("no service specified to resolve the backends")
+
+	k8sResolverMutator = tag.Upsert(tag.MustNewKey("resolver"), "k8s")
+
+	k8sResolverSuccessTrueMutators  = []tag.Mutator{k8sResolverMutator, successTrueMutator}
+	k8sResolverSuccessFalseMutators = []tag.Mutator{k8sResolverMutator, successFalseMutator}
+)
+
+type k8sResolver struct {
+	logger *zap.Logger
+
+	svcName string
+	svcNs   string
+	ports   []int32
+
+	informer    cache.SharedInformer
+	syncTimeout time.Duration
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	stopped            bool
+	stopLock           sync.Mutex
+	updateLock         sync.RWMutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+}
+
+func newK8sResolver(clt kubernetes.Interface, logger *zap.Logger, service string, ports []int32) (*k8sResolver, error) {
+	if len(service) == 0 {
+		return nil, errNoSvc
+	}
+
+	nAddr := strings.SplitN(service, k8sServiceNameSeparator, 2)
+	name, namespace := nAddr[0], defaultK8sNamespace
+	if len(nAddr) > 1 && nAddr[1] != "" {
+		namespace = nAddr[1]
+	}
+
+	if len(ports) == 0 {
+		port, err := strconv.ParseInt(defaultPort, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		ports = []int32{int32(port)}
+	}
+
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	listWatcher := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return clt.CoreV1().Endpoints(namespace).List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return clt.CoreV1().Endpoints(namespace).Watch(context.Background(), options)
+		},
+	}
+
+	r := &k8sResolver{
+		logger:      logger,
+		svcName:     name,
+		svcNs:       namespace,
+		ports:       ports,
+		informer:    cache.NewSharedInformer(listWatcher, &corev1.Endpoints{}, defaultK8sResyncPeriod),
+		syncTimeout: defaultK8sSyncTimeout,
+		stopCh:      make(chan struct{}),
+	}
+	_, err := r.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    r.onEndpointsChange,
+		UpdateFunc: func(_, obj interface{}) { r.onEndpointsChange(obj) },
+		DeleteFunc: r.onEndpointsDelete,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *k8sResolver) start(ctx context.Context) error {
+	go r.informer.Run(r.stopCh)
+
+	ctx, cancel := context.WithTimeout(ctx, r.syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), r.informer.HasSynced) {
+		_ = stats.RecordWithTags(context.Background(), k8sResolverSuccessFalseMutators, mNumResolutions.M(1))
+		r.logger.Warn("timed out waiting for the endpoints of the service to be synced",
+			zap.String("service", r.svcName), zap.String("namespace", r.svcNs))
+	}
+
+	r.logger.Debug("K8s service resolver started",
+		zap.String("service", r.svcName), zap.String("namespace", r.svcNs),
+		zap.Int32s("ports", r.ports))
+	return nil
+}
+
+func (r *k8sResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	// updates are no longer tracked once stopped, so that
+	// none of them is added to the wait group while waiting
+	r.stopLock.Lock()
+	if !r.stopped {
+		r.stopped = true
+		close(r.stopCh)
+	}
+	r.stopLock.Unlock()
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *k8sResolver) resolve(ctx context.Context) ([]string, error) {
+	r.updateLock.RLock()
+	defer r.updateLock.RUnlock()
+	return r.endpoints, nil
+}
+
+func (r *k8sResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
+
+func (r *k8sResolver) onEndpointsChange(obj interface{}) {
+	endpoints, ok := r.watchedEndpoints(obj)
+	if !ok {
+		return
+	}
+
+	var backends []string
+	for _, subset := range endpoints.Subsets {
+		for _, addr := range subset.Addresses {
+			for _, port := range r.ports {
+				backends = append(backends, net.JoinHostPort(addr.IP, strconv.FormatInt(int64(port), 10)))
+			}
+		}
+	}
+	r.update(context.Background(), backends)
+}
+
+func (r *k8sResolver) onEndpointsDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if _, ok := r.watchedEndpoints(obj); ok {
+		r.update(context.Background(), nil)
+	}
+}
+
+// watchedEndpoints returns the endpoints of the watched service, if obj holds them
+func (r *k8sResolver) watchedEndpoints(obj interface{}) (*corev1.Endpoints, bool) {
+	endpoints, ok := obj.(*corev1.Endpoints)
+	if !ok {
+		r.logger.Warn("unexpected object received by the k8s resolver", zap.String("type", fmt.Sprintf("%T", obj)))
+		return nil, false
+	}
+	// the field selector of the watch should already exclude other services
+	return endpoints, endpoints.Name == r.svcName && endpoints.Namespace == r.svcNs
+}
+
+// update replaces the list of backends, propagating it to the callbacks if it has changed
+func (r *k8sResolver) update(ctx context.Context, backends []string) {
+	r.stopLock.Lock()
+	if r.stopped {
+		r.stopLock.Unlock()
+		return
+	}
+	r.shutdownWg.Add(1)
+	r.stopLock.Unlock()
+	defer r.shutdownWg.Done()
+
+	_ = stats.RecordWithTags(ctx, k8sResolverSuccessTrueMutators, mNumResolutions.M(1))
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	r.updateLock.Lock()
+	if equalStringSlice(r.endpoints, backends) {
+		r.updateLock.Unlock()
+		return
+	}
+	r.endpoints = backends
+	r.updateLock.Unlock()
+	_ = stats.RecordWithTags(ctx, k8sResolverSuccessTrueMutators, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+}
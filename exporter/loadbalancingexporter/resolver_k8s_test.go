@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestEndpoints(name, namespace string, ips ...string) *corev1.Endpoints {
+	addresses := make([]corev1.EndpointAddress, 0, len(ips))
+	for _, ip := range ips {
+		addresses = append(addresses, corev1.EndpointAddress{IP: ip})
+	}
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Subsets: []corev1.EndpointSubset{{Addresses: addresses}},
+	}
+}
+
+// resolvedEndpoints records the endpoints propagated by a resolver
+type resolvedEndpoints struct {
+	mu        sync.Mutex
+	endpoints []string
+}
+
+func (r *resolvedEndpoints) onChange(endpoints []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endpoints = endpoints
+}
+
+func (r *resolvedEndpoints) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.endpoints
+}
+
+func TestK8sResolve(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(
+		newTestEndpoints("lb", "observability", "10.0.0.2", "10.0.0.1"),
+		newTestEndpoints("lb", "default", "10.0.1.1"),
+	)
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb.observability", []int32{4317, 4318})
+	require.NoError(t, err)
+
+	resolved := &resolvedEndpoints{}
+	res.onChange(resolved.onChange)
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// verify
+	expected := []string{"10.0.0.1:4317", "10.0.0.1:4318", "10.0.0.2:4317", "10.0.0.2:4318"}
+	assert.Equal(t, expected, resolved.get())
+	endpoints, err := res.resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expected, endpoints)
+}
+
+func TestK8sResolveOnChange(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(newTestEndpoints("lb", "default", "10.0.0.1"))
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+
+	resolved := &resolvedEndpoints{}
+	res.onChange(resolved.onChange)
+
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+	assert.Equal(t, []string{"10.0.0.1:4317"}, resolved.get())
+
+	// test
+	_, err = clt.CoreV1().Endpoints("default").Update(context.Background(),
+		newTestEndpoints("lb", "default", "10.0.0.1", "10.0.0.3", "fd00::1"), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"10.0.0.1:4317", "10.0.0.3:4317", "[fd00::1]:4317"}, resolved.get())
+	}, time.Second, 10*time.Millisecond)
+
+	// test
+	require.NoError(t, clt.CoreV1().Endpoints("default").Delete(context.Background(), "lb", metav1.DeleteOptions{}))
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return len(resolved.get()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestK8sResolveOtherServicesIgnored(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(newTestEndpoints("lb", "default", "10.0.0.1"))
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb.default", nil)
+	require.NoError(t, err)
+
+	var callbacks int
+	var mu sync.Mutex
+	res.onChange(func([]string) {
+		mu.Lock()
+		defer mu.Unlock()
+		callbacks++
+	})
+
+	require.NoError(t, res.start(context.Background()))
+	defer func() {
+		require.NoError(t, res.shutdown(context.Background()))
+	}()
+
+	// test
+	_, err = clt.CoreV1().Endpoints("default").Create(context.Background(),
+		newTestEndpoints("other", "default", "10.0.0.2"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// verify
+	time.Sleep(100 * time.Millisecond)
+	endpoints, err := res.resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1:4317"}, endpoints)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, callbacks)
+}
+
+func TestK8sResolverShutdownTwice(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(newTestEndpoints("lb", "default", "10.0.0.1"))
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+	require.NoError(t, res.start(context.Background()))
+
+	// test and verify
+	require.NoError(t, res.shutdown(context.Background()))
+	assert.NotPanics(t, func() {
+		assert.NoError(t, res.shutdown(context.Background()))
+	})
+}
+
+func TestK8sResolverUpdateDuringShutdown(t *testing.T) {
+	// prepare
+	clt := fake.NewSimpleClientset(newTestEndpoints("lb", "default", "10.0.0.1"))
+	res, err := newK8sResolver(clt, zap.NewNop(), "lb", nil)
+	require.NoError(t, err)
+	require.NoError(t, res.start(context.Background()))
+
+	// test
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res.onEndpointsChange(newTestEndpoints("lb", "default", "10.0.0.2"))
+		}()
+	}
+	require.NoError(t, res.shutdown(context.Background()))
+	wg.Wait()
+
+	// verify that the updates received once stopped are ignored
+	res.onEndpointsChange(newTestEndpoints("lb", "default", "10.0.0.3"))
+	endpoints, err := res.resolve(context.Background())
+	require.NoError(t, err)
+	assert.NotContains(t, endpoints, "10.0.0.3:4317")
+}
+
+func TestErrNoSvc(t *testing.T) {
+	// test
+	res, err := newK8sResolver(fake.NewSimpleClientset(), zap.NewNop(), "", nil)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoSvc, err)
+}
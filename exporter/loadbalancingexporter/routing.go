@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+const (
+	// routingFunctionName is the name of the function wrapping the routing expression into an OTTL statement
+	routingFunctionName = "route_by"
+	// routingKeySeparator separates the values of the routing attributes in the routing key
+	routingKeySeparator = "\x00"
+)
+
+var (
+	errNoRoutingAttributes = errors.New("routing_attributes must be specified when routing by attributes")
+	errNoRoutingExpression = errors.New("routing_expression must be specified when routing by an OTTL expression")
+)
+
+// spanRoutingKeyFunc returns the key a span is routed by, or an empty key if it can't be determined
+type spanRoutingKeyFunc func(ctx context.Context, span ptrace.Span, scope pcommon.InstrumentationScope, resource pcommon.Resource) (string, error)
+
+// logRoutingKeyFunc returns the key a log record is routed by, or an empty key if it can't be determined
+type logRoutingKeyFunc func(ctx context.Context, log plog.LogRecord, scope pcommon.InstrumentationScope, resource pcommon.Resource) (string, error)
+
+func newSpanRoutingKeyFunc(cfg *Config, settings component.TelemetrySettings) (spanRoutingKeyFunc, error) {
+	if cfg.RoutingKey == "attributes" {
+		if len(cfg.RoutingAttributes) == 0 {
+			return nil, errNoRoutingAttributes
+		}
+		return func(_ context.Context, span ptrace.Span, _ pcommon.InstrumentationScope, resource pcommon.Resource) (string, error) {
+			return attributesRoutingKey(cfg.RoutingAttributes, span.Attributes(), resource.Attributes()), nil
+		}, nil
+	}
+
+	statement, err := parseRoutingExpression(ottlspan.NewParser(routingFunctions[ottlspan.TransformContext](), settings), cfg.RoutingExpression)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, span ptrace.Span, scope pcommon.InstrumentationScope, resource pcommon.Resource) (string, error) {
+		return evalRoutingExpression(ctx, statement, ottlspan.NewTransformContext(span, scope, resource))
+	}, nil
+}
+
+func newLogRoutingKeyFunc(cfg *Config, settings component.TelemetrySettings) (logRoutingKeyFunc, error) {
+	if cfg.RoutingKey == "attributes" {
+		if len(cfg.RoutingAttributes) == 0 {
+			return nil, errNoRoutingAttributes
+		}
+		return func(_ context.Context, log plog.LogRecord, _ pcommon.InstrumentationScope, resource pcommon.Resource) (string, error) {
+			return attributesRoutingKey(cfg.RoutingAttributes, log.Attributes(), resource.Attributes()), nil
+		}, nil
+	}
+
+	statement, err := parseRoutingExpression(ottllog.NewParser(routingFunctions[ottllog.TransformContext](), settings), cfg.RoutingExpression)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, log plog.LogRecord, scope pcommon.InstrumentationScope, resource pcommon.Resource) (string, error) {
+		return evalRoutingExpression(ctx, statement, ottllog.NewTransformContext(log, scope, resource))
+	}, nil
+}
+
+// attributesRoutingKey joins the values of the given attributes, which are looked up in the attributes
+// of the record first and of the resource second. The key is empty if none of the attributes is found.
+func attributesRoutingKey(names []string, attrs pcommon.Map, resourceAttrs pcommon.Map) string {
+	found := false
+	values := make([]string, len(names))
+	for i, name := range names {
+		v, ok := attrs.Get(name)
+		if !ok {
+			v, ok = resourceAttrs.Get(name)
+		}
+		if ok {
+			values[i] = v.AsString()
+			found = true
+		}
+	}
+	if !found {
+		return ""
+	}
+	return strings.Join(values, routingKeySeparator)
+}
+
+func routingFunctions[K any]() map[string]interface{} {
+	return map[string]interface{}{
+		routingFunctionName: routeBy[K],
+		"Concat":            ottlfuncs.Concat[K],
+		"ConvertCase":       ottlfuncs.ConvertCase[K],
+		"Int":               ottlfuncs.Int[K],
+		"IsMatch":           ottlfuncs.IsMatch[K],
+		"Substring":         ottlfuncs.Substring[K],
+	}
+}
+
+// routeBy returns the value of the routing expression
+func routeBy[K any](target ottl.Getter[K]) (ottl.ExprFunc[K], error) {
+	return func(ctx context.Context, tCtx K) (interface{}, error) {
+		return target.Get(ctx, tCtx)
+	}, nil
+}
+
+// parseRoutingExpression wraps the expression into a statement invoking the routing function
+func parseRoutingExpression[K any](parser ottl.Parser[K], expression string) (*ottl.Statement[K], error) {
+	if expression == "" {
+		return nil, errNoRoutingExpression
+	}
+	statements, err := parser.ParseStatements([]string{fmt.Sprintf("%s(%s)", routingFunctionName, expression)})
+	if err != nil {
+		return nil, fmt.Errorf("invalid routing_expression: %w", err)
+	}
+	return statements[0], nil
+}
+
+func evalRoutingExpression[K any](ctx context.Context, statement *ottl.Statement[K], tCtx K) (string, error) {
+	value, _, err := statement.Execute(ctx, tCtx)
+	if err != nil {
+		return "", err
+	}
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
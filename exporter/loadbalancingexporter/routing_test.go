@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestAttributesRoutingKey(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("tenant.id", "acme")
+	attrs.PutInt("shard", 3)
+	resourceAttrs := pcommon.NewMap()
+	resourceAttrs.PutStr("tenant.id", "ignored")
+	resourceAttrs.PutStr("k8s.pod.name", "pod-1")
+
+	for _, tt := range []struct {
+		desc     string
+		names    []string
+		expected string
+	}{
+		{"record attribute", []string{"tenant.id"}, "acme"},
+		{"resource attribute", []string{"k8s.pod.name"}, "pod-1"},
+		{"non-string attribute", []string{"shard"}, "3"},
+		{"multiple attributes", []string{"tenant.id", "k8s.pod.name"}, "acme\x00pod-1"},
+		{"some attributes missing", []string{"missing", "tenant.id"}, "\x00acme"},
+		{"all attributes missing", []string{"missing"}, ""},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, attributesRoutingKey(tt.names, attrs, resourceAttrs))
+		})
+	}
+}
+
+func TestNewRoutingKeyFuncErrors(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    string
+	}{
+		{
+			"no attributes",
+			&Config{RoutingKey: "attributes"},
+			errNoRoutingAttributes.Error(),
+		},
+		{
+			"no expression",
+			&Config{RoutingKey: "ottl"},
+			errNoRoutingExpression.Error(),
+		},
+		{
+			"invalid expression",
+			&Config{RoutingKey: "ottl", RoutingExpression: `attributes["tenant.id"`},
+			"invalid routing_expression",
+		},
+		{
+			"unknown function",
+			&Config{RoutingKey: "ottl", RoutingExpression: `Unknown(attributes["tenant.id"])`},
+			"invalid routing_expression",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := newSpanRoutingKeyFunc(tt.config, componenttest.NewNopTelemetrySettings())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+
+			_, err = newLogRoutingKeyFunc(tt.config, componenttest.NewNopTelemetrySettings())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestSpanRoutingKeyOTTL(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("tenant.id", "acme")
+	span := ptrace.NewSpan()
+	span.Attributes().PutStr("http.route", "/users")
+	span.Attributes().PutInt("http.status_code", 200)
+
+	for _, tt := range []struct {
+		desc       string
+		expression string
+		expected   string
+	}{
+		{"resource attribute", `resource.attributes["tenant.id"]`, "acme"},
+		{"concat", `Concat([resource.attributes["tenant.id"], attributes["http.route"]], "/")`, "acme//users"},
+		{"int", `attributes["http.status_code"]`, "200"},
+		{"missing", `attributes["missing"]`, ""},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			keyFunc, err := newSpanRoutingKeyFunc(&Config{RoutingKey: "ottl", RoutingExpression: tt.expression}, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			key, err := keyFunc(context.Background(), span, pcommon.NewInstrumentationScope(), resource)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, key)
+		})
+	}
+}
+
+func TestLogRoutingKey(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("k8s.pod.name", "pod-1")
+	log := plog.NewLogRecord()
+	log.Attributes().PutStr("log.file.name", "app.log")
+
+	for _, tt := range []struct {
+		desc     string
+		config   *Config
+		expected string
+	}{
+		{
+			"attributes",
+			&Config{RoutingKey: "attributes", RoutingAttributes: []string{"k8s.pod.name", "log.file.name"}},
+			"pod-1\x00app.log",
+		},
+		{
+			"ottl",
+			&Config{RoutingKey: "ottl", RoutingExpression: `ConvertCase(resource.attributes["k8s.pod.name"], "upper")`},
+			"POD-1",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			keyFunc, err := newLogRoutingKeyFunc(tt.config, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			key, err := keyFunc(context.Background(), log, pcommon.NewInstrumentationScope(), resource)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, key)
+		})
+	}
+}
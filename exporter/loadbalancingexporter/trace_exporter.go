@@ -38,6 +38,7 @@ var _ exporter.Traces = (*traceExporterImp)(nil)
 type traceExporterImp struct {
 	loadBalancer loadBalancer
 	routingKey   routingKey
+	spanKey      spanRoutingKeyFunc
 
 	stopped    bool
 	shutdownWg sync.WaitGroup
@@ -60,6 +61,12 @@ func newTracesExporter(params exporter.CreateSettings, cfg component.Config) (*t
 	switch cfg.(*Config).RoutingKey {
 	case "service":
 		traceExporter.routingKey = svcRouting
+	case "attributes", "ottl":
+		traceExporter.routingKey = spanRouting
+		traceExporter.spanKey, err = newSpanRoutingKeyFunc(cfg.(*Config), params.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
 	case "traceID", "":
 	default:
 		return nil, fmt.Errorf("unsupported routing_key: %s", cfg.(*Config).RoutingKey)
@@ -88,6 +95,10 @@ func (e *traceExporterImp) Shutdown(context.Context) error {
 }
 
 func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if e.routingKey == spanRouting {
+		return e.consumeTracesBySpanKey(ctx, td)
+	}
+
 	var errs error
 	batches := batchpersignal.SplitTraces(td)
 	for _, batch := range batches {
@@ -98,38 +109,97 @@ func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 }
 
 func (e *traceExporterImp) consumeTrace(ctx context.Context, td ptrace.Traces) error {
-	var exp component.Component
 	routingIds, err := routingIdentifiersFromTraces(td, e.routingKey)
 	if err != nil {
 		return err
 	}
+	var errs error
 	for rid := range routingIds {
 		endpoint := e.loadBalancer.Endpoint([]byte(rid))
-		exp, err = e.loadBalancer.Exporter(endpoint)
-		if err != nil {
-			return err
-		}
+		errs = multierr.Append(errs, e.exportTraces(ctx, endpoint, td))
+	}
+	return errs
+}
 
-		te, ok := exp.(exporter.Traces)
-		if !ok {
-			return fmt.Errorf("unable to export traces, unexpected exporter type: expected exporter.Traces but got %T", exp)
+// consumeTracesBySpanKey routes each span individually, by the key returned for it
+func (e *traceExporterImp) consumeTracesBySpanKey(ctx context.Context, td ptrace.Traces) error {
+	var errs error
+	batches := make(map[string]ptrace.Traces)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			// the destination scope spans in the batch of each endpoint
+			scopes := make(map[string]ptrace.ScopeSpans)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				key, err := e.spanKey(ctx, span, ils.Scope(), rs.Resource())
+				if err != nil {
+					errs = multierr.Append(errs, err)
+					key = ""
+				}
+				if key == "" {
+					// spans without a routing key, including those for which it couldn't be
+					// determined, are routed along with the rest of their trace
+					tid := span.TraceID()
+					key = string(tid[:])
+				}
+
+				endpoint := e.loadBalancer.Endpoint([]byte(key))
+				scope, ok := scopes[endpoint]
+				if !ok {
+					batch, ok := batches[endpoint]
+					if !ok {
+						batch = ptrace.NewTraces()
+						batches[endpoint] = batch
+					}
+					dstRs := batch.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(dstRs.Resource())
+					dstRs.SetSchemaUrl(rs.SchemaUrl())
+					scope = dstRs.ScopeSpans().AppendEmpty()
+					ils.Scope().CopyTo(scope.Scope())
+					scope.SetSchemaUrl(ils.SchemaUrl())
+					scopes[endpoint] = scope
+				}
+				span.CopyTo(scope.Spans().AppendEmpty())
+			}
 		}
+	}
 
-		start := time.Now()
-		err = te.ConsumeTraces(ctx, td)
-		duration := time.Since(start)
-
-		if err == nil {
-			_ = stats.RecordWithTags(
-				ctx,
-				[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
-				mBackendLatency.M(duration.Milliseconds()))
-		} else {
-			_ = stats.RecordWithTags(
-				ctx,
-				[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
-				mBackendLatency.M(duration.Milliseconds()))
-		}
+	for endpoint, batch := range batches {
+		errs = multierr.Append(errs, e.exportTraces(ctx, endpoint, batch))
+	}
+	return errs
+}
+
+func (e *traceExporterImp) exportTraces(ctx context.Context, endpoint string, td ptrace.Traces) error {
+	exp, err := e.loadBalancer.Exporter(endpoint)
+	if err != nil {
+		return err
+	}
+
+	te, ok := exp.(exporter.Traces)
+	if !ok {
+		return fmt.Errorf("unable to export traces, unexpected exporter type: expected exporter.Traces but got %T", exp)
+	}
+
+	start := time.Now()
+	err = te.ConsumeTraces(ctx, td)
+	duration := time.Since(start)
+
+	if err == nil {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successTrueMutator},
+			mBackendLatency.M(duration.Milliseconds()))
+	} else {
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(endpointTagKey, endpoint), successFalseMutator},
+			mBackendLatency.M(duration.Milliseconds()))
 	}
 	return err
 }
@@ -53,6 +53,22 @@ func TestNewTracesExporter(t *testing.T) {
 			simpleConfig(),
 			nil,
 		},
+		{
+			"attributes without routing attributes",
+			&Config{
+				Resolver:   ResolverSettings{Static: &StaticResolver{Hostnames: []string{"endpoint-1"}}},
+				RoutingKey: "attributes",
+			},
+			errNoRoutingAttributes,
+		},
+		{
+			"ottl without routing expression",
+			&Config{
+				Resolver:   ResolverSettings{Static: &StaticResolver{Hostnames: []string{"endpoint-1"}}},
+				RoutingKey: "ottl",
+			},
+			errNoRoutingExpression,
+		},
 		{
 			"empty",
 			&Config{},
@@ -229,6 +245,165 @@ func TestServiceBasedRoutingForSameTraceId(t *testing.T) {
 	}
 }
 
+func TestConsumeTracesAttributeBased(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+	}{
+		{
+			"attributes",
+			&Config{RoutingKey: "attributes", RoutingAttributes: []string{"tenant.id"}},
+		},
+		{
+			"ottl",
+			&Config{RoutingKey: "ottl", RoutingExpression: `attributes["tenant.id"]`},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			sinks := map[string]*consumertest.TracesSink{}
+			componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+				sinks[endpoint] = new(consumertest.TracesSink)
+				return newMockTracesExporter(sinks[endpoint].ConsumeTraces), nil
+			}
+			tt.config.Resolver = ResolverSettings{
+				Static: &StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2", "endpoint-3"}},
+			}
+			lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), tt.config, componentFactory)
+			require.NotNil(t, lb)
+			require.NoError(t, err)
+
+			p, err := newTracesExporter(exportertest.NewNopCreateSettings(), tt.config)
+			require.NotNil(t, p)
+			require.NoError(t, err)
+			p.loadBalancer = lb
+
+			err = p.Start(context.Background(), componenttest.NewNopHost())
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			// a single trace, with spans of 20 tenants and spans without a tenant
+			td := ptrace.NewTraces()
+			spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+			for i := 0; i < 40; i++ {
+				span := spans.AppendEmpty()
+				span.SetTraceID([16]byte{1, 2, 3, 4})
+				if i%2 == 0 {
+					span.Attributes().PutStr("tenant.id", fmt.Sprintf("tenant-%d", i%20))
+				}
+			}
+
+			// test
+			require.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+			// verify
+			tenants := map[string]string{}
+			var total int
+			for endpoint, sink := range sinks {
+				for _, received := range sink.AllTraces() {
+					total += received.SpanCount()
+					spans := received.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+					for i := 0; i < spans.Len(); i++ {
+						tenant, _ := spans.At(i).Attributes().Get("tenant.id")
+						if previous, ok := tenants[tenant.Str()]; ok {
+							assert.Equal(t, previous, endpoint)
+						}
+						tenants[tenant.Str()] = endpoint
+					}
+				}
+			}
+			assert.Len(t, tenants, 11)
+			assert.Equal(t, 40, total)
+		})
+	}
+}
+
+func TestConsumeTracesFailingBackend(t *testing.T) {
+	calls := atomic.NewInt64(0)
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newMockTracesExporter(func(ctx context.Context, td ptrace.Traces) error {
+			calls.Inc()
+			return errors.New("backend unavailable")
+		}), nil
+	}
+	cfg := serviceBasedRoutingConfig()
+	cfg.Resolver.Static.Hostnames = []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newTracesExporter(exportertest.NewNopCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	// a single trace, with spans of 10 services
+	td := ptrace.NewTraces()
+	for i := 0; i < 10; i++ {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr(conventions.AttributeServiceName, fmt.Sprintf("service-%d", i))
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetTraceID([16]byte{1, 2, 3, 4})
+	}
+
+	// test
+	err = p.ConsumeTraces(context.Background(), td)
+
+	// verify that the trace is exported for each service despite the previous failures
+	assert.Error(t, err)
+	assert.EqualValues(t, 10, calls.Load())
+}
+
+func TestConsumeTracesSpanKeyError(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
+		return newMockTracesExporter(sink.ConsumeTraces), nil
+	}
+	cfg := &Config{
+		Resolver:          ResolverSettings{Static: &StaticResolver{Hostnames: []string{"endpoint-1"}}},
+		RoutingKey:        "attributes",
+		RoutingAttributes: []string{"tenant.id"},
+	}
+	lb, err := newLoadBalancer(exportertest.NewNopCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newTracesExporter(exportertest.NewNopCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	p.loadBalancer = lb
+	p.spanKey = func(_ context.Context, span ptrace.Span, _ pcommon.InstrumentationScope, _ pcommon.Resource) (string, error) {
+		if _, ok := span.Attributes().Get("invalid"); ok {
+			return "", errors.New("invalid routing key")
+		}
+		return "tenant", nil
+	}
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().Attributes().PutBool("invalid", true)
+	spans.AppendEmpty()
+
+	// test
+	err = p.ConsumeTraces(context.Background(), td)
+
+	// verify that the span whose key couldn't be determined is still exported
+	assert.Error(t, err)
+	assert.Equal(t, 2, sink.SpanCount())
+}
+
 func TestConsumeTracesExporterNoEndpoint(t *testing.T) {
 	componentFactory := func(ctx context.Context, endpoint string) (component.Component, error) {
 		return newNopMockTracesExporter(), nil
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+)
+
+var compressionCodecs = map[string]parquet.CompressionCodec{
+	compressionNone:   parquet.CompressionCodec_UNCOMPRESSED,
+	compressionSnappy: parquet.CompressionCodec_SNAPPY,
+	compressionGzip:   parquet.CompressionCodec_GZIP,
+	compressionZstd:   parquet.CompressionCodec_ZSTD,
+}
+
+// Config defines configuration for the Parquet exporter.
+type Config struct {
+	// Path is the directory Parquet files are written to. Every signal gets its
+	// own set of files, named after the signal and the time the file was opened.
+	Path string `mapstructure:"path"`
+
+	// Compression is the codec used for column chunks.
+	// Options: none, snappy[default], gzip, zstd.
+	Compression string `mapstructure:"compression"`
+
+	// MaxFileSizeMB is the size in megabytes after which the current file is
+	// closed and a new one is started. It defaults to 128 megabytes.
+	MaxFileSizeMB int `mapstructure:"max_file_size_mb"`
+
+	// RotationInterval is the maximum amount of time a file is kept open before
+	// it is closed and a new one is started. It defaults to 1 hour.
+	RotationInterval time.Duration `mapstructure:"rotation_interval"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+	if _, ok := compressionCodecs[cfg.Compression]; !ok {
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	if cfg.MaxFileSizeMB <= 0 {
+		return errors.New("max_file_size_mb must be positive")
+	}
+	if cfg.RotationInterval <= 0 {
+		return errors.New("rotation_interval must be positive")
+	}
+	return nil
+}
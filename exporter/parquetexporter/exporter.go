@@ -16,33 +16,98 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	tracesFilePrefix  = "traces"
+	metricsFilePrefix = "metrics"
+	logsFilePrefix    = "logs"
 )
 
 type parquetExporter struct {
-	path string
+	path   string
+	writer *rollingWriter
+	logger *zap.Logger
+
+	done chan struct{}
+	wg   sync.WaitGroup
 }
 
-func (e parquetExporter) start(ctx context.Context, host component.Host) error {
-	return nil
+func newParquetExporter(cfg *Config, logger *zap.Logger, prefix string, schema interface{}) *parquetExporter {
+	return &parquetExporter{
+		path:   cfg.Path,
+		writer: newRollingWriter(cfg, prefix, schema),
+		logger: logger,
+		done:   make(chan struct{}),
+	}
 }
 
-func (e parquetExporter) shutdown(ctx context.Context) error {
+func (e *parquetExporter) start(ctx context.Context, host component.Host) error {
+	if err := os.MkdirAll(e.path, 0750); err != nil {
+		return fmt.Errorf("failed to create output directory %q: %w", e.path, err)
+	}
+
+	e.wg.Add(1)
+	go e.rotateLoop()
 	return nil
 }
 
-func (e parquetExporter) consumeMetrics(ctx context.Context, ld pmetric.Metrics) error {
-	return nil
+// rotateLoop periodically closes files that outlived the rotation interval
+// even when no new data is being written to them.
+func (e *parquetExporter) rotateLoop() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(rotationCheckInterval(e.writer.interval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.writer.rotateIfExpired(); err != nil {
+				e.logger.Warn("Failed to rotate Parquet file", zap.Error(err))
+			}
+		case <-e.done:
+			return
+		}
+	}
 }
 
-func (e parquetExporter) consumeTraces(ctx context.Context, ld ptrace.Traces) error {
-	return nil
+func (e *parquetExporter) shutdown(ctx context.Context) error {
+	select {
+	case <-e.done:
+	default:
+		close(e.done)
+	}
+	e.wg.Wait()
+	return e.writer.close()
 }
 
-func (e parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
-	return nil
+func (e *parquetExporter) consumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return e.writer.write(metricsToRows(md))
+}
+
+func (e *parquetExporter) consumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return e.writer.write(tracesToRows(td))
+}
+
+func (e *parquetExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
+	return e.writer.write(logsToRows(ld))
+}
+
+// rotationCheckInterval returns how often files are checked for expiry: a
+// tenth of the rotation interval, but at most once per second.
+func rotationCheckInterval(interval time.Duration) time.Duration {
+	check := interval / 10
+	if check < time.Second {
+		check = time.Second
+	}
+	return check
 }
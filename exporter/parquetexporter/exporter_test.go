@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func testConfig(t *testing.T) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = t.TempDir()
+	return cfg
+}
+
+func readRows[T any](t *testing.T, cfg *Config, prefix string, schema *T) []T {
+	files, err := filepath.Glob(filepath.Join(cfg.Path, prefix+"-*"+fileExtension))
+	require.NoError(t, err)
+	var out []T
+	for _, file := range files {
+		fr, err := local.NewLocalFileReader(file)
+		require.NoError(t, err)
+		pr, err := reader.NewParquetReader(fr, schema, 1)
+		require.NoError(t, err)
+		rows := make([]T, pr.GetNumRows())
+		require.NoError(t, pr.Read(&rows))
+		pr.ReadStop()
+		require.NoError(t, fr.Close())
+		out = append(out, rows...)
+	}
+	return out
+}
+
+func TestTracesExporter(t *testing.T) {
+	cfg := testConfig(t)
+	exp, err := NewFactory().CreateTracesExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("test")
+	span := ss.Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetStartTimestamp(pcommon.Timestamp(1000))
+	span.SetEndTimestamp(pcommon.Timestamp(3000))
+	span.Attributes().PutInt("http.status_code", 200)
+	span.Status().SetCode(ptrace.StatusCodeOk)
+	event := span.Events().AppendEmpty()
+	event.SetName("cache miss")
+	event.SetTimestamp(pcommon.Timestamp(2000))
+	event.Attributes().PutStr("key", "cart:1")
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	rows := readRows(t, cfg, tracesFilePrefix, new(spanRow))
+	require.Len(t, rows, 1)
+	row := rows[0]
+	assert.Equal(t, map[string]string{"service.name": "checkout"}, row.ResourceAttributes)
+	assert.Equal(t, "test", row.ScopeName)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", row.TraceID)
+	assert.Equal(t, "0102030405060708", row.SpanID)
+	assert.Equal(t, "", row.ParentSpanID)
+	assert.Equal(t, "GET /cart", row.Name)
+	assert.Equal(t, "Server", row.Kind)
+	assert.Equal(t, int64(2000), row.DurationNano)
+	assert.Equal(t, map[string]string{"http.status_code": "200"}, row.Attributes)
+	assert.Equal(t, "Ok", row.StatusCode)
+	require.Len(t, row.Events, 1)
+	assert.Equal(t, "cache miss", row.Events[0].Name)
+	assert.Equal(t, int64(2000), row.Events[0].TimeUnixNano)
+	assert.Equal(t, map[string]string{"key": "cart:1"}, row.Events[0].Attributes)
+}
+
+func TestLogsExporter(t *testing.T) {
+	cfg := testConfig(t)
+	exp, err := NewFactory().CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "node-1")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.Timestamp(1000))
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetSeverityText("ERROR")
+	lr.Body().SetStr("something failed")
+	lr.Attributes().PutBool("retry", true)
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	rows := readRows(t, cfg, logsFilePrefix, new(logRow))
+	require.Len(t, rows, 1)
+	assert.Equal(t, map[string]string{"host.name": "node-1"}, rows[0].ResourceAttributes)
+	assert.Equal(t, int64(1000), rows[0].TimeUnixNano)
+	assert.Equal(t, int32(plog.SeverityNumberError), rows[0].SeverityNumber)
+	assert.Equal(t, "ERROR", rows[0].SeverityText)
+	assert.Equal(t, "something failed", rows[0].Body)
+	assert.Equal(t, map[string]string{"retry": "true"}, rows[0].Attributes)
+}
+
+func TestMetricsExporter(t *testing.T) {
+	cfg := testConfig(t)
+	exp, err := NewFactory().CreateMetricsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("queue.size")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(42)
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.Sum().DataPoints().AppendEmpty().SetDoubleValue(1.5)
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(6)
+	hdp.ExplicitBounds().FromRaw([]float64{1, 5})
+	hdp.BucketCounts().FromRaw([]uint64{1, 1, 1})
+	summary := metrics.AppendEmpty()
+	summary.SetName("sizes")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetCount(2)
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(10)
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	rows := readRows(t, cfg, metricsFilePrefix, new(metricRow))
+	require.Len(t, rows, 4)
+
+	assert.Equal(t, "queue.size", rows[0].MetricName)
+	assert.Equal(t, "Gauge", rows[0].MetricType)
+	require.NotNil(t, rows[0].ValueInt)
+	assert.Equal(t, int64(42), *rows[0].ValueInt)
+	assert.Nil(t, rows[0].ValueDouble)
+
+	assert.Equal(t, "requests", rows[1].MetricName)
+	assert.Equal(t, "Cumulative", rows[1].AggregationTemporality)
+	assert.True(t, rows[1].IsMonotonic)
+	require.NotNil(t, rows[1].ValueDouble)
+	assert.Equal(t, 1.5, *rows[1].ValueDouble)
+
+	assert.Equal(t, "latency", rows[2].MetricName)
+	require.NotNil(t, rows[2].Count)
+	assert.Equal(t, int64(3), *rows[2].Count)
+	assert.Equal(t, []int64{1, 1, 1}, rows[2].BucketCounts)
+	assert.Equal(t, []float64{1, 5}, rows[2].ExplicitBounds)
+
+	assert.Equal(t, "sizes", rows[3].MetricName)
+	assert.Equal(t, []quantileRow{{Quantile: 0.5, Value: 10}}, rows[3].QuantileValues)
+}
+
+func TestRollingWriterRotation(t *testing.T) {
+	cfg := testConfig(t)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	w := newRollingWriter(cfg, logsFilePrefix, new(logRow))
+	w.now = func() time.Time { return now }
+
+	require.NoError(t, w.write([]interface{}{logRow{Body: "first"}}))
+	now = now.Add(cfg.RotationInterval)
+	require.NoError(t, w.write([]interface{}{logRow{Body: "second"}}))
+
+	// the expired file is complete while the current one is still in progress.
+	files, err := filepath.Glob(filepath.Join(cfg.Path, "*"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Len(t, readRows(t, cfg, logsFilePrefix, new(logRow)), 1)
+
+	now = now.Add(cfg.RotationInterval)
+	require.NoError(t, w.rotateIfExpired())
+	rows := readRows(t, cfg, logsFilePrefix, new(logRow))
+	require.Len(t, rows, 2)
+	assert.Equal(t, "first", rows[0].Body)
+	assert.Equal(t, "second", rows[1].Body)
+	require.NoError(t, w.close())
+}
+
+func TestRollingWriterMaxSize(t *testing.T) {
+	cfg := testConfig(t)
+	w := newRollingWriter(cfg, logsFilePrefix, new(logRow))
+	// any flushed row group exceeds the limit, forcing a new file per write.
+	w.maxSize = 1
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, w.write([]interface{}{logRow{Body: "entry"}}))
+	}
+	require.NoError(t, w.close())
+
+	files, err := filepath.Glob(filepath.Join(cfg.Path, "*"+fileExtension))
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+}
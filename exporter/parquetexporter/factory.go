@@ -16,6 +16,7 @@ package parquetexporter // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
@@ -27,11 +28,10 @@ const (
 	typeStr = "parquet"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelDevelopment
-)
 
-type Config struct {
-	Path string `mapstructure:"path"`
-}
+	defaultMaxFileSizeMB    = 128
+	defaultRotationInterval = time.Hour
+)
 
 // NewFactory creates a factory for the Parquet exporter.
 func NewFactory() exporter.Factory {
@@ -44,7 +44,11 @@ func NewFactory() exporter.Factory {
 }
 
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		Compression:      compressionSnappy,
+		MaxFileSizeMB:    defaultMaxFileSizeMB,
+		RotationInterval: defaultRotationInterval,
+	}
 }
 
 func createTracesExporter(
@@ -52,7 +56,7 @@ func createTracesExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Traces, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, tracesFilePrefix, new(spanRow))
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
@@ -68,7 +72,7 @@ func createMetricsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Metrics, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, metricsFilePrefix, new(metricRow))
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
@@ -84,7 +88,7 @@ func createLogsExporter(
 	set exporter.CreateSettings,
 	cfg component.Config,
 ) (exporter.Logs, error) {
-	fe := &parquetExporter{path: cfg.(*Config).Path}
+	fe := newParquetExporter(cfg.(*Config), set.Logger, logsFilePrefix, new(logRow))
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
@@ -0,0 +1,429 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// The row types below define the Parquet schema of the written files. Column
+// names are part of the exporter's public contract: only add new columns,
+// never rename or remove existing ones.
+
+type spanRow struct {
+	ResourceSchemaURL  string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ResourceAttributes map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName          string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion       string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeAttributes    map[string]string `parquet:"name=scope_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	TraceID            string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID             string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ParentSpanID       string            `parquet:"name=parent_span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState         string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Name               string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Kind               string            `parquet:"name=kind, type=BYTE_ARRAY, convertedtype=UTF8"`
+	StartTimeUnixNano  int64             `parquet:"name=start_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	EndTimeUnixNano    int64             `parquet:"name=end_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	DurationNano       int64             `parquet:"name=duration_nano, type=INT64"`
+	Attributes         map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StatusCode         string            `parquet:"name=status_code, type=BYTE_ARRAY, convertedtype=UTF8"`
+	StatusMessage      string            `parquet:"name=status_message, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Events             []spanEventRow    `parquet:"name=events, type=LIST"`
+	Links              []spanLinkRow     `parquet:"name=links, type=LIST"`
+}
+
+type spanEventRow struct {
+	TimeUnixNano int64             `parquet:"name=time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Name         string            `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes   map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+type spanLinkRow struct {
+	TraceID    string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID     string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TraceState string            `parquet:"name=trace_state, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+}
+
+type logRow struct {
+	ResourceSchemaURL      string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeAttributes        map[string]string `parquet:"name=scope_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	TimeUnixNano           int64             `parquet:"name=time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	ObservedTimeUnixNano   int64             `parquet:"name=observed_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	TraceID                string            `parquet:"name=trace_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SpanID                 string            `parquet:"name=span_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+	SeverityText           string            `parquet:"name=severity_text, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SeverityNumber         int32             `parquet:"name=severity_number, type=INT32"`
+	Body                   string            `parquet:"name=body, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	DroppedAttributesCount int64             `parquet:"name=dropped_attributes_count, type=INT64"`
+}
+
+// metricRow holds a single data point. Columns that do not apply to the
+// metric type of the point are left empty.
+type metricRow struct {
+	ResourceSchemaURL      string            `parquet:"name=resource_schema_url, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ResourceAttributes     map[string]string `parquet:"name=resource_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	ScopeName              string            `parquet:"name=scope_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeVersion           string            `parquet:"name=scope_version, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ScopeAttributes        map[string]string `parquet:"name=scope_attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	MetricName             string            `parquet:"name=metric_name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricDescription      string            `parquet:"name=metric_description, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricUnit             string            `parquet:"name=metric_unit, type=BYTE_ARRAY, convertedtype=UTF8"`
+	MetricType             string            `parquet:"name=metric_type, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AggregationTemporality string            `parquet:"name=aggregation_temporality, type=BYTE_ARRAY, convertedtype=UTF8"`
+	IsMonotonic            bool              `parquet:"name=is_monotonic, type=BOOLEAN"`
+	Attributes             map[string]string `parquet:"name=attributes, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=BYTE_ARRAY, valueconvertedtype=UTF8"`
+	StartTimeUnixNano      int64             `parquet:"name=start_time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	TimeUnixNano           int64             `parquet:"name=time_unix_nano, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"`
+	Flags                  int64             `parquet:"name=flags, type=INT64"`
+	ValueDouble            *float64          `parquet:"name=value_double, type=DOUBLE"`
+	ValueInt               *int64            `parquet:"name=value_int, type=INT64"`
+	Count                  *int64            `parquet:"name=count, type=INT64"`
+	Sum                    *float64          `parquet:"name=sum, type=DOUBLE"`
+	Min                    *float64          `parquet:"name=min, type=DOUBLE"`
+	Max                    *float64          `parquet:"name=max, type=DOUBLE"`
+	BucketCounts           []int64           `parquet:"name=bucket_counts, type=LIST, valuetype=INT64"`
+	ExplicitBounds         []float64         `parquet:"name=explicit_bounds, type=LIST, valuetype=DOUBLE"`
+	Scale                  *int32            `parquet:"name=scale, type=INT32"`
+	ZeroCount              *int64            `parquet:"name=zero_count, type=INT64"`
+	PositiveOffset         *int32            `parquet:"name=positive_offset, type=INT32"`
+	PositiveBucketCounts   []int64           `parquet:"name=positive_bucket_counts, type=LIST, valuetype=INT64"`
+	NegativeOffset         *int32            `parquet:"name=negative_offset, type=INT32"`
+	NegativeBucketCounts   []int64           `parquet:"name=negative_bucket_counts, type=LIST, valuetype=INT64"`
+	QuantileValues         []quantileRow     `parquet:"name=quantile_values, type=LIST"`
+}
+
+type quantileRow struct {
+	Quantile float64 `parquet:"name=quantile, type=DOUBLE"`
+	Value    float64 `parquet:"name=value, type=DOUBLE"`
+}
+
+// resourceColumns are the columns shared by every row originating from the
+// same resource and instrumentation scope.
+type resourceColumns struct {
+	schemaURL       string
+	attributes      map[string]string
+	scopeName       string
+	scopeVersion    string
+	scopeAttributes map[string]string
+}
+
+func newResourceColumns(res pcommon.Resource, schemaURL string, scope pcommon.InstrumentationScope) resourceColumns {
+	return resourceColumns{
+		schemaURL:       schemaURL,
+		attributes:      attributesToMap(res.Attributes()),
+		scopeName:       scope.Name(),
+		scopeVersion:    scope.Version(),
+		scopeAttributes: attributesToMap(scope.Attributes()),
+	}
+}
+
+// attributesToMap flattens attribute values into their string form, so all
+// attribute columns share a single map<string, string> type regardless of the
+// value types sent by the instrumentation.
+func attributesToMap(attrs pcommon.Map) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
+
+func tracesToRows(td ptrace.Traces) []interface{} {
+	rows := make([]interface{}, 0, td.SpanCount())
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			rc := newResourceColumns(rs.Resource(), rs.SchemaUrl(), ss.Scope())
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				rows = append(rows, spanToRow(rc, spans.At(k)))
+			}
+		}
+	}
+	return rows
+}
+
+func spanToRow(rc resourceColumns, span ptrace.Span) spanRow {
+	row := spanRow{
+		ResourceSchemaURL:  rc.schemaURL,
+		ResourceAttributes: rc.attributes,
+		ScopeName:          rc.scopeName,
+		ScopeVersion:       rc.scopeVersion,
+		ScopeAttributes:    rc.scopeAttributes,
+		TraceID:            traceIDToString(span.TraceID()),
+		SpanID:             spanIDToString(span.SpanID()),
+		ParentSpanID:       spanIDToString(span.ParentSpanID()),
+		TraceState:         span.TraceState().AsRaw(),
+		Name:               span.Name(),
+		Kind:               span.Kind().String(),
+		StartTimeUnixNano:  int64(span.StartTimestamp()),
+		EndTimeUnixNano:    int64(span.EndTimestamp()),
+		DurationNano:       int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+		Attributes:         attributesToMap(span.Attributes()),
+		StatusCode:         span.Status().Code().String(),
+		StatusMessage:      span.Status().Message(),
+		Events:             make([]spanEventRow, 0, span.Events().Len()),
+		Links:              make([]spanLinkRow, 0, span.Links().Len()),
+	}
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		row.Events = append(row.Events, spanEventRow{
+			TimeUnixNano: int64(event.Timestamp()),
+			Name:         event.Name(),
+			Attributes:   attributesToMap(event.Attributes()),
+		})
+	}
+	for i := 0; i < span.Links().Len(); i++ {
+		link := span.Links().At(i)
+		row.Links = append(row.Links, spanLinkRow{
+			TraceID:    traceIDToString(link.TraceID()),
+			SpanID:     spanIDToString(link.SpanID()),
+			TraceState: link.TraceState().AsRaw(),
+			Attributes: attributesToMap(link.Attributes()),
+		})
+	}
+	return row
+}
+
+func logsToRows(ld plog.Logs) []interface{} {
+	rows := make([]interface{}, 0, ld.LogRecordCount())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			rc := newResourceColumns(rl.Resource(), rl.SchemaUrl(), sl.Scope())
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				rows = append(rows, logRow{
+					ResourceSchemaURL:      rc.schemaURL,
+					ResourceAttributes:     rc.attributes,
+					ScopeName:              rc.scopeName,
+					ScopeVersion:           rc.scopeVersion,
+					ScopeAttributes:        rc.scopeAttributes,
+					TimeUnixNano:           int64(lr.Timestamp()),
+					ObservedTimeUnixNano:   int64(lr.ObservedTimestamp()),
+					TraceID:                traceIDToString(lr.TraceID()),
+					SpanID:                 spanIDToString(lr.SpanID()),
+					Flags:                  int64(lr.Flags()),
+					SeverityText:           lr.SeverityText(),
+					SeverityNumber:         int32(lr.SeverityNumber()),
+					Body:                   lr.Body().AsString(),
+					Attributes:             attributesToMap(lr.Attributes()),
+					DroppedAttributesCount: int64(lr.DroppedAttributesCount()),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+func metricsToRows(md pmetric.Metrics) []interface{} {
+	rows := make([]interface{}, 0, md.DataPointCount())
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			rc := newResourceColumns(rm.Resource(), rm.SchemaUrl(), sm.Scope())
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				rows = appendMetricRows(rows, rc, metrics.At(k))
+			}
+		}
+	}
+	return rows
+}
+
+func appendMetricRows(rows []interface{}, rc resourceColumns, metric pmetric.Metric) []interface{} {
+	base := metricRow{
+		ResourceSchemaURL:  rc.schemaURL,
+		ResourceAttributes: rc.attributes,
+		ScopeName:          rc.scopeName,
+		ScopeVersion:       rc.scopeVersion,
+		ScopeAttributes:    rc.scopeAttributes,
+		MetricName:         metric.Name(),
+		MetricDescription:  metric.Description(),
+		MetricUnit:         metric.Unit(),
+		MetricType:         metric.Type().String(),
+	}
+
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rows = append(rows, numberDataPointToRow(base, dps.At(i)))
+		}
+	case pmetric.MetricTypeSum:
+		base.AggregationTemporality = metric.Sum().AggregationTemporality().String()
+		base.IsMonotonic = metric.Sum().IsMonotonic()
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rows = append(rows, numberDataPointToRow(base, dps.At(i)))
+		}
+	case pmetric.MetricTypeHistogram:
+		base.AggregationTemporality = metric.Histogram().AggregationTemporality().String()
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rows = append(rows, histogramDataPointToRow(base, dps.At(i)))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		base.AggregationTemporality = metric.ExponentialHistogram().AggregationTemporality().String()
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rows = append(rows, exponentialHistogramDataPointToRow(base, dps.At(i)))
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			rows = append(rows, summaryDataPointToRow(base, dps.At(i)))
+		}
+	}
+	return rows
+}
+
+func numberDataPointToRow(row metricRow, dp pmetric.NumberDataPoint) metricRow {
+	row.Attributes = attributesToMap(dp.Attributes())
+	row.StartTimeUnixNano = int64(dp.StartTimestamp())
+	row.TimeUnixNano = int64(dp.Timestamp())
+	row.Flags = int64(dp.Flags())
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeDouble:
+		v := dp.DoubleValue()
+		row.ValueDouble = &v
+	case pmetric.NumberDataPointValueTypeInt:
+		v := dp.IntValue()
+		row.ValueInt = &v
+	}
+	return row
+}
+
+func histogramDataPointToRow(row metricRow, dp pmetric.HistogramDataPoint) metricRow {
+	row.Attributes = attributesToMap(dp.Attributes())
+	row.StartTimeUnixNano = int64(dp.StartTimestamp())
+	row.TimeUnixNano = int64(dp.Timestamp())
+	row.Flags = int64(dp.Flags())
+	count := int64(dp.Count())
+	row.Count = &count
+	if dp.HasSum() {
+		sum := dp.Sum()
+		row.Sum = &sum
+	}
+	if dp.HasMin() {
+		min := dp.Min()
+		row.Min = &min
+	}
+	if dp.HasMax() {
+		max := dp.Max()
+		row.Max = &max
+	}
+	row.BucketCounts = uint64SliceToInt64(dp.BucketCounts())
+	row.ExplicitBounds = dp.ExplicitBounds().AsRaw()
+	return row
+}
+
+func exponentialHistogramDataPointToRow(row metricRow, dp pmetric.ExponentialHistogramDataPoint) metricRow {
+	row.Attributes = attributesToMap(dp.Attributes())
+	row.StartTimeUnixNano = int64(dp.StartTimestamp())
+	row.TimeUnixNano = int64(dp.Timestamp())
+	row.Flags = int64(dp.Flags())
+	count := int64(dp.Count())
+	row.Count = &count
+	if dp.HasSum() {
+		sum := dp.Sum()
+		row.Sum = &sum
+	}
+	if dp.HasMin() {
+		min := dp.Min()
+		row.Min = &min
+	}
+	if dp.HasMax() {
+		max := dp.Max()
+		row.Max = &max
+	}
+	scale := dp.Scale()
+	row.Scale = &scale
+	zeroCount := int64(dp.ZeroCount())
+	row.ZeroCount = &zeroCount
+	positiveOffset := dp.Positive().Offset()
+	row.PositiveOffset = &positiveOffset
+	row.PositiveBucketCounts = uint64SliceToInt64(dp.Positive().BucketCounts())
+	negativeOffset := dp.Negative().Offset()
+	row.NegativeOffset = &negativeOffset
+	row.NegativeBucketCounts = uint64SliceToInt64(dp.Negative().BucketCounts())
+	return row
+}
+
+func summaryDataPointToRow(row metricRow, dp pmetric.SummaryDataPoint) metricRow {
+	row.Attributes = attributesToMap(dp.Attributes())
+	row.StartTimeUnixNano = int64(dp.StartTimestamp())
+	row.TimeUnixNano = int64(dp.Timestamp())
+	row.Flags = int64(dp.Flags())
+	count := int64(dp.Count())
+	row.Count = &count
+	sum := dp.Sum()
+	row.Sum = &sum
+	qvs := dp.QuantileValues()
+	row.QuantileValues = make([]quantileRow, 0, qvs.Len())
+	for i := 0; i < qvs.Len(); i++ {
+		row.QuantileValues = append(row.QuantileValues, quantileRow{
+			Quantile: qvs.At(i).Quantile(),
+			Value:    qvs.At(i).Value(),
+		})
+	}
+	return row
+}
+
+func uint64SliceToInt64(s pcommon.UInt64Slice) []int64 {
+	out := make([]int64, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		out[i] = int64(s.At(i))
+	}
+	return out
+}
+
+// traceIDToString returns the hex encoding of the trace ID, or an empty string
+// if the ID is not set.
+func traceIDToString(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.String()
+}
+
+// spanIDToString returns the hex encoding of the span ID, or an empty string
+// if the ID is not set.
+func spanIDToString(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquetexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.uber.org/multierr"
+)
+
+const (
+	fileExtension = ".parquet"
+	// nanosecond precision keeps file names unique across rotations and restarts.
+	fileTimeFormat = "20060102T150405.000000000Z"
+	// files are written under a temporary name and renamed once they are
+	// complete, so that readers never pick up a file without a footer.
+	inProgressSuffix = ".inprogress"
+	// number of goroutines used by the parquet writer to marshal rows.
+	marshalParallelism = 4
+)
+
+// rollingWriter writes rows of a single schema to a sequence of Parquet
+// files, starting a new file whenever the current one grows beyond the
+// configured size or has been open longer than the rotation interval.
+type rollingWriter struct {
+	dir      string
+	prefix   string
+	schema   interface{}
+	codec    parquet.CompressionCodec
+	maxSize  int64
+	interval time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	counter  *countingWriter
+	pw       *writer.ParquetWriter
+	name     string
+	openedAt time.Time
+}
+
+func newRollingWriter(cfg *Config, prefix string, schema interface{}) *rollingWriter {
+	return &rollingWriter{
+		dir:      cfg.Path,
+		prefix:   prefix,
+		schema:   schema,
+		codec:    compressionCodecs[cfg.Compression],
+		maxSize:  int64(cfg.MaxFileSizeMB) * 1024 * 1024,
+		interval: cfg.RotationInterval,
+		now:      time.Now,
+	}
+}
+
+// write appends rows to the current file and flushes them as one row group.
+func (w *rollingWriter) write(rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.pw != nil && w.now().Sub(w.openedAt) >= w.interval {
+		if err := w.closeFile(); err != nil {
+			return err
+		}
+	}
+	if w.pw == nil {
+		if err := w.openFile(); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		if err := w.pw.Write(row); err != nil {
+			return fmt.Errorf("failed to write row to %s: %w", w.name, err)
+		}
+	}
+	if err := w.pw.Flush(true); err != nil {
+		return fmt.Errorf("failed to flush %s: %w", w.name, err)
+	}
+
+	if w.counter.n >= w.maxSize {
+		return w.closeFile()
+	}
+	return nil
+}
+
+// rotateIfExpired closes the current file if it has been open for longer than
+// the rotation interval, so that idle pipelines still produce complete files.
+func (w *rollingWriter) rotateIfExpired() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pw == nil || w.now().Sub(w.openedAt) < w.interval {
+		return nil
+	}
+	return w.closeFile()
+}
+
+// close finalizes the current file, if any.
+func (w *rollingWriter) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.pw == nil {
+		return nil
+	}
+	return w.closeFile()
+}
+
+func (w *rollingWriter) openFile() error {
+	w.openedAt = w.now()
+	w.name = filepath.Join(w.dir, w.prefix+"-"+w.openedAt.UTC().Format(fileTimeFormat)+fileExtension)
+
+	f, err := os.OpenFile(w.name+inProgressSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	counter := &countingWriter{w: f}
+	pw, err := writer.NewParquetWriterFromWriter(counter, w.schema, marshalParallelism)
+	if err != nil {
+		return multierr.Append(err, f.Close())
+	}
+	pw.CompressionType = w.codec
+
+	w.file = f
+	w.counter = counter
+	w.pw = pw
+	return nil
+}
+
+func (w *rollingWriter) closeFile() error {
+	err := w.pw.WriteStop()
+	err = multierr.Append(err, w.file.Close())
+	if err == nil {
+		err = os.Rename(w.name+inProgressSuffix, w.name)
+	}
+	w.file = nil
+	w.counter = nil
+	w.pw = nil
+	return err
+}
+
+// countingWriter keeps track of the number of bytes written to the file.
+type countingWriter struct {
+	w *os.File
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
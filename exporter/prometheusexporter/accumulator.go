@@ -96,6 +96,8 @@ func (a *lastValueAccumulator) addMetric(metric pmetric.Metric, il pcommon.Instr
 		return a.accumulateSum(metric, il, resourceAttrs, now)
 	case pmetric.MetricTypeHistogram:
 		return a.accumulateDoubleHistogram(metric, il, resourceAttrs, now)
+	case pmetric.MetricTypeExponentialHistogram:
+		return a.accumulateExponentialHistogram(metric, il, resourceAttrs, now)
 	case pmetric.MetricTypeSummary:
 		return a.accumulateSummary(metric, il, resourceAttrs, now)
 	default:
@@ -274,6 +276,39 @@ func (a *lastValueAccumulator) accumulateDoubleHistogram(metric pmetric.Metric,
 	return
 }
 
+func (a *lastValueAccumulator) accumulateExponentialHistogram(metric pmetric.Metric, il pcommon.InstrumentationScope, resourceAttrs pcommon.Map, now time.Time) (n int) {
+	expHistogram := metric.ExponentialHistogram()
+
+	// Drop metrics with non-cumulative aggregations
+	if expHistogram.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+		return
+	}
+
+	dps := expHistogram.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		ip := dps.At(i)
+
+		signature := timeseriesSignature(il.Name(), metric, ip.Attributes(), resourceAttrs)
+		if ip.Flags().NoRecordedValue() {
+			a.registeredMetrics.Delete(signature)
+			return 0
+		}
+
+		v, ok := a.registeredMetrics.Load(signature)
+		if ok && ip.Timestamp().AsTime().Before(v.(*accumulatedValue).value.ExponentialHistogram().DataPoints().At(0).Timestamp().AsTime()) {
+			// only keep datapoint with latest timestamp
+			continue
+		}
+
+		m := copyMetricMetadata(metric)
+		ip.CopyTo(m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty())
+		m.ExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		a.registeredMetrics.Store(signature, &accumulatedValue{value: m, resourceAttrs: resourceAttrs, scope: il, updated: now})
+		n++
+	}
+	return
+}
+
 // Collect returns a slice with relevant aggregated metrics and their resource attributes.
 func (a *lastValueAccumulator) Collect() ([]pmetric.Metric, []pcommon.Map) {
 	a.logger.Debug("Accumulator collect called")
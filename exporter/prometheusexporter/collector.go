@@ -44,6 +44,9 @@ type collector struct {
 	sendTimestamps bool
 	namespace      string
 	constLabels    prometheus.Labels
+
+	nativeHistograms bool
+	maxBuckets       int
 }
 
 func newCollector(config *Config, logger *zap.Logger) *collector {
@@ -53,6 +56,9 @@ func newCollector(config *Config, logger *zap.Logger) *collector {
 		namespace:      prometheustranslator.CleanUpString(config.Namespace),
 		sendTimestamps: config.SendTimestamps,
 		constLabels:    config.ConstLabels,
+
+		nativeHistograms: config.ExponentialHistograms.Native,
+		maxBuckets:       config.ExponentialHistograms.MaxBuckets,
 	}
 }
 
@@ -77,6 +83,8 @@ func (c *collector) convertMetric(metric pmetric.Metric, resourceAttrs pcommon.M
 		return c.convertSum(metric, resourceAttrs)
 	case pmetric.MetricTypeHistogram:
 		return c.convertDoubleHistogram(metric, resourceAttrs)
+	case pmetric.MetricTypeExponentialHistogram:
+		return c.convertExponentialHistogram(metric, resourceAttrs)
 	case pmetric.MetricTypeSummary:
 		return c.convertSummary(metric, resourceAttrs)
 	}
@@ -212,11 +220,39 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 		points[bucket] = cumCount
 	}
 
-	arrLen := ip.Exemplars().Len()
+	m, err := prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), points, attributes...)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.withExemplarsAndTimestamp(m, ip.Exemplars(), ip.Timestamp())
+}
+
+func (c *collector) convertExponentialHistogram(metric pmetric.Metric, resourceAttrs pcommon.Map) (prometheus.Metric, error) {
+	ip := metric.ExponentialHistogram().DataPoints().At(0)
+	desc, attributes := c.getMetricMetadata(metric, ip.Attributes(), resourceAttrs)
+
+	var m prometheus.Metric
+	var err error
+	if c.nativeHistograms {
+		m, err = newNativeHistogram(desc, ip, attributes)
+	} else {
+		m, err = newExplicitHistogram(desc, ip, c.maxBuckets, attributes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return c.withExemplarsAndTimestamp(m, ip.Exemplars(), ip.Timestamp())
+}
+
+// withExemplarsAndTimestamp adds the exemplars to the histogram, and its timestamp if timestamps are sent
+func (c *collector) withExemplarsAndTimestamp(m prometheus.Metric, exemplarSlice pmetric.ExemplarSlice, ts pcommon.Timestamp) (prometheus.Metric, error) {
+	arrLen := exemplarSlice.Len()
 	exemplars := make([]prometheus.Exemplar, arrLen)
 
 	for i := 0; i < arrLen; i++ {
-		e := ip.Exemplars().At(i)
+		e := exemplarSlice.At(i)
 		exemplarLabels := make(prometheus.Labels, 0)
 
 		if traceID := e.TraceID(); !traceID.IsEmpty() {
@@ -234,12 +270,8 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 		}
 	}
 
-	m, err := prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), points, attributes...)
-	if err != nil {
-		return nil, err
-	}
-
 	if arrLen > 0 {
+		var err error
 		m, err = prometheus.NewMetricWithExemplars(m, exemplars...)
 		if err != nil {
 			return nil, err
@@ -247,7 +279,7 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 	}
 
 	if c.sendTimestamps {
-		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), m), nil
+		return prometheus.NewMetricWithTimestamp(ts.AsTime(), m), nil
 	}
 	return m, nil
 }
@@ -523,6 +523,94 @@ func TestAccumulateHistograms(t *testing.T) {
 	}
 }
 
+func TestAccumulateExponentialHistograms(t *testing.T) {
+	for _, native := range []bool{true, false} {
+		for _, sendTimestamp := range []bool{true, false} {
+			name := "Explicit"
+			if native {
+				name = "Native"
+			}
+			if sendTimestamp {
+				name += "/WithTimestamp"
+			}
+			t.Run(name, func(t *testing.T) {
+				ts := time.Now()
+				metric := pmetric.NewMetric()
+				metric.SetName("test_metric")
+				metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+				dp.SetScale(0)
+				dp.SetCount(7)
+				dp.SetSum(42.42)
+				dp.SetZeroCount(1)
+				dp.Positive().SetOffset(1)
+				dp.Positive().BucketCounts().FromRaw([]uint64{4, 2})
+				dp.Attributes().PutStr("label_1", "1")
+				dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+				exemplar := dp.Exemplars().AppendEmpty()
+				exemplar.SetDoubleValue(3.0)
+				exemplar.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+
+				c := collector{
+					accumulator: &mockAccumulator{
+						[]pmetric.Metric{metric},
+						pcommon.NewMap(),
+					},
+					sendTimestamps:   sendTimestamp,
+					logger:           zap.NewNop(),
+					nativeHistograms: native,
+					maxBuckets:       defaultMaxBuckets,
+				}
+
+				ch := make(chan prometheus.Metric, 1)
+				go func() {
+					c.Collect(ch)
+					close(ch)
+				}()
+
+				n := 0
+				for m := range ch {
+					n++
+					require.Contains(t, m.Desc().String(), "fqName: \"test_metric\"")
+
+					pbMetric := io_prometheus_client.Metric{}
+					require.NoError(t, m.Write(&pbMetric))
+
+					if sendTimestamp {
+						require.Equal(t, ts.UnixNano()/1e6, *(pbMetric.TimestampMs))
+					} else {
+						require.Nil(t, pbMetric.TimestampMs)
+					}
+
+					h := pbMetric.GetHistogram()
+					require.NotNil(t, h)
+					require.Equal(t, uint64(7), h.GetSampleCount())
+					require.Equal(t, 42.42, h.GetSampleSum())
+
+					if native {
+						require.Equal(t, int32(0), h.GetSchema())
+						require.Equal(t, uint64(1), h.GetZeroCount())
+						require.Equal(t, []int64{4, -2}, h.GetPositiveDelta())
+						// the exemplar is attached to the +Inf bucket
+						require.Len(t, h.GetBucket(), 1)
+						require.Equal(t, 3.0, h.GetBucket()[0].GetExemplar().GetValue())
+						continue
+					}
+
+					require.Nil(t, h.Schema)
+					points := map[float64]uint64{}
+					for _, b := range h.GetBucket() {
+						points[b.GetUpperBound()] = b.GetCumulativeCount()
+					}
+					require.Equal(t, map[float64]uint64{0: 1, 4: 5, 8: 7}, points)
+					require.Equal(t, 3.0, h.GetBucket()[1].GetExemplar().GetValue())
+				}
+				require.Equal(t, 1, n)
+			})
+		}
+	}
+}
+
 func TestAccumulateSummary(t *testing.T) {
 	fillQuantileValue := func(pN, value float64, dest pmetric.SummaryDataPointValueAtQuantile) {
 		dest.SetQuantile(pN)
@@ -15,6 +15,7 @@
 package prometheusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
 
 import (
+	"errors"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -45,11 +46,28 @@ type Config struct {
 
 	// EnableOpenMetrics enables the use of the OpenMetrics encoding option for the prometheus exporter.
 	EnableOpenMetrics bool `mapstructure:"enable_open_metrics"`
+
+	// ExponentialHistograms defines how exponential histograms are exported.
+	ExponentialHistograms ExponentialHistogramsSettings `mapstructure:"exponential_histograms"`
+}
+
+// ExponentialHistogramsSettings defines how exponential histograms are exported.
+type ExponentialHistogramsSettings struct {
+	// Native exports exponential histograms as Prometheus native histograms, which are only
+	// served in the protobuf exposition format. Otherwise, they are converted into explicit buckets.
+	Native bool `mapstructure:"native"`
+
+	// MaxBuckets is the maximum number of explicit buckets an exponential histogram is converted into.
+	// Exponential histograms with more buckets are downscaled until they fit.
+	MaxBuckets int `mapstructure:"max_buckets"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.ExponentialHistograms.MaxBuckets <= 0 {
+		return errors.New("exponential_histograms.max_buckets must be positive")
+	}
 	return nil
 }
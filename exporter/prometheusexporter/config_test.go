@@ -61,6 +61,23 @@ func TestLoadConfig(t *testing.T) {
 				},
 				SendTimestamps:   true,
 				MetricExpiration: 60 * time.Minute,
+				ExponentialHistograms: ExponentialHistogramsSettings{
+					MaxBuckets: defaultMaxBuckets,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "native_histograms"),
+			expected: &Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "1.2.3.4:1234",
+				},
+				ConstLabels:      map[string]string{},
+				MetricExpiration: 5 * time.Minute,
+				ExponentialHistograms: ExponentialHistogramsSettings{
+					Native:     true,
+					MaxBuckets: 20,
+				},
 			},
 		},
 	}
@@ -79,3 +96,11 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, component.ValidateConfig(cfg))
+
+	cfg.ExponentialHistograms.MaxBuckets = 0
+	assert.EqualError(t, component.ValidateConfig(cfg), "exponential_histograms.max_buckets must be positive")
+}
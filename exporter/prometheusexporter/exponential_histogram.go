@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"google.golang.org/protobuf/proto"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// maxScaleDown is the reduction of scale merging all the buckets of an exponential histogram
+const maxScaleDown = 32
+
+// nativeHistogram is a histogram metric which also holds the buckets of a native histogram
+type nativeHistogram struct {
+	prometheus.Metric
+
+	schema         int32
+	zeroCount      uint64
+	positiveSpans  []*dto.BucketSpan
+	positiveDeltas []int64
+	negativeSpans  []*dto.BucketSpan
+	negativeDeltas []int64
+}
+
+// Write adds the native histogram buckets to the histogram written by the wrapped metric
+func (h *nativeHistogram) Write(out *dto.Metric) error {
+	if err := h.Metric.Write(out); err != nil {
+		return err
+	}
+	out.Histogram.Schema = proto.Int32(h.schema)
+	out.Histogram.ZeroThreshold = proto.Float64(prometheustranslator.DefaultZeroThreshold)
+	out.Histogram.ZeroCount = proto.Uint64(h.zeroCount)
+	out.Histogram.PositiveSpan = h.positiveSpans
+	out.Histogram.PositiveDelta = h.positiveDeltas
+	out.Histogram.NegativeSpan = h.negativeSpans
+	out.Histogram.NegativeDelta = h.negativeDeltas
+	return nil
+}
+
+// newNativeHistogram converts the exponential histogram data point into a native histogram.
+// Scales above the maximum schema of native histograms are reduced by merging buckets.
+func newNativeHistogram(desc *prometheus.Desc, ip pmetric.ExponentialHistogramDataPoint, labelValues []string) (prometheus.Metric, error) {
+	scale := ip.Scale()
+	if scale < prometheustranslator.MinNativeHistogramSchema {
+		return nil, fmt.Errorf("cannot convert exponential to native histogram: scale must be >= %d, was %d",
+			prometheustranslator.MinNativeHistogramSchema, scale)
+	}
+
+	var scaleDown int32
+	if scale > prometheustranslator.MaxNativeHistogramSchema {
+		scaleDown = scale - prometheustranslator.MaxNativeHistogramSchema
+		scale = prometheustranslator.MaxNativeHistogramSchema
+	}
+
+	// The classic histogram only holds the count and the sum, served in the text exposition formats
+	m, err := prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), nil, labelValues...)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &nativeHistogram{
+		Metric:    m,
+		schema:    scale,
+		zeroCount: ip.ZeroCount(),
+	}
+	h.positiveSpans, h.positiveDeltas = convertBucketsLayout(ip.Positive(), scaleDown)
+	h.negativeSpans, h.negativeDeltas = convertBucketsLayout(ip.Negative(), scaleDown)
+	return h, nil
+}
+
+// convertBucketsLayout converts the exponential histogram buckets into the spans and the
+// delta encoded counts of native histogram buckets, reducing the scale by scaleDown
+func convertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) ([]*dto.BucketSpan, []int64) {
+	layout, deltas := prometheustranslator.ConvertBucketsLayout(buckets, scaleDown)
+	if len(layout) == 0 {
+		return nil, deltas
+	}
+	spans := make([]*dto.BucketSpan, 0, len(layout))
+	for _, span := range layout {
+		spans = append(spans, &dto.BucketSpan{Offset: proto.Int32(span.Offset), Length: proto.Uint32(span.Length)})
+	}
+	return spans, deltas
+}
+
+// newExplicitHistogram converts the exponential histogram data point into a histogram with explicit
+// buckets, whose bounds are the upper bounds of the exponential buckets. The scale of the exponential
+// histogram is reduced until it has at most maxBuckets buckets, or cannot be reduced further.
+// The zero bucket is converted into a bucket whose upper bound is 0.
+func newExplicitHistogram(desc *prometheus.Desc, ip pmetric.ExponentialHistogramDataPoint, maxBuckets int, labelValues []string) (prometheus.Metric, error) {
+	numBuckets := func(scaleDown int32) int {
+		return bucketsLen(ip.Positive(), scaleDown) + bucketsLen(ip.Negative(), scaleDown)
+	}
+	// Buckets with negative and positive indices are never merged, hence the minimum number of buckets
+	minBuckets := numBuckets(maxScaleDown)
+	var scaleDown int32
+	for n := numBuckets(0); n > maxBuckets && n > minBuckets; n = numBuckets(scaleDown) {
+		scaleDown++
+	}
+	scale := ip.Scale() - scaleDown
+
+	var negative []float64
+	var negativeCounts []uint64
+	prometheustranslator.ForEachBucket(ip.Negative(), scaleDown, func(index int32, count uint64) {
+		negative = append(negative, -prometheustranslator.LowerBoundary(index, scale))
+		negativeCounts = append(negativeCounts, count)
+	})
+
+	points := make(map[float64]uint64)
+	var cumCount uint64
+	// The negative buckets come first, from the lowest values to the highest ones
+	for i := len(negative) - 1; i >= 0; i-- {
+		cumCount += negativeCounts[i]
+		points[negative[i]] = cumCount
+	}
+	cumCount += ip.ZeroCount()
+	points[0] = cumCount
+	prometheustranslator.ForEachBucket(ip.Positive(), scaleDown, func(index int32, count uint64) {
+		cumCount += count
+		points[prometheustranslator.LowerBoundary(index+1, scale)] = cumCount
+	})
+
+	return prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), points, labelValues...)
+}
+
+// bucketsLen returns the number of buckets once the scale is reduced by scaleDown
+func bucketsLen(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) int {
+	n := buckets.BucketCounts().Len()
+	if n == 0 {
+		return 0
+	}
+	first := buckets.Offset()
+	last := first + int32(n-1)
+	return int((last >> scaleDown) - (first >> scaleDown) + 1)
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"google.golang.org/protobuf/proto"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+func TestConvertBucketsLayout(t *testing.T) {
+	span := func(offset int32, length uint32) *dto.BucketSpan {
+		return &dto.BucketSpan{Offset: proto.Int32(offset), Length: proto.Uint32(length)}
+	}
+
+	tests := []struct {
+		name       string
+		offset     int32
+		counts     []uint64
+		scaleDown  int32
+		wantSpans  []*dto.BucketSpan
+		wantDeltas []int64
+	}{
+		{
+			name: "Empty",
+		},
+		{
+			name:       "SmallGapsFilled",
+			counts:     []uint64{1, 2, 0, 0, 3},
+			wantSpans:  []*dto.BucketSpan{span(1, 5)},
+			wantDeltas: []int64{1, 1, -2, 0, 3},
+		},
+		{
+			name:       "LargeGapsSplit",
+			offset:     -1,
+			counts:     []uint64{1, 0, 0, 0, 2},
+			wantSpans:  []*dto.BucketSpan{span(0, 1), span(3, 1)},
+			wantDeltas: []int64{1, 1},
+		},
+		{
+			name:       "ScaleDown",
+			offset:     -3,
+			counts:     []uint64{1, 1, 1, 4},
+			scaleDown:  1,
+			wantSpans:  []*dto.BucketSpan{span(-1, 3)},
+			wantDeltas: []int64{1, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := pmetric.NewExponentialHistogramDataPointBuckets()
+			buckets.SetOffset(tt.offset)
+			buckets.BucketCounts().FromRaw(tt.counts)
+
+			spans, deltas := convertBucketsLayout(buckets, tt.scaleDown)
+			assert.Equal(t, tt.wantSpans, spans)
+			assert.Equal(t, tt.wantDeltas, deltas)
+		})
+	}
+}
+
+func newTestExponentialHistogramDataPoint(scale int32) pmetric.ExponentialHistogramDataPoint {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(scale)
+	dp.SetCount(10)
+	dp.SetSum(4.5)
+	dp.SetZeroCount(4)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	dp.Negative().BucketCounts().FromRaw([]uint64{3})
+	return dp
+}
+
+func TestNewNativeHistogram(t *testing.T) {
+	desc := prometheus.NewDesc("test_metric", "", []string{"label_1"}, nil)
+
+	m, err := newNativeHistogram(desc, newTestExponentialHistogramDataPoint(1), []string{"1"})
+	require.NoError(t, err)
+	pb := dto.Metric{}
+	require.NoError(t, m.Write(&pb))
+
+	h := pb.GetHistogram()
+	assert.Equal(t, uint64(10), h.GetSampleCount())
+	assert.Equal(t, 4.5, h.GetSampleSum())
+	assert.Empty(t, h.GetBucket())
+	assert.Equal(t, int32(1), h.GetSchema())
+	assert.Equal(t, float64(prometheustranslator.DefaultZeroThreshold), h.GetZeroThreshold())
+	assert.Equal(t, uint64(4), h.GetZeroCount())
+	assert.Equal(t, []*dto.BucketSpan{{Offset: proto.Int32(1), Length: proto.Uint32(2)}}, h.GetPositiveSpan())
+	assert.Equal(t, []int64{1, 1}, h.GetPositiveDelta())
+	assert.Equal(t, []*dto.BucketSpan{{Offset: proto.Int32(1), Length: proto.Uint32(1)}}, h.GetNegativeSpan())
+	assert.Equal(t, []int64{3}, h.GetNegativeDelta())
+
+	// Scales above the maximum schema are reduced
+	m, err = newNativeHistogram(desc, newTestExponentialHistogramDataPoint(10), []string{"1"})
+	require.NoError(t, err)
+	pb = dto.Metric{}
+	require.NoError(t, m.Write(&pb))
+	assert.Equal(t, int32(prometheustranslator.MaxNativeHistogramSchema), pb.GetHistogram().GetSchema())
+	assert.Equal(t, []*dto.BucketSpan{{Offset: proto.Int32(1), Length: proto.Uint32(1)}}, pb.GetHistogram().GetPositiveSpan())
+	assert.Equal(t, []int64{3}, pb.GetHistogram().GetPositiveDelta())
+
+	// Scales below the minimum schema cannot be converted
+	_, err = newNativeHistogram(desc, newTestExponentialHistogramDataPoint(-5), []string{"1"})
+	assert.Error(t, err)
+}
+
+func TestNewExplicitHistogram(t *testing.T) {
+	desc := prometheus.NewDesc("test_metric", "", []string{"label_1"}, nil)
+
+	tests := []struct {
+		name       string
+		scale      int32
+		maxBuckets int
+		want       map[float64]uint64
+	}{
+		{
+			name:       "NoScaleDown",
+			scale:      0,
+			maxBuckets: defaultMaxBuckets,
+			want:       map[float64]uint64{-1: 3, 0: 7, 2: 8, 4: 10},
+		},
+		{
+			name:       "ScaleDown",
+			scale:      1,
+			maxBuckets: 2,
+			// the two positive buckets are merged
+			want: map[float64]uint64{-1: 3, 0: 7, 2: 10},
+		},
+		{
+			name:       "MaxBucketsTooLow",
+			scale:      1,
+			maxBuckets: 1,
+			want:       map[float64]uint64{-1: 3, 0: 7, 2: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := newExplicitHistogram(desc, newTestExponentialHistogramDataPoint(tt.scale), tt.maxBuckets, []string{"1"})
+			require.NoError(t, err)
+			pb := dto.Metric{}
+			require.NoError(t, m.Write(&pb))
+
+			h := pb.GetHistogram()
+			assert.Equal(t, uint64(10), h.GetSampleCount())
+			assert.Equal(t, 4.5, h.GetSampleSum())
+			got := make(map[float64]uint64)
+			for _, b := range h.GetBucket() {
+				got[b.GetUpperBound()] = b.GetCumulativeCount()
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
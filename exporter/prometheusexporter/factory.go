@@ -30,6 +30,9 @@ const (
 	typeStr = "prometheus"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
+	// defaultMaxBuckets is the default maximum number of explicit buckets of the converted
+	// exponential histograms, the default maximum size of the OpenTelemetry SDKs
+	defaultMaxBuckets = 160
 )
 
 // NewFactory creates a new Prometheus exporter factory.
@@ -46,6 +49,10 @@ func createDefaultConfig() component.Config {
 		SendTimestamps:    false,
 		MetricExpiration:  time.Minute * 5,
 		EnableOpenMetrics: false,
+		ExponentialHistograms: ExponentialHistogramsSettings{
+			Native:     false,
+			MaxBuckets: defaultMaxBuckets,
+		},
 	}
 }
 
@@ -50,6 +50,9 @@ type Config struct {
 
 	// TargetInfo allows customizing the target_info metric
 	TargetInfo *TargetInfo `mapstructure:"target_info,omitempty"`
+
+	// NativeHistograms allows customizing the export of exponential histograms
+	NativeHistograms *NativeHistograms `mapstructure:"native_histograms,omitempty"`
 }
 
 type TargetInfo struct {
@@ -57,6 +60,12 @@ type TargetInfo struct {
 	Enabled bool `mapstructure:"enabled"`
 }
 
+type NativeHistograms struct {
+	// Enabled if false the exponential histograms are exported as classic histograms
+	// instead of native histograms
+	Enabled bool `mapstructure:"enabled"`
+}
+
 // RemoteWriteQueue allows to configure the remote write queue.
 type RemoteWriteQueue struct {
 	// Enabled if false the queue is not enabled, the export requests
@@ -95,5 +104,10 @@ func (cfg *Config) Validate() error {
 			Enabled: true,
 		}
 	}
+	if cfg.NativeHistograms == nil {
+		cfg.NativeHistograms = &NativeHistograms{
+			Enabled: true,
+		}
+	}
 	return nil
 }
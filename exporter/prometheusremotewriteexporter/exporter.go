@@ -55,6 +55,8 @@ type prwExporter struct {
 	clientSettings    *confighttp.HTTPClientSettings
 	settings          component.TelemetrySettings
 	disableTargetInfo bool
+	// disableNativeHistograms exports exponential histograms as classic histograms
+	disableNativeHistograms bool
 
 	wal *prweWAL
 }
@@ -74,16 +76,17 @@ func newPRWExporter(cfg *Config, set exporter.CreateSettings) (*prwExporter, err
 	userAgentHeader := fmt.Sprintf("%s/%s", strings.ReplaceAll(strings.ToLower(set.BuildInfo.Description), " ", "-"), set.BuildInfo.Version)
 
 	prwe := &prwExporter{
-		namespace:         cfg.Namespace,
-		externalLabels:    sanitizedLabels,
-		endpointURL:       endpointURL,
-		wg:                new(sync.WaitGroup),
-		closeChan:         make(chan struct{}),
-		userAgentHeader:   userAgentHeader,
-		concurrency:       cfg.RemoteWriteQueue.NumConsumers,
-		clientSettings:    &cfg.HTTPClientSettings,
-		settings:          set.TelemetrySettings,
-		disableTargetInfo: !cfg.TargetInfo.Enabled,
+		namespace:               cfg.Namespace,
+		externalLabels:          sanitizedLabels,
+		endpointURL:             endpointURL,
+		wg:                      new(sync.WaitGroup),
+		closeChan:               make(chan struct{}),
+		userAgentHeader:         userAgentHeader,
+		concurrency:             cfg.RemoteWriteQueue.NumConsumers,
+		clientSettings:          &cfg.HTTPClientSettings,
+		settings:                set.TelemetrySettings,
+		disableTargetInfo:       !cfg.TargetInfo.Enabled,
+		disableNativeHistograms: !cfg.NativeHistograms.Enabled,
 	}
 	if cfg.WAL == nil {
 		return prwe, nil
@@ -136,7 +139,12 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
-		tsMap, err := prometheusremotewrite.FromMetrics(md, prometheusremotewrite.Settings{Namespace: prwe.namespace, ExternalLabels: prwe.externalLabels, DisableTargetInfo: prwe.disableTargetInfo})
+		tsMap, err := prometheusremotewrite.FromMetrics(md, prometheusremotewrite.Settings{
+			Namespace:               prwe.namespace,
+			ExternalLabels:          prwe.externalLabels,
+			DisableTargetInfo:       prwe.disableTargetInfo,
+			DisableNativeHistograms: prwe.disableNativeHistograms,
+		})
 		if err != nil {
 			err = consumererror.NewPermanent(err)
 		}
@@ -53,6 +53,9 @@ func Test_NewPRWExporter(t *testing.T) {
 		TargetInfo: &TargetInfo{
 			Enabled: true,
 		},
+		NativeHistograms: &NativeHistograms{
+			Enabled: true,
+		},
 	}
 	buildInfo := component.BuildInfo{
 		Description: "OpenTelemetry Collector",
@@ -146,6 +149,9 @@ func Test_Start(t *testing.T) {
 		TargetInfo: &TargetInfo{
 			Enabled: true,
 		},
+		NativeHistograms: &NativeHistograms{
+			Enabled: true,
+		},
 	}
 	buildInfo := component.BuildInfo{
 		Description: "OpenTelemetry Collector",
@@ -390,6 +396,8 @@ func Test_PushMetrics(t *testing.T) {
 
 	histogramNoSumBatch := getMetricsFromMetricList(validMetrics1[validHistogramNoSum], validMetrics2[validHistogramNoSum])
 
+	expHistogramBatch := getMetricsFromMetricList(validMetrics1[validExpHistogram], validMetrics2[validExpHistogram])
+
 	summaryBatch := getMetricsFromMetricList(validMetrics1[validSummary], validMetrics2[validSummary])
 
 	// len(BucketCount) > len(ExplicitBounds)
@@ -505,6 +513,13 @@ func Test_PushMetrics(t *testing.T) {
 			expectedTimeSeries: 10,
 			httpResponseCode:   http.StatusAccepted,
 		},
+		{
+			name:               "exponential_histogram_case",
+			metrics:            expHistogramBatch,
+			reqTestFunc:        checkFunc,
+			expectedTimeSeries: 2,
+			httpResponseCode:   http.StatusAccepted,
+		},
 		{
 			name:               "summary_case",
 			metrics:            summaryBatch,
@@ -652,6 +667,9 @@ func Test_PushMetrics(t *testing.T) {
 						TargetInfo: &TargetInfo{
 							Enabled: true,
 						},
+						NativeHistograms: &NativeHistograms{
+							Enabled: true,
+						},
 					}
 
 					if useWAL {
@@ -836,6 +854,9 @@ func TestWALOnExporterRoundTrip(t *testing.T) {
 		TargetInfo: &TargetInfo{
 			Enabled: true,
 		},
+		NativeHistograms: &NativeHistograms{
+			Enabled: true,
+		},
 	}
 
 	set := exportertest.NewNopCreateSettings()
@@ -191,3 +191,23 @@ func TestEnsureTimeseriesPointsAreSortedByTimestamp(t *testing.T) {
 		}
 	}
 }
+
+func TestEnsureTimeseriesHistogramsAreSortedByTimestamp(t *testing.T) {
+	outOfOrder := []prompb.TimeSeries{
+		{
+			Histograms: []prompb.Histogram{
+				{Sum: 3, Timestamp: 30},
+				{Sum: 1, Timestamp: 10},
+				{Sum: 2, Timestamp: 20},
+			},
+		},
+	}
+	got := convertTimeseriesToRequest(outOfOrder)
+
+	want := []prompb.Histogram{
+		{Sum: 1, Timestamp: 10},
+		{Sum: 2, Timestamp: 20},
+		{Sum: 3, Timestamp: 30},
+	}
+	assert.Equal(t, want, got.Timeseries[0].Histograms)
+}
@@ -64,6 +64,7 @@ var (
 	validHistogram      = "valid_Histogram"
 	validEmptyHistogram = "valid_empty_Histogram"
 	validHistogramNoSum = "valid_Histogram_No_Sum"
+	validExpHistogram   = "valid_ExpHistogram"
 	validSummary        = "valid_Summary"
 	suffixedCounter     = "valid_IntSum_total"
 
@@ -81,6 +82,7 @@ var (
 		validHistogram:      getHistogramMetric(validHistogram, lbs1, time1, &floatVal1, uint64(intVal1), bounds, buckets),
 		validHistogramNoSum: getHistogramMetric(validHistogramNoSum, lbs1, time1, nil, uint64(intVal1), bounds, buckets),
 		validEmptyHistogram: getHistogramMetricEmptyDataPoint(validEmptyHistogram, lbs1, time1),
+		validExpHistogram:   getExpHistogramMetric(validExpHistogram, lbs1, time1, floatVal1, uint64(intVal1), buckets),
 		validSummary:        getSummaryMetric(validSummary, lbs1, time1, floatVal1, uint64(intVal1), quantiles),
 	}
 	validMetrics2 = map[string]pmetric.Metric{
@@ -91,6 +93,7 @@ var (
 		validHistogram:      getHistogramMetric(validHistogram, lbs2, time2, &floatVal2, uint64(intVal2), bounds, buckets),
 		validHistogramNoSum: getHistogramMetric(validHistogramNoSum, lbs2, time2, nil, uint64(intVal2), bounds, buckets),
 		validEmptyHistogram: getHistogramMetricEmptyDataPoint(validEmptyHistogram, lbs2, time2),
+		validExpHistogram:   getExpHistogramMetric(validExpHistogram, lbs2, time2, floatVal2, uint64(intVal2), buckets),
 		validSummary:        getSummaryMetric(validSummary, lbs2, time2, floatVal2, uint64(intVal2), quantiles),
 		validIntGaugeDirty:  getIntGaugeMetric(validIntGaugeDirty, lbs1, intVal1, time1),
 		unmatchedBoundBucketHist: getHistogramMetric(unmatchedBoundBucketHist, pcommon.NewMap(), 0, &floatValZero, 0,
@@ -324,6 +327,20 @@ func getHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum *flo
 	return metric
 }
 
+func getExpHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum float64, count uint64, buckets []uint64) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.Positive().BucketCounts().FromRaw(buckets)
+	attributes.CopyTo(dp.Attributes())
+
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	return metric
+}
+
 func getEmptySummaryMetric(name string) pmetric.Metric {
 	metric := pmetric.NewMetric()
 	metric.SetName(name)
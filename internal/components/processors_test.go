@@ -136,6 +136,9 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "cumulativetodelta",
 		},
+		{
+			processor: "deltatocumulative",
+		},
 		{
 			processor: "tail_sampling",
 		},
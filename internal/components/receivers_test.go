@@ -290,6 +290,9 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver:     "prometheus_exec",
 			skipLifecyle: true, // Requires running a subproccess that can not be easily set across platforms
 		},
+		{
+			receiver: "prometheusremotewrite",
+		},
 		// {
 		// 	receiver: "promtail",
 		// 	getConfigFn: func() component.Config {
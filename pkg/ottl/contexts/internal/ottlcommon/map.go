@@ -15,26 +15,148 @@
 package ottlcommon // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/internal/ottlcommon"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
-func GetMapValue(attrs pcommon.Map, mapKey string) interface{} {
-	val, ok := attrs.Get(mapKey)
+// GetMapValue returns the value found by following the keys into m,
+// the first key selects the entry of m and any following keys index into
+// nested maps or slices. Returns nil if any of the map entries does not exist.
+func GetMapValue(m pcommon.Map, keys []ottl.Key) (interface{}, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("cannot get map value without key")
+	}
+	if keys[0].String == nil {
+		return nil, fmt.Errorf("map must be indexed by a string")
+	}
+	val, ok := m.Get(*keys[0].String)
 	if !ok {
-		return nil
+		return nil, nil
+	}
+	return GetIndexableValue(val, keys[1:])
+}
+
+// SetMapValue sets val at the location found by following the keys into m.
+// Missing map entries are created along the way, once all the keys are checked,
+// so that m is left unchanged when any of them is invalid.
+func SetMapValue(m pcommon.Map, keys []ottl.Key, val interface{}) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("cannot set map value without key")
+	}
+	if keys[0].String == nil {
+		return fmt.Errorf("map must be indexed by a string")
+	}
+	currentValue, ok := m.Get(*keys[0].String)
+	if ok {
+		return SetIndexableValue(currentValue, keys[1:], val)
+	}
+	if err := checkMissingKeys(keys[1:]); err != nil {
+		return err
 	}
-	return GetValue(val)
+	// the new value is built before the entry is created, as val may hold m itself
+	value := newValue(val)
+	setMissingValue(m.PutEmpty(*keys[0].String), keys[1:], value)
+	return nil
 }
 
-func SetMapValue(attrs pcommon.Map, mapKey string, val interface{}) {
-	var value pcommon.Value
-	switch val.(type) {
-	case []string, []bool, []int64, []float64, [][]byte, []any:
-		value = pcommon.NewValueSlice()
-	default:
-		value = pcommon.NewValueEmpty()
+// GetIndexableValue returns the value found by following the keys into val.
+func GetIndexableValue(val pcommon.Value, keys []ottl.Key) (interface{}, error) {
+	for _, key := range keys {
+		switch val.Type() {
+		case pcommon.ValueTypeMap:
+			if key.String == nil {
+				return nil, fmt.Errorf("map must be indexed by a string")
+			}
+			var ok bool
+			val, ok = val.Map().Get(*key.String)
+			if !ok {
+				return nil, nil
+			}
+		case pcommon.ValueTypeSlice:
+			if key.Int == nil {
+				return nil, fmt.Errorf("slice must be indexed by an int")
+			}
+			if *key.Int < 0 || int(*key.Int) >= val.Slice().Len() {
+				return nil, fmt.Errorf("index %v out of bounds", *key.Int)
+			}
+			val = val.Slice().At(int(*key.Int))
+		default:
+			return nil, fmt.Errorf("type %v does not support indexing", val.Type())
+		}
 	}
+	return GetValue(val), nil
+}
+
+// SetIndexableValue sets newVal at the location found by following the keys into val,
+// replacing the value found there. Missing map entries and empty values are turned into
+// maps along the way, once all the keys are checked, so that val is left unchanged when
+// any of them is invalid. Slices are never extended.
+func SetIndexableValue(val pcommon.Value, keys []ottl.Key, newVal interface{}) error {
+	value := newValue(newVal)
+	for i, key := range keys {
+		switch val.Type() {
+		case pcommon.ValueTypeMap:
+			if key.String == nil {
+				return fmt.Errorf("map must be indexed by a string")
+			}
+			next, ok := val.Map().Get(*key.String)
+			if !ok {
+				if err := checkMissingKeys(keys[i+1:]); err != nil {
+					return err
+				}
+				setMissingValue(val.Map().PutEmpty(*key.String), keys[i+1:], value)
+				return nil
+			}
+			val = next
+		case pcommon.ValueTypeSlice:
+			if key.Int == nil {
+				return fmt.Errorf("slice must be indexed by an int")
+			}
+			if *key.Int < 0 || int(*key.Int) >= val.Slice().Len() {
+				return fmt.Errorf("index %v out of bounds", *key.Int)
+			}
+			val = val.Slice().At(int(*key.Int))
+		case pcommon.ValueTypeEmpty:
+			if err := checkMissingKeys(keys[i:]); err != nil {
+				return err
+			}
+			setMissingValue(val, keys[i:], value)
+			return nil
+		default:
+			return fmt.Errorf("type %v does not support indexing", val.Type())
+		}
+	}
+	value.CopyTo(val)
+	return nil
+}
+
+// checkMissingKeys returns an error if the keys cannot index the empty value that
+// is created for a missing entry, only maps being created for them.
+func checkMissingKeys(keys []ottl.Key) error {
+	for _, key := range keys {
+		if key.Int != nil {
+			return fmt.Errorf("index %v out of bounds", *key.Int)
+		}
+	}
+	return nil
+}
+
+// setMissingValue copies newVal into the empty value val, creating a nested map for each of
+// the keys, which must have been checked with checkMissingKeys.
+func setMissingValue(val pcommon.Value, keys []ottl.Key, newVal pcommon.Value) {
+	for _, key := range keys {
+		val = val.SetEmptyMap().PutEmpty(*key.String)
+	}
+	newVal.CopyTo(val)
+}
 
+// newValue returns a new value holding val, which is empty
+// if val is nil or of an unsupported type.
+func newValue(val interface{}) pcommon.Value {
+	value := pcommon.NewValueEmpty()
 	SetValue(value, val)
-	value.CopyTo(attrs.PutEmpty(mapKey))
+	return value
 }
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ottlcommon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
+)
+
+func Test_GetMapValue(t *testing.T) {
+	m := pcommon.NewMap()
+	require.NoError(t, m.FromRaw(map[string]any{
+		"str": "val",
+		"map": map[string]any{
+			"slice": []any{"zero", map[string]any{"key": int64(1)}},
+		},
+	}))
+
+	tests := []struct {
+		name    string
+		keys    []ottl.Key
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name: "top level key",
+			keys: []ottl.Key{{String: ottltest.Strp("str")}},
+			want: "val",
+		},
+		{
+			name: "nested map and slice",
+			keys: []ottl.Key{
+				{String: ottltest.Strp("map")},
+				{String: ottltest.Strp("slice")},
+				{Int: ottltest.Intp(1)},
+				{String: ottltest.Strp("key")},
+			},
+			want: int64(1),
+		},
+		{
+			name: "missing key",
+			keys: []ottl.Key{
+				{String: ottltest.Strp("map")},
+				{String: ottltest.Strp("missing")},
+			},
+			want: nil,
+		},
+		{
+			name:    "no keys",
+			keys:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "map indexed by int",
+			keys:    []ottl.Key{{Int: ottltest.Intp(0)}},
+			wantErr: true,
+		},
+		{
+			name: "slice index out of bounds",
+			keys: []ottl.Key{
+				{String: ottltest.Strp("map")},
+				{String: ottltest.Strp("slice")},
+				{Int: ottltest.Intp(2)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "indexing a string",
+			keys: []ottl.Key{
+				{String: ottltest.Strp("str")},
+				{String: ottltest.Strp("key")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetMapValue(m, tt.keys)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_SetMapValue(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("str", "val")
+	m.PutEmptySlice("slice").AppendEmpty().SetStr("zero")
+
+	require.NoError(t, SetMapValue(m, []ottl.Key{
+		{String: ottltest.Strp("map")},
+		{String: ottltest.Strp("key")},
+	}, "new"))
+	require.NoError(t, SetMapValue(m, []ottl.Key{
+		{String: ottltest.Strp("slice")},
+		{Int: ottltest.Intp(0)},
+	}, []any{"a", "b"}))
+
+	assert.Equal(t, map[string]any{
+		"str":   "val",
+		"map":   map[string]any{"key": "new"},
+		"slice": []any{[]any{"a", "b"}},
+	}, m.AsRaw())
+}
+
+func Test_SetMapValue_Replace(t *testing.T) {
+	m := pcommon.NewMap()
+	require.NoError(t, m.FromRaw(map[string]any{
+		"str": "val",
+		"map": map[string]any{"key": "val"},
+	}))
+
+	require.NoError(t, SetMapValue(m, []ottl.Key{{String: ottltest.Strp("str")}}, nil))
+	require.NoError(t, SetMapValue(m, []ottl.Key{
+		{String: ottltest.Strp("map")},
+		{String: ottltest.Strp("key")},
+	}, struct{}{}))
+
+	assert.Equal(t, map[string]any{
+		"str": nil,
+		"map": map[string]any{"key": nil},
+	}, m.AsRaw())
+}
+
+func Test_SetMapValue_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []ottl.Key
+	}{
+		{
+			name: "slice index out of bounds",
+			keys: []ottl.Key{
+				{String: ottltest.Strp("slice")},
+				{Int: ottltest.Intp(5)},
+			},
+		},
+		{
+			name: "indexing a string",
+			keys: []ottl.Key{
+				{String: ottltest.Strp("str")},
+				{String: ottltest.Strp("key")},
+			},
+		},
+		{
+			name: "missing entry indexed by int",
+			keys: []ottl.Key{
+				{String: ottltest.Strp("missing")},
+				{Int: ottltest.Intp(2000000000)},
+			},
+		},
+		{
+			name: "missing nested entry indexed by int",
+			keys: []ottl.Key{
+				{String: ottltest.Strp("map")},
+				{String: ottltest.Strp("missing")},
+				{String: ottltest.Strp("nested")},
+				{Int: ottltest.Intp(0)},
+			},
+		},
+		{
+			name: "empty value indexed by int",
+			keys: []ottl.Key{
+				{String: ottltest.Strp("empty")},
+				{Int: ottltest.Intp(0)},
+			},
+		},
+		{
+			name: "missing entry indexed by wrong key type",
+			keys: []ottl.Key{
+				{String: ottltest.Strp("missing")},
+				{String: ottltest.Strp("nested")},
+				{Int: ottltest.Intp(0)},
+				{String: ottltest.Strp("key")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := pcommon.NewMap()
+			m.PutStr("str", "val")
+			m.PutEmpty("empty")
+			m.PutEmptyMap("map")
+			m.PutEmptySlice("slice").AppendEmpty().SetStr("zero")
+			want := m.AsRaw()
+
+			assert.Error(t, SetMapValue(m, tt.keys, "new"))
+			assert.Equal(t, want, m.AsRaw())
+		})
+	}
+}
@@ -89,8 +89,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("str"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("str")}},
 				},
 			},
 			orig:   "val",
@@ -116,8 +116,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("bool")}},
 				},
 			},
 			orig:   true,
@@ -130,8 +130,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("int"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("int")}},
 				},
 			},
 			orig:   int64(10),
@@ -144,8 +144,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("double"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("double")}},
 				},
 			},
 			orig:   1.2,
@@ -158,8 +158,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("bytes")}},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -172,8 +172,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes array empty",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_empty"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_empty")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -189,8 +189,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -207,8 +207,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -225,8 +225,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -243,8 +243,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -261,8 +261,8 @@ func TestScopePathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -64,11 +64,14 @@ func SpanPathGetSetter[K SpanContext](path []ottl.Field) (ottl.GetSetter[K], err
 			return accessStringSpanID[K](), nil
 		}
 	case "trace_state":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		mapKeys := path[0].Keys
+		if mapKeys == nil {
 			return accessTraceState[K](), nil
 		}
-		return accessTraceStateKey[K](mapKey), nil
+		if len(mapKeys) != 1 || mapKeys[0].String == nil {
+			return nil, fmt.Errorf("trace_state must be indexed by a single string key")
+		}
+		return accessTraceStateKey[K](*mapKeys[0].String), nil
 	case "parent_span_id":
 		if len(path) == 1 {
 			return accessParentSpanID[K](), nil
@@ -85,11 +88,11 @@ func SpanPathGetSetter[K SpanContext](path []ottl.Field) (ottl.GetSetter[K], err
 	case "end_time_unix_nano":
 		return accessEndTimeUnixNano[K](), nil
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		mapKeys := path[0].Keys
+		if mapKeys == nil {
 			return accessAttributes[K](), nil
 		}
-		return accessAttributesKey[K](mapKey), nil
+		return accessAttributesKey[K](mapKeys), nil
 	case "dropped_attributes_count":
 		return accessSpanDroppedAttributesCount[K](), nil
 	case "events":
@@ -209,18 +212,18 @@ func accessTraceState[K SpanContext]() ottl.StandardGetSetter[K] {
 	}
 }
 
-func accessTraceStateKey[K SpanContext](mapKey *string) ottl.StandardGetSetter[K] {
+func accessTraceStateKey[K SpanContext](mapKey string) ottl.StandardGetSetter[K] {
 	return ottl.StandardGetSetter[K]{
 		Getter: func(ctx context.Context, tCtx K) (interface{}, error) {
 			if ts, err := trace.ParseTraceState(tCtx.GetSpan().TraceState().AsRaw()); err == nil {
-				return ts.Get(*mapKey), nil
+				return ts.Get(mapKey), nil
 			}
 			return nil, nil
 		},
 		Setter: func(ctx context.Context, tCtx K, val interface{}) error {
 			if str, ok := val.(string); ok {
 				if ts, err := trace.ParseTraceState(tCtx.GetSpan().TraceState().AsRaw()); err == nil {
-					if updated, err := ts.Insert(*mapKey, str); err == nil {
+					if updated, err := ts.Insert(mapKey, str); err == nil {
 						tCtx.GetSpan().TraceState().FromRaw(updated.String())
 					}
 				}
@@ -333,14 +336,13 @@ func accessAttributes[K SpanContext]() ottl.StandardGetSetter[K] {
 	}
 }
 
-func accessAttributesKey[K SpanContext](mapKey *string) ottl.StandardGetSetter[K] {
+func accessAttributesKey[K SpanContext](keys []ottl.Key) ottl.StandardGetSetter[K] {
 	return ottl.StandardGetSetter[K]{
 		Getter: func(ctx context.Context, tCtx K) (interface{}, error) {
-			return GetMapValue(tCtx.GetSpan().Attributes(), *mapKey), nil
+			return GetMapValue(tCtx.GetSpan().Attributes(), keys)
 		},
 		Setter: func(ctx context.Context, tCtx K, val interface{}) error {
-			SetMapValue(tCtx.GetSpan().Attributes(), *mapKey, val)
-			return nil
+			return SetMapValue(tCtx.GetSpan().Attributes(), keys, val)
 		},
 	}
 }
@@ -83,10 +83,12 @@ func SetValue(value pcommon.Value, val interface{}) {
 		}
 	case pcommon.Map:
 		v.CopyTo(value.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(value.SetEmptySlice())
 	case map[string]interface{}:
 		value.SetEmptyMap()
 		for mk, mv := range v {
-			SetMapValue(value.Map(), mk, mv)
+			SetValue(value.Map().PutEmpty(mk), mv)
 		}
 	}
 }
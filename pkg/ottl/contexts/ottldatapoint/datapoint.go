@@ -109,11 +109,11 @@ func newPathGetSetter(path []ottl.Field) (ottl.GetSetter[TransformContext], erro
 	case "metric":
 		return ottlcommon.MetricPathGetSetter[TransformContext](path[1:])
 	case "attributes":
-		mapKey := path[0].MapKey
-		if mapKey == nil {
+		mapKeys := path[0].Keys
+		if mapKeys == nil {
 			return accessAttributes(), nil
 		}
-		return accessAttributesKey(mapKey), nil
+		return accessAttributesKey(mapKeys), nil
 	case "start_time_unix_nano":
 		return accessStartTimeUnixNano(), nil
 	case "time_unix_nano":
@@ -203,31 +203,31 @@ func accessAttributes() ottl.StandardGetSetter[TransformContext] {
 	}
 }
 
-func accessAttributesKey(mapKey *string) ottl.StandardGetSetter[TransformContext] {
+func accessAttributesKey(keys []ottl.Key) ottl.StandardGetSetter[TransformContext] {
 	return ottl.StandardGetSetter[TransformContext]{
 		Getter: func(ctx context.Context, tCtx TransformContext) (interface{}, error) {
 			switch tCtx.GetDataPoint().(type) {
 			case pmetric.NumberDataPoint:
-				return ottlcommon.GetMapValue(tCtx.GetDataPoint().(pmetric.NumberDataPoint).Attributes(), *mapKey), nil
+				return ottlcommon.GetMapValue(tCtx.GetDataPoint().(pmetric.NumberDataPoint).Attributes(), keys)
 			case pmetric.HistogramDataPoint:
-				return ottlcommon.GetMapValue(tCtx.GetDataPoint().(pmetric.HistogramDataPoint).Attributes(), *mapKey), nil
+				return ottlcommon.GetMapValue(tCtx.GetDataPoint().(pmetric.HistogramDataPoint).Attributes(), keys)
 			case pmetric.ExponentialHistogramDataPoint:
-				return ottlcommon.GetMapValue(tCtx.GetDataPoint().(pmetric.ExponentialHistogramDataPoint).Attributes(), *mapKey), nil
+				return ottlcommon.GetMapValue(tCtx.GetDataPoint().(pmetric.ExponentialHistogramDataPoint).Attributes(), keys)
 			case pmetric.SummaryDataPoint:
-				return ottlcommon.GetMapValue(tCtx.GetDataPoint().(pmetric.SummaryDataPoint).Attributes(), *mapKey), nil
+				return ottlcommon.GetMapValue(tCtx.GetDataPoint().(pmetric.SummaryDataPoint).Attributes(), keys)
 			}
 			return nil, nil
 		},
 		Setter: func(ctx context.Context, tCtx TransformContext, val interface{}) error {
 			switch tCtx.GetDataPoint().(type) {
 			case pmetric.NumberDataPoint:
-				ottlcommon.SetMapValue(tCtx.GetDataPoint().(pmetric.NumberDataPoint).Attributes(), *mapKey, val)
+				return ottlcommon.SetMapValue(tCtx.GetDataPoint().(pmetric.NumberDataPoint).Attributes(), keys, val)
 			case pmetric.HistogramDataPoint:
-				ottlcommon.SetMapValue(tCtx.GetDataPoint().(pmetric.HistogramDataPoint).Attributes(), *mapKey, val)
+				return ottlcommon.SetMapValue(tCtx.GetDataPoint().(pmetric.HistogramDataPoint).Attributes(), keys, val)
 			case pmetric.ExponentialHistogramDataPoint:
-				ottlcommon.SetMapValue(tCtx.GetDataPoint().(pmetric.ExponentialHistogramDataPoint).Attributes(), *mapKey, val)
+				return ottlcommon.SetMapValue(tCtx.GetDataPoint().(pmetric.ExponentialHistogramDataPoint).Attributes(), keys, val)
 			case pmetric.SummaryDataPoint:
-				ottlcommon.SetMapValue(tCtx.GetDataPoint().(pmetric.SummaryDataPoint).Attributes(), *mapKey, val)
+				return ottlcommon.SetMapValue(tCtx.GetDataPoint().(pmetric.SummaryDataPoint).Attributes(), keys, val)
 			}
 			return nil
 		},
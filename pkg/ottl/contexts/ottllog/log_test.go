@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 
@@ -210,8 +211,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("str"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("str")}},
 				},
 			},
 			orig:   "val",
@@ -220,12 +221,48 @@ func Test_newPathGetSetter(t *testing.T) {
 				log.Attributes().PutStr("str", "newVal")
 			},
 		},
+		{
+			name: "attributes nested map",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{String: ottltest.Strp("map")},
+						{String: ottltest.Strp("original")},
+					},
+				},
+			},
+			orig:   "map",
+			newVal: "new",
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				m, _ := log.Attributes().Get("map")
+				m.Map().PutStr("original", "new")
+			},
+		},
+		{
+			name: "attributes slice index",
+			path: []ottl.Field{
+				{
+					Name: "attributes",
+					Keys: []ottl.Key{
+						{String: ottltest.Strp("arr_str")},
+						{Int: ottltest.Intp(1)},
+					},
+				},
+			},
+			orig:   "two",
+			newVal: "three",
+			modified: func(log plog.LogRecord, il pcommon.InstrumentationScope, resource pcommon.Resource) {
+				s, _ := log.Attributes().Get("arr_str")
+				s.Slice().At(1).SetStr("three")
+			},
+		},
 		{
 			name: "attributes bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("bool")}},
 				},
 			},
 			orig:   true,
@@ -238,8 +275,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("int"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("int")}},
 				},
 			},
 			orig:   int64(10),
@@ -252,8 +289,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("double"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("double")}},
 				},
 			},
 			orig:   float64(1.2),
@@ -266,8 +303,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("bytes")}},
 				},
 			},
 			orig:   []byte{1, 3, 2},
@@ -280,8 +317,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array string",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_str"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_str")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -297,8 +334,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bool",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bool"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_bool")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -314,8 +351,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array int",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_int"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_int")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -331,8 +368,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array float",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_float"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_float")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -348,8 +385,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes array bytes",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("arr_bytes"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("arr_bytes")}},
 				},
 			},
 			orig: func() pcommon.Slice {
@@ -365,8 +402,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes pcommon.Map",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("pMap"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("pMap")}},
 				},
 			},
 			orig: func() pcommon.Map {
@@ -384,8 +421,8 @@ func Test_newPathGetSetter(t *testing.T) {
 			name: "attributes map[string]interface{}",
 			path: []ottl.Field{
 				{
-					Name:   "attributes",
-					MapKey: ottltest.Strp("map"),
+					Name: "attributes",
+					Keys: []ottl.Key{{String: ottltest.Strp("map")}},
 				},
 			},
 			orig: func() pcommon.Map {
@@ -656,3 +693,68 @@ func Test_ParseEnum_False(t *testing.T) {
 		})
 	}
 }
+
+func Test_newPathGetSetter_bodyKeys(t *testing.T) {
+	log, il, resource := createTelemetry()
+	body := log.Body().SetEmptyMap()
+	body.PutEmptyMap("http").PutEmptySlice("headers").AppendEmpty().SetStr("accept")
+
+	accessor, err := newPathGetSetter([]ottl.Field{
+		{
+			Name: "body",
+			Keys: []ottl.Key{
+				{String: ottltest.Strp("http")},
+				{String: ottltest.Strp("headers")},
+				{Int: ottltest.Intp(0)},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	tCtx := NewTransformContext(log, il, resource)
+	got, err := accessor.Get(context.Background(), tCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "accept", got)
+
+	require.NoError(t, accessor.Set(context.Background(), tCtx, "content-type"))
+	assert.Equal(t, map[string]any{
+		"http": map[string]any{
+			"headers": []any{"content-type"},
+		},
+	}, log.Body().Map().AsRaw())
+
+	accessor, err = newPathGetSetter([]ottl.Field{
+		{
+			Name: "body",
+			Keys: []ottl.Key{
+				{String: ottltest.Strp("http")},
+				{String: ottltest.Strp("status")},
+				{String: ottltest.Strp("code")},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	got, err = accessor.Get(context.Background(), tCtx)
+	require.NoError(t, err)
+	assert.Nil(t, got, "Must return nil for missing keys")
+
+	require.NoError(t, accessor.Set(context.Background(), tCtx, int64(200)))
+	status, _ := log.Body().Map().Get("http")
+	assert.Equal(t, map[string]any{"code": int64(200)}, status.Map().AsRaw()["status"], "Must create missing maps")
+
+	accessor, err = newPathGetSetter([]ottl.Field{
+		{
+			Name: "body",
+			Keys: []ottl.Key{
+				{String: ottltest.Strp("http")},
+				{String: ottltest.Strp("headers")},
+				{String: ottltest.Strp("invalid")},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = accessor.Get(context.Background(), tCtx)
+	assert.Error(t, err, "Must not index a slice with a string")
+}
@@ -17,6 +17,8 @@ package ottl // import "github.com/open-telemetry/opentelemetry-collector-contri
 import (
 	"context"
 	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
 type ExprFunc[K any] func(ctx context.Context, tCtx K) (interface{}, error)
@@ -89,6 +91,46 @@ func (l *listGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
 	return evaluated, nil
 }
 
+type mapGetter[K any] struct {
+	mapValues map[string]Getter[K]
+}
+
+func (m *mapGetter[K]) Get(ctx context.Context, tCtx K) (interface{}, error) {
+	evaluated := make(map[string]any, len(m.mapValues))
+	for k, v := range m.mapValues {
+		val, err := v.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		evaluated[k] = toRaw(val)
+	}
+
+	result := pcommon.NewMap()
+	if err := result.FromRaw(evaluated); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// toRaw converts the values returned by getters into
+// the types understood by pcommon.Map's FromRaw.
+func toRaw(val any) any {
+	switch v := val.(type) {
+	case pcommon.Map:
+		return v.AsRaw()
+	case pcommon.Slice:
+		return v.AsRaw()
+	case []any:
+		raw := make([]any, len(v))
+		for i, item := range v {
+			raw[i] = toRaw(item)
+		}
+		return raw
+	default:
+		return val
+	}
+}
+
 func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 	if val.IsNil != nil && *val.IsNil {
 		return &literal[K]{value: nil}, nil
@@ -120,6 +162,7 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 			return &literal[K]{value: *i}, nil
 		}
 		if eL.Path != nil {
+			eL.Path.setMapKeys()
 			return p.pathParser(eL.Path)
 		}
 		if eL.Converter != nil {
@@ -148,6 +191,18 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 		return &lg, nil
 	}
 
+	if val.Map != nil {
+		mg := mapGetter[K]{mapValues: make(map[string]Getter[K], len(val.Map.Values))}
+		for _, kvp := range val.Map.Values {
+			getter, err := p.newGetter(*kvp.Value)
+			if err != nil {
+				return nil, err
+			}
+			mg.mapValues[*kvp.Key] = getter
+		}
+		return &mg, nil
+	}
+
 	if val.MathExpression == nil {
 		// In practice, can't happen since the DSL grammar guarantees one is set
 		return nil, fmt.Errorf("no value field set. This is a bug in the OpenTelemetry Transformation Language")
@@ -19,7 +19,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
 )
@@ -318,3 +320,129 @@ func Test_newGetter(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func Test_newGetter_map(t *testing.T) {
+	p := NewParser(
+		map[string]interface{}{"Hello": hello[interface{}]},
+		testParsePath,
+		testParseEnum,
+		component.TelemetrySettings{},
+	)
+
+	val := value{
+		Map: &mapValue{
+			Values: []mapItem{
+				{
+					Key:   ottltest.Strp("string"),
+					Value: &value{String: ottltest.Strp("bar")},
+				},
+				{
+					Key: ottltest.Strp("path"),
+					Value: &value{
+						Literal: &mathExprLiteral{
+							Path: &Path{
+								Fields: []Field{
+									{
+										Name: "name",
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Key: ottltest.Strp("function"),
+					Value: &value{
+						Literal: &mathExprLiteral{
+							Converter: &converter{
+								Function: "Hello",
+							},
+						},
+					},
+				},
+				{
+					Key: ottltest.Strp("nested"),
+					Value: &value{
+						Map: &mapValue{
+							Values: []mapItem{
+								{
+									Key: ottltest.Strp("list"),
+									Value: &value{
+										List: &list{
+											Values: []value{
+												{Literal: &mathExprLiteral{Int: ottltest.Intp(1)}},
+												{Map: &mapValue{}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	reader, err := p.newGetter(val)
+	require.NoError(t, err)
+
+	got, err := reader.Get(context.Background(), "bear")
+	require.NoError(t, err)
+	require.IsType(t, pcommon.Map{}, got)
+	assert.Equal(t, map[string]any{
+		"string":   "bar",
+		"path":     "bear",
+		"function": "world",
+		"nested": map[string]any{
+			"list": []any{int64(1), map[string]any{}},
+		},
+	}, got.(pcommon.Map).AsRaw())
+}
+
+func Test_newGetter_deprecatedMapKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       []Key
+		wantMapKey *string
+	}{
+		{
+			name: "no key",
+		},
+		{
+			name:       "single string key",
+			keys:       []Key{{String: ottltest.Strp("key")}},
+			wantMapKey: ottltest.Strp("key"),
+		},
+		{
+			name: "single int key",
+			keys: []Key{{Int: ottltest.Intp(0)}},
+		},
+		{
+			name: "multiple keys",
+			keys: []Key{{String: ottltest.Strp("key")}, {String: ottltest.Strp("nested")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Path
+			p := NewParser(
+				map[string]interface{}{},
+				func(val *Path) (GetSetter[interface{}], error) {
+					got = val
+					return testParsePath(val)
+				},
+				testParseEnum,
+				component.TelemetrySettings{},
+			)
+
+			_, err := p.newGetter(value{
+				Literal: &mathExprLiteral{
+					Path: &Path{Fields: []Field{{Name: "name", Keys: tt.keys}}},
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMapKey, got.Fields[0].MapKey)
+		})
+	}
+}
@@ -140,6 +140,7 @@ func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 		if argVal.Literal == nil || argVal.Literal.Path == nil {
 			return nil, fmt.Errorf("must be a Path")
 		}
+		argVal.Literal.Path.setMapKeys()
 		arg, err := p.pathParser(argVal.Literal.Path)
 		if err != nil {
 			return nil, err
@@ -22,9 +22,10 @@ import (
 
 // parsedStatement represents a parsed statement. It is the entry point into the statement DSL.
 type parsedStatement struct {
-	Invocation invocation `parser:"(@@"`
+	Conditional *conditional `parser:"( @@"`
+	Invocation  invocation   `parser:"| @@"`
 	// If converter is matched then return error
-	Converter   *converter         `parser:"|@@)"`
+	Converter   *converter         `parser:"| @@ )"`
 	WhereClause *booleanExpression `parser:"( 'where' @@ )?"`
 }
 
@@ -32,6 +33,12 @@ func (p *parsedStatement) checkForCustomError() error {
 	if p.Converter != nil {
 		return fmt.Errorf("invocation names must start with a lowercase letter but got '%v'", p.Converter.Function)
 	}
+	if p.Conditional != nil {
+		if p.WhereClause != nil {
+			return fmt.Errorf("where clauses can not be used with conditional statements")
+		}
+		return p.Conditional.checkForCustomError()
+	}
 	err := p.Invocation.checkForCustomError()
 	if err != nil {
 		return err
@@ -42,6 +49,62 @@ func (p *parsedStatement) checkForCustomError() error {
 	return nil
 }
 
+// conditional represents a group of statements that is only executed when its condition is met.
+// It can be followed by any number of elif branches and an else branch, only the
+// statements of the first branch that matches are executed.
+type conditional struct {
+	If     *conditionalBranch   `parser:"'if' @@"`
+	ElseIf []*conditionalBranch `parser:"( 'elif' @@ )*"`
+	Else   *statementBlock      `parser:"( 'else' @@ )?"`
+}
+
+func (c *conditional) checkForCustomError() error {
+	err := c.If.checkForCustomError()
+	if err != nil {
+		return err
+	}
+	for _, b := range c.ElseIf {
+		err = b.checkForCustomError()
+		if err != nil {
+			return err
+		}
+	}
+	if c.Else != nil {
+		return c.Else.checkForCustomError()
+	}
+	return nil
+}
+
+// conditionalBranch represents a condition and the statements executed when it is met.
+type conditionalBranch struct {
+	Condition *booleanExpression `parser:"@@"`
+	Block     *statementBlock    `parser:"@@"`
+}
+
+func (b *conditionalBranch) checkForCustomError() error {
+	err := b.Condition.checkForCustomError()
+	if err != nil {
+		return err
+	}
+	return b.Block.checkForCustomError()
+}
+
+// statementBlock represents a list of statements surrounded by curly braces,
+// statements can optionally be separated by semicolons.
+type statementBlock struct {
+	Statements []*parsedStatement `parser:"'{' ( @@ ';'? )* '}'"`
+}
+
+func (b *statementBlock) checkForCustomError() error {
+	for _, s := range b.Statements {
+		err := s.checkForCustomError()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // booleanValue represents something that evaluates to a boolean --
 // either an equality or inequality, explicit true or false, or
 // a parenthesized subexpression.
@@ -225,6 +288,7 @@ type value struct {
 	String         *string          `parser:"| @String"`
 	Bool           *boolean         `parser:"| @Boolean"`
 	Enum           *EnumSymbol      `parser:"| @Uppercase"`
+	Map            *mapValue        `parser:"| @@"`
 	List           *list            `parser:"| @@)"`
 }
 
@@ -235,6 +299,12 @@ func (v *value) checkForCustomError() error {
 	if v.MathExpression != nil {
 		return v.MathExpression.checkForCustomError()
 	}
+	if v.Map != nil {
+		return v.Map.checkForCustomError()
+	}
+	if v.List != nil {
+		return v.List.checkForCustomError()
+	}
 	return nil
 }
 
@@ -244,15 +314,65 @@ type Path struct {
 }
 
 // Field is an item within a Path.
+// A Field can be followed by any number of keys, allowing to access
+// nested maps by string keys and slices by integer indexes.
 type Field struct {
-	Name   string  `parser:"@Lowercase"`
-	MapKey *string `parser:"( '[' @String ']' )?"`
+	Name string `parser:"@Lowercase"`
+	// Deprecated: [v0.70.0] use Keys instead. MapKey is only set when the Field
+	// is followed by a single string key, and will be removed in a future release.
+	MapKey *string
+	Keys   []Key `parser:"( @@ )*"`
+}
+
+// setMapKeys sets the deprecated MapKey of each Field of the Path
+// that is followed by a single string key.
+func (p *Path) setMapKeys() {
+	for i, field := range p.Fields {
+		if len(field.Keys) == 1 && field.Keys[0].String != nil {
+			p.Fields[i].MapKey = field.Keys[0].String
+		}
+	}
+}
+
+// Key represents a single index into a map or a slice.
+// Only one of String or Int will be set.
+type Key struct {
+	String *string `parser:"'[' ( @String"`
+	Int    *int64  `parser:"| @Int ) ']'"`
 }
 
 type list struct {
 	Values []value `parser:"'[' (@@)* (',' @@)* ']'"`
 }
 
+func (l *list) checkForCustomError() error {
+	for _, v := range l.Values {
+		if err := v.checkForCustomError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapValue represents a map literal, e.g. {"key": "value", "nested": {"key": 1}}.
+type mapValue struct {
+	Values []mapItem `parser:"'{' ( @@ ( ',' @@ )* )? '}'"`
+}
+
+func (m *mapValue) checkForCustomError() error {
+	for _, item := range m.Values {
+		if err := item.Value.checkForCustomError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type mapItem struct {
+	Key   *string `parser:"@String ':'"`
+	Value *value  `parser:"@@"`
+}
+
 // byteSlice type for capturing byte slices
 type byteSlice []byte
 
@@ -426,7 +546,7 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `Boolean`, Pattern: `\b(true|false)\b`},
 		{Name: `LParen`, Pattern: `\(`},
 		{Name: `RParen`, Pattern: `\)`},
-		{Name: `Punct`, Pattern: `[,.\[\]]`},
+		{Name: `Punct`, Pattern: `[,.:;\[\]{}]`},
 		{Name: `Uppercase`, Pattern: `[A-Z][A-Z0-9_]*`},
 		{Name: `Lowercase`, Pattern: `[a-z][a-z0-9_]*`},
 		{Name: "whitespace", Pattern: `\s+`},
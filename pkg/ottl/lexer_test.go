@@ -89,9 +89,18 @@ func Test_lexer(t *testing.T) {
 			{"OpNot", "not"},
 			{"Boolean", "false"},
 		}},
-		{"nothing_recognizable", "{}", true, []result{
+		{"nothing_recognizable", "|", true, []result{
 			{"", ""},
 		}},
+		{"map_literal", `{"foo": [0]}`, false, []result{
+			{"Punct", "{"},
+			{"String", `"foo"`},
+			{"Punct", ":"},
+			{"Punct", "["},
+			{"Int", "0"},
+			{"Punct", "]"},
+			{"Punct", "}"},
+		}},
 		{"basic_ident_expr", `set(attributes["bytes"], 0x0102030405060708)`, false, []result{
 			{"Lowercase", "set"},
 			{"LParen", "("},
@@ -32,16 +32,34 @@ type Parser[K any] struct {
 
 // Statement holds a top level Statement for processing telemetry data. A Statement is a combination of a function
 // invocation and the boolean expression to match telemetry for invoking the function.
+// A Statement can also be a conditional statement, grouping statements into branches
+// where only the statements of the first branch whose condition is met are executed.
 type Statement[K any] struct {
 	function  Expr[K]
 	condition BoolExpr[K]
+	// branches and otherwise are only set for conditional statements,
+	// in which case function and condition are not used.
+	branches  []branch[K]
+	otherwise []*Statement[K]
+}
+
+// branch holds the statements that are executed when the condition is met.
+type branch[K any] struct {
+	condition  BoolExpr[K]
+	statements []*Statement[K]
 }
 
 // Execute is a function that will execute the statement's function if the statement's condition is met.
 // Returns true if the function was run, returns false otherwise.
 // If the statement contains no condition, the function will run and true will be returned.
 // In addition, the functions return value is always returned.
+// For conditional statements, the conditions of each branch are evaluated once, in order, and the statements of
+// the first branch that is met, or of the else branch if none are, are executed. Returns true if any statements
+// were executed and a nil result.
 func (s *Statement[K]) Execute(ctx context.Context, tCtx K) (any, bool, error) {
+	if s.branches != nil {
+		return s.executeBranches(ctx, tCtx)
+	}
 	condition, err := s.condition.Eval(ctx, tCtx)
 	if err != nil {
 		return nil, false, err
@@ -56,6 +74,31 @@ func (s *Statement[K]) Execute(ctx context.Context, tCtx K) (any, bool, error) {
 	return result, condition, nil
 }
 
+func (s *Statement[K]) executeBranches(ctx context.Context, tCtx K) (any, bool, error) {
+	for _, b := range s.branches {
+		condition, err := b.condition.Eval(ctx, tCtx)
+		if err != nil {
+			return nil, false, err
+		}
+		if condition {
+			return nil, true, executeStatements(ctx, tCtx, b.statements)
+		}
+	}
+	if s.otherwise != nil {
+		return nil, true, executeStatements(ctx, tCtx, s.otherwise)
+	}
+	return nil, false, nil
+}
+
+func executeStatements[K any](ctx context.Context, tCtx K, statements []*Statement[K]) error {
+	for _, statement := range statements {
+		if _, _, err := statement.Execute(ctx, tCtx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func NewParser[K any](functions map[string]interface{}, pathParser PathExpressionParser[K], enumParser EnumParser, telemetrySettings component.TelemetrySettings) Parser[K] {
 	return Parser[K]{
 		functions:         functions,
@@ -75,20 +118,12 @@ func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error
 			errors = multierr.Append(errors, err)
 			continue
 		}
-		function, err := p.newFunctionCall(parsed.Invocation)
+		s, err := p.newStatement(parsed)
 		if err != nil {
 			errors = multierr.Append(errors, err)
 			continue
 		}
-		expression, err := p.newBoolExpr(parsed.WhereClause)
-		if err != nil {
-			errors = multierr.Append(errors, err)
-			continue
-		}
-		parsedStatements = append(parsedStatements, &Statement[K]{
-			function:  function,
-			condition: expression,
-		})
+		parsedStatements = append(parsedStatements, s)
 	}
 
 	if errors != nil {
@@ -97,6 +132,64 @@ func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error
 	return parsedStatements, nil
 }
 
+func (p *Parser[K]) newStatement(parsed *parsedStatement) (*Statement[K], error) {
+	if parsed.Conditional != nil {
+		return p.newConditionalStatement(parsed.Conditional)
+	}
+	function, err := p.newFunctionCall(parsed.Invocation)
+	if err != nil {
+		return nil, err
+	}
+	expression, err := p.newBoolExpr(parsed.WhereClause)
+	if err != nil {
+		return nil, err
+	}
+	return &Statement[K]{
+		function:  function,
+		condition: expression,
+	}, nil
+}
+
+func (p *Parser[K]) newConditionalStatement(c *conditional) (*Statement[K], error) {
+	branches := make([]branch[K], 0, len(c.ElseIf)+1)
+	for _, cb := range append([]*conditionalBranch{c.If}, c.ElseIf...) {
+		condition, err := p.newBoolExpr(cb.Condition)
+		if err != nil {
+			return nil, err
+		}
+		statements, err := p.newStatementBlock(cb.Block)
+		if err != nil {
+			return nil, err
+		}
+		branches = append(branches, branch[K]{
+			condition:  condition,
+			statements: statements,
+		})
+	}
+
+	s := &Statement[K]{branches: branches}
+	if c.Else != nil {
+		otherwise, err := p.newStatementBlock(c.Else)
+		if err != nil {
+			return nil, err
+		}
+		s.otherwise = otherwise
+	}
+	return s, nil
+}
+
+func (p *Parser[K]) newStatementBlock(block *statementBlock) ([]*Statement[K], error) {
+	statements := make([]*Statement[K], 0, len(block.Statements))
+	for _, parsed := range block.Statements {
+		s, err := p.newStatement(parsed)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, s)
+	}
+	return statements, nil
+}
+
 var parser = newParser[parsedStatement]()
 
 func parseStatement(raw string) (*parsedStatement, error) {
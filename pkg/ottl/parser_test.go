@@ -22,6 +22,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottltest"
 )
@@ -139,8 +141,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -157,6 +159,166 @@ func Test_parse(t *testing.T) {
 				WhereClause: nil,
 			},
 		},
+		{
+			name:      "complex path with multiple keys",
+			statement: `set(attributes["foo"]["bar"][0], "dog")`,
+			expected: &parsedStatement{
+				Invocation: invocation{
+					Function: "set",
+					Arguments: []value{
+						{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "attributes",
+											Keys: []Key{
+												{String: ottltest.Strp("foo")},
+												{String: ottltest.Strp("bar")},
+												{Int: ottltest.Intp(0)},
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							String: ottltest.Strp("dog"),
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:      "invocation with map",
+			statement: `set(attributes["foo"], {"bar": "dog", "nested": {"list": [1, 2]}, "empty": {}})`,
+			expected: &parsedStatement{
+				Invocation: invocation{
+					Function: "set",
+					Arguments: []value{
+						{
+							Literal: &mathExprLiteral{
+								Path: &Path{
+									Fields: []Field{
+										{
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("foo")}},
+										},
+									},
+								},
+							},
+						},
+						{
+							Map: &mapValue{
+								Values: []mapItem{
+									{
+										Key:   ottltest.Strp("bar"),
+										Value: &value{String: ottltest.Strp("dog")},
+									},
+									{
+										Key: ottltest.Strp("nested"),
+										Value: &value{
+											Map: &mapValue{
+												Values: []mapItem{
+													{
+														Key: ottltest.Strp("list"),
+														Value: &value{
+															List: &list{
+																Values: []value{
+																	{Literal: &mathExprLiteral{Int: ottltest.Intp(1)}},
+																	{Literal: &mathExprLiteral{Int: ottltest.Intp(2)}},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+									{
+										Key:   ottltest.Strp("empty"),
+										Value: &value{Map: &mapValue{}},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:      "conditional statement",
+			statement: `if true { set("foo"); set("bar") where false } elif false { set("baz") } else { set("qux") }`,
+			expected: &parsedStatement{
+				Conditional: &conditional{
+					If: &conditionalBranch{
+						Condition: &booleanExpression{
+							Left: &term{
+								Left: &booleanValue{
+									ConstExpr: booleanp(true),
+								},
+							},
+						},
+						Block: &statementBlock{
+							Statements: []*parsedStatement{
+								{
+									Invocation: invocation{
+										Function:  "set",
+										Arguments: []value{{String: ottltest.Strp("foo")}},
+									},
+								},
+								{
+									Invocation: invocation{
+										Function:  "set",
+										Arguments: []value{{String: ottltest.Strp("bar")}},
+									},
+									WhereClause: &booleanExpression{
+										Left: &term{
+											Left: &booleanValue{
+												ConstExpr: booleanp(false),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					ElseIf: []*conditionalBranch{
+						{
+							Condition: &booleanExpression{
+								Left: &term{
+									Left: &booleanValue{
+										ConstExpr: booleanp(false),
+									},
+								},
+							},
+							Block: &statementBlock{
+								Statements: []*parsedStatement{
+									{
+										Invocation: invocation{
+											Function:  "set",
+											Arguments: []value{{String: ottltest.Strp("baz")}},
+										},
+									},
+								},
+							},
+						},
+					},
+					Else: &statementBlock{
+						Statements: []*parsedStatement{
+							{
+								Invocation: invocation{
+									Function:  "set",
+									Arguments: []value{{String: ottltest.Strp("qux")}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:      "where == clause",
 			statement: `set(foo.attributes["bar"].cat, "dog") where name == "fido"`,
@@ -172,8 +334,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -227,8 +389,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -282,8 +444,8 @@ func Test_parse(t *testing.T) {
 											Name: "foo",
 										},
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bar"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bar")}},
 										},
 										{
 											Name: "cat",
@@ -385,8 +547,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("bytes"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("bytes")}},
 										},
 									},
 								},
@@ -412,8 +574,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -439,8 +601,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -466,8 +628,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -495,8 +657,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -528,8 +690,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -564,8 +726,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -625,8 +787,8 @@ func Test_parse(t *testing.T) {
 											Path: &Path{
 												Fields: []Field{
 													{
-														Name:   "attributes",
-														MapKey: ottltest.Strp("test"),
+														Name: "attributes",
+														Keys: []Key{{String: ottltest.Strp("test")}},
 													},
 												},
 											},
@@ -652,8 +814,8 @@ func Test_parse(t *testing.T) {
 								Path: &Path{
 									Fields: []Field{
 										{
-											Name:   "attributes",
-											MapKey: ottltest.Strp("test"),
+											Name: "attributes",
+											Keys: []Key{{String: ottltest.Strp("test")}},
 										},
 									},
 								},
@@ -1246,6 +1408,18 @@ func Test_parseStatement(t *testing.T) {
 		{`test() where one() == 1`, true},
 		{`test(fail())`, true},
 		{`Test()`, true},
+		{`if name == "fido" { set("foo") }`, false},
+		{`if name == "fido" { set("foo"); set("bar") where animal == "dog" }`, false},
+		{`if name == "fido" { set("foo") } elif name == "rex" { set("bar") } else { set("baz") }`, false},
+		{`if name == "fido" { if animal == "dog" { set("foo") } else { set("bar") } }`, false},
+		{`if true {}`, false},
+		{`if name == "fido" { set("foo") } where animal == "dog"`, true},
+		{`if name == "fido" { Set("foo") }`, true},
+		{`if name == "fido" set("foo")`, true},
+		{`if { set("foo") }`, true},
+		{`if name == "fido" { set("foo")`, true},
+		{`else { set("foo") }`, true},
+		{`if name == "fido" { set("foo") } else`, true},
 	}
 	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
 	for _, tt := range tests {
@@ -1310,3 +1484,70 @@ func Test_Execute(t *testing.T) {
 		})
 	}
 }
+
+func Test_Execute_conditional(t *testing.T) {
+	var executed []string
+	var evaluations int
+	functions := map[string]interface{}{
+		"record": func(s string) (ExprFunc[interface{}], error) {
+			return func(context.Context, interface{}) (interface{}, error) {
+				executed = append(executed, s)
+				return nil, nil
+			}, nil
+		},
+		"Count": func() (ExprFunc[interface{}], error) {
+			return func(context.Context, interface{}) (interface{}, error) {
+				evaluations++
+				return int64(1), nil
+			}, nil
+		},
+	}
+	p := NewParser(
+		functions,
+		testParsePath,
+		testParseEnum,
+		component.TelemetrySettings{},
+	)
+
+	statements, err := p.ParseStatements([]string{
+		`if name == "fido" { record("a"); record("b") where name == "rex" } elif name == "rex" { record("c") record("d") } else { record("e") }`,
+		`if name == "spot" { record("f") }`,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		tCtx       string
+		executed   []string
+		conditions []bool
+	}{
+		{tCtx: "fido", executed: []string{"a"}, conditions: []bool{true, false}},
+		{tCtx: "rex", executed: []string{"c", "d"}, conditions: []bool{true, false}},
+		{tCtx: "spot", executed: []string{"e", "f"}, conditions: []bool{true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tCtx, func(t *testing.T) {
+			executed = nil
+			for i, statement := range statements {
+				result, condition, err := statement.Execute(context.Background(), tt.tCtx)
+				assert.NoError(t, err)
+				assert.Nil(t, result)
+				assert.Equal(t, tt.conditions[i], condition)
+			}
+			assert.Equal(t, tt.executed, executed)
+		})
+	}
+
+	t.Run("condition evaluated once", func(t *testing.T) {
+		executed = nil
+		statements, err := p.ParseStatements([]string{
+			`if Count() == 1 { record("a") record("b") record("c") }`,
+		})
+		require.NoError(t, err)
+
+		_, condition, err := statements[0].Execute(context.Background(), "fido")
+		assert.NoError(t, err)
+		assert.True(t, condition)
+		assert.Equal(t, []string{"a", "b", "c"}, executed)
+		assert.Equal(t, 1, evaluations)
+	})
+}
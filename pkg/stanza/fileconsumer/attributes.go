@@ -25,6 +25,8 @@ type FileAttributes struct {
 	Path         string
 	NameResolved string
 	PathResolved string
+	// HeaderAttributes are parsed from the header of the file
+	HeaderAttributes map[string]interface{}
 }
 
 // resolveFileAttributes resolves file attributes
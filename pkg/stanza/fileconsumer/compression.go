@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	compressionNone = ""
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+	compressionAuto = "auto"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+func validateCompression(compression string) error {
+	switch compression {
+	case compressionNone, compressionGzip, compressionZstd, compressionAuto:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression '%s'", compression)
+	}
+}
+
+// detectCompression determines which compression applies to the file.
+// When auto detection is configured, the compression is inferred from
+// the magic number at the beginning of the file.
+func detectCompression(file *os.File, compression string) (string, error) {
+	if compression != compressionAuto {
+		return compression, nil
+	}
+
+	buf := make([]byte, len(zstdMagic))
+	n, err := file.ReadAt(buf, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading magic number: %w", err)
+	}
+	switch {
+	case bytes.HasPrefix(buf[:n], gzipMagic):
+		return compressionGzip, nil
+	case bytes.HasPrefix(buf[:n], zstdMagic):
+		return compressionZstd, nil
+	default:
+		return compressionNone, nil
+	}
+}
+
+// newDecompressor wraps r with a reader returning the decompressed content
+func newDecompressor(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case compressionGzip:
+		return gzip.NewReader(r)
+	case compressionZstd:
+		dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return io.NopCloser(r), nil
+	}
+}
+
+// decompressedSize returns the size of the decompressed content of the file
+func decompressedSize(file *os.File, compression string) (int64, error) {
+	dec, err := newDecompressor(io.NewSectionReader(file, 0, math.MaxInt64), compression)
+	if err != nil {
+		return 0, err
+	}
+	defer dec.Close()
+	return io.Copy(io.Discard, dec)
+}
+
+// countingReader counts the number of bytes read from the underlying reader
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.n += int64(n)
+	return n, err
+}
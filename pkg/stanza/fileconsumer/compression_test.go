@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func compress(t testing.TB, compression string, content string) []byte {
+	var buf bytes.Buffer
+	switch compression {
+	case compressionGzip:
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	case compressionZstd:
+		w, err := zstd.NewWriter(&buf)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	default:
+		buf.WriteString(content)
+	}
+	return buf.Bytes()
+}
+
+// appendCompressed appends a compressed stream of the content to the file
+func appendCompressed(t testing.TB, path string, compression string, content string) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.Write(compress(t, compression, content))
+	require.NoError(t, err)
+}
+
+func TestDetectCompression(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		configured string
+		content    []byte
+		expected   string
+	}{
+		{"Gzip", compressionAuto, compress(t, compressionGzip, "testlog\n"), compressionGzip},
+		{"Zstd", compressionAuto, compress(t, compressionZstd, "testlog\n"), compressionZstd},
+		{"Uncompressed", compressionAuto, []byte("testlog\n"), compressionNone},
+		{"Empty", compressionAuto, []byte{}, compressionNone},
+		{"Configured", compressionGzip, []byte("testlog\n"), compressionGzip},
+		{"Disabled", compressionNone, compress(t, compressionGzip, "testlog\n"), compressionNone},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			file := openTemp(t, t.TempDir())
+			_, err := file.Write(tc.content)
+			require.NoError(t, err)
+
+			compression, err := detectCompression(file, tc.configured)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, compression)
+		})
+	}
+}
+
+func TestDecompressedFingerprint(t *testing.T) {
+	t.Parallel()
+
+	content := "testlog1\ntestlog2\ntestlog3\n"
+	for _, compression := range []string{compressionGzip, compressionZstd} {
+		compression := compression
+		t.Run(compression, func(t *testing.T) {
+			t.Parallel()
+
+			file := openTemp(t, t.TempDir())
+			_, err := file.Write(compress(t, compression, content))
+			require.NoError(t, err)
+
+			fp, err := newDecompressedFingerprint(file, MinFingerprintSize, compression)
+			require.NoError(t, err)
+			require.Equal(t, []byte(content[:MinFingerprintSize]), fp.FirstBytes)
+
+			fp, err = newDecompressedFingerprint(file, DefaultFingerprintSize, compression)
+			require.NoError(t, err)
+			require.Equal(t, []byte(content), fp.FirstBytes)
+		})
+	}
+}
+
+func TestReadCompressedFiles(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		configured  string
+		compression string
+	}{
+		{"GzipAuto", compressionAuto, compressionGzip},
+		{"ZstdAuto", compressionAuto, compressionZstd},
+		{"Gzip", compressionGzip, compressionGzip},
+		{"Zstd", compressionZstd, compressionZstd},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			cfg := NewConfig().includeDir(tempDir)
+			cfg.StartAt = "beginning"
+			cfg.Compression = tc.configured
+			operator, emitCalls := buildTestManager(t, cfg)
+
+			appendCompressed(t, filepath.Join(tempDir, "test.log.1"), tc.compression, "testlog1\ntestlog2\n")
+
+			require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+			defer func() {
+				require.NoError(t, operator.Stop())
+			}()
+
+			waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+			expectNoTokens(t, emitCalls)
+		})
+	}
+}
+
+func TestCompressedRotatedFileNotReadTwice(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	logPath := filepath.Join(tempDir, "test.log")
+	logFile := openFile(t, logPath)
+	writeString(t, logFile, "testlog1\ntestlog2\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForTokens(t, emitCalls, [][]byte{[]byte("testlog1"), []byte("testlog2")})
+
+	// Compress the file, including a line which was never read, then remove it
+	appendCompressed(t, filepath.Join(tempDir, "test.log.1.gz"), compressionGzip, "testlog1\ntestlog2\ntestlog3\n")
+	require.NoError(t, logFile.Close())
+	require.NoError(t, os.Remove(logPath))
+
+	waitForToken(t, emitCalls, []byte("testlog3"))
+	expectNoTokens(t, emitCalls)
+}
+
+func TestCompressedRestartOffsets(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Compression = compressionAuto
+	persister := testutil.NewMockPersister("test")
+	path := filepath.Join(tempDir, "test.log.gz")
+
+	appendCompressed(t, path, compressionGzip, "testlog1\n")
+
+	operatorOne, emitCallsOne := buildTestManager(t, cfg)
+	require.NoError(t, operatorOne.Start(persister))
+	waitForToken(t, emitCallsOne, []byte("testlog1"))
+	require.NoError(t, operatorOne.Stop())
+
+	// Gzip supports concatenated streams, which are decompressed as a whole
+	appendCompressed(t, path, compressionGzip, "testlog2\n")
+
+	operatorTwo, emitCallsTwo := buildTestManager(t, cfg)
+	require.NoError(t, operatorTwo.Start(persister))
+	defer func() {
+		require.NoError(t, operatorTwo.Stop())
+	}()
+	waitForToken(t, emitCallsTwo, []byte("testlog2"))
+	expectNoTokens(t, emitCallsTwo)
+}
@@ -58,6 +58,9 @@ type Config struct {
 	FingerprintSize         helper.ByteSize       `mapstructure:"fingerprint_size,omitempty"`
 	MaxLogSize              helper.ByteSize       `mapstructure:"max_log_size,omitempty"`
 	MaxConcurrentFiles      int                   `mapstructure:"max_concurrent_files,omitempty"`
+	Compression             string                `mapstructure:"compression,omitempty"`
+	DeleteAfterRead         bool                  `mapstructure:"delete_after_read,omitempty"`
+	Header                  *HeaderConfig         `mapstructure:"header,omitempty"`
 	Splitter                helper.SplitterConfig `mapstructure:",squash,omitempty"`
 }
 
@@ -109,6 +112,15 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit EmitFunc, factory s
 	default:
 		return nil, fmt.Errorf("invalid start_at location '%s'", c.StartAt)
 	}
+
+	var header *headerPipeline
+	if c.Header != nil {
+		var err error
+		if header, err = c.Header.build(logger); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Manager{
 		SugaredLogger: logger.With("component", "fileconsumer"),
 		cancel:        func() {},
@@ -118,17 +130,21 @@ func (c Config) buildManager(logger *zap.SugaredLogger, emit EmitFunc, factory s
 				fingerprintSize: int(c.FingerprintSize),
 				maxLogSize:      int(c.MaxLogSize),
 				emit:            emit,
+				header:          header,
+				flushAtEOF:      c.DeleteAfterRead,
 			},
 			fromBeginning:   startAtBeginning,
 			splitterFactory: factory,
 			encodingConfig:  c.Splitter.EncodingConfig,
+			compression:     c.Compression,
 		},
-		finder:        c.Finder,
-		roller:        newRoller(),
-		pollInterval:  c.PollInterval,
-		maxBatchFiles: c.MaxConcurrentFiles / 2,
-		knownFiles:    make([]*Reader, 0, 10),
-		seenPaths:     make(map[string]struct{}, 100),
+		finder:          c.Finder,
+		roller:          newRoller(),
+		pollInterval:    c.PollInterval,
+		maxBatchFiles:   c.MaxConcurrentFiles / 2,
+		deleteAfterRead: c.DeleteAfterRead,
+		knownFiles:      make([]*Reader, 0, 10),
+		seenPaths:       make(map[string]struct{}, 100),
 	}, nil
 }
 
@@ -153,6 +169,18 @@ func (c Config) validate() error {
 		}
 	}
 
+	if err := c.OrderingCriteria.validate(); err != nil {
+		return err
+	}
+
+	if c.DeleteAfterRead && c.StartAt == "end" {
+		return fmt.Errorf("`delete_after_read` cannot be used with `start_at: end`")
+	}
+
+	if c.Header != nil && c.StartAt == "end" {
+		return fmt.Errorf("`header` cannot be used with `start_at: end`")
+	}
+
 	if c.MaxLogSize <= 0 {
 		return fmt.Errorf("`max_log_size` must be positive")
 	}
@@ -165,6 +193,10 @@ func (c Config) validate() error {
 		return fmt.Errorf("`fingerprint_size` must be at least %d bytes", MinFingerprintSize)
 	}
 
+	if err := validateCompression(c.Compression); err != nil {
+		return err
+	}
+
 	_, err := c.Splitter.EncodingConfig.Build()
 	if err != nil {
 		return err
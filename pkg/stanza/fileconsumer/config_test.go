@@ -22,8 +22,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -48,6 +50,20 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "header",
+				Expect: func() *mockOperatorConfig {
+					regexCfg := regex.NewConfig()
+					regexCfg.Regex = "^#(?P<header_key>[A-Za-z]+): (?P<header_value>.*)$"
+					cfg := NewConfig()
+					cfg.StartAt = "beginning"
+					cfg.Header = &HeaderConfig{
+						Pattern:           "^#",
+						MetadataOperators: []operator.Config{{Builder: regexCfg}},
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "include_glob",
 				Expect: func() *mockOperatorConfig {
@@ -168,6 +184,29 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "ordering_criteria",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.OrderingCriteria = OrderingCriteria{
+						Regex: `^err\.(?P<value>\d{10})\.log$`,
+						TopN:  2,
+						SortBy: []SortRule{
+							{
+								RegexKey: "value",
+								SortType: "timestamp",
+								Layout:   "%Y%m%d%H",
+								Location: "UTC",
+							},
+							{
+								SortType:  "mtime",
+								Ascending: true,
+							},
+						},
+					}
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "poll_interval_no_units",
 				Expect: func() *mockOperatorConfig {
@@ -336,6 +375,23 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "compression_auto",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Compression = "auto"
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
+			{
+				Name: "delete_after_read",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.StartAt = "beginning"
+					cfg.DeleteAfterRead = true
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "encoding_lower",
 				Expect: func() *mockOperatorConfig {
@@ -440,6 +496,44 @@ func TestBuild(t *testing.T) {
 			require.Error,
 			nil,
 		},
+		{
+			"DeleteAfterReadStartAtEnd",
+			func(f *Config) {
+				f.StartAt = "end"
+				f.DeleteAfterRead = true
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"DeleteAfterReadStartAtBeginning",
+			func(f *Config) {
+				f.StartAt = "beginning"
+				f.DeleteAfterRead = true
+			},
+			require.NoError,
+			func(t *testing.T, f *Manager) {
+				require.True(t, f.deleteAfterRead)
+			},
+		},
+		{
+			"InvalidOrderingCriteria",
+			func(f *Config) {
+				f.OrderingCriteria = OrderingCriteria{
+					SortBy: []SortRule{{SortType: "numeric", RegexKey: "value"}},
+				}
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"InvalidCompression",
+			func(f *Config) {
+				f.Compression = "lz4"
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"LineStartAndEnd",
 			func(f *Config) {
@@ -40,8 +40,9 @@ type Manager struct {
 	roller        roller
 	persister     operator.Persister
 
-	pollInterval  time.Duration
-	maxBatchFiles int
+	pollInterval    time.Duration
+	maxBatchFiles   int
+	deleteAfterRead bool
 
 	knownFiles []*Reader
 	seenPaths  map[string]struct{}
@@ -63,6 +64,12 @@ func (m *Manager) Start(persister operator.Persister) error {
 			"exclude", m.finder.Exclude)
 	}
 
+	if header := m.readerFactory.readerConfig.header; header != nil {
+		if err := header.pipeline.Start(operator.NewScopedPersister("header", persister)); err != nil {
+			return fmt.Errorf("start header pipeline: %w", err)
+		}
+	}
+
 	// Start polling goroutine
 	m.startPoller(ctx)
 
@@ -79,6 +86,9 @@ func (m *Manager) Stop() error {
 	}
 	m.knownFiles = nil
 	m.cancel = nil
+	if header := m.readerFactory.readerConfig.header; header != nil {
+		return header.pipeline.Stop()
+	}
 	return nil
 }
 
@@ -135,10 +145,20 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 		go func(r *Reader) {
 			defer wg.Done()
 			r.ReadToEnd(ctx)
+			// Delete the file if it was consumed entirely
+			if m.deleteAfterRead && r.eof {
+				r.Delete()
+			}
 		}(reader)
 	}
 	wg.Wait()
 
+	if m.deleteAfterRead {
+		// Deleted files are no longer tracked, so that a new file
+		// with the same fingerprint is not mistaken for them
+		readers = m.dropDeleted(readers)
+	}
+
 	// Any new files that appear should be consumed entirely
 	m.readerFactory.fromBeginning = true
 
@@ -223,6 +243,17 @@ OUTER:
 	return readers
 }
 
+// dropDeleted removes the readers whose file was deleted
+func (m *Manager) dropDeleted(readers []*Reader) []*Reader {
+	remaining := make([]*Reader, 0, len(readers))
+	for _, reader := range readers {
+		if reader.file != nil {
+			remaining = append(remaining, reader)
+		}
+	}
+	return remaining
+}
+
 // saveCurrent adds the readers from this polling interval to this list of
 // known files, then increments the generation of all tracked old readers
 // before clearing out readers that have existed for 3 generations.
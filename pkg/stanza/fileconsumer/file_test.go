@@ -1184,3 +1184,70 @@ func TestEncodings(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteAfterRead(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.DeleteAfterRead = true
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	files := make([]*os.File, 0, 3)
+	expected := make([][]byte, 0, 6)
+	for i := 0; i < 3; i++ {
+		file := openTemp(t, tempDir)
+		writeString(t, file, fmt.Sprintf("file%d_line1\nfile%d_line2\n", i, i))
+		expected = append(expected, []byte(fmt.Sprintf("file%d_line1", i)), []byte(fmt.Sprintf("file%d_line2", i)))
+		require.NoError(t, file.Close())
+		files = append(files, file)
+	}
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForTokens(t, emitCalls, expected)
+
+	for _, file := range files {
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(file.Name())
+			return os.IsNotExist(err)
+		}, time.Second, 10*time.Millisecond, "file %s was not deleted", file.Name())
+	}
+
+	// A new file with the same content as a deleted one must be read
+	file := openTemp(t, tempDir)
+	writeString(t, file, "file0_line1\nfile0_line2\n")
+	require.NoError(t, file.Close())
+	waitForTokens(t, emitCalls, expected[:2])
+}
+
+func TestDeleteAfterReadUnterminatedLine(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.DeleteAfterRead = true
+	// The last line must be emitted before the file is deleted, not by a force flush
+	cfg.Splitter.Flusher.Period = time.Hour
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	file := openTemp(t, tempDir)
+	writeString(t, file, "line1\nline2")
+	require.NoError(t, file.Close())
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	waitForTokens(t, emitCalls, [][]byte{[]byte("line1"), []byte("line2")})
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(file.Name())
+		return os.IsNotExist(err)
+	}, time.Second, 10*time.Millisecond, "file %s was not deleted", file.Name())
+	expectNoTokens(t, emitCalls)
+}
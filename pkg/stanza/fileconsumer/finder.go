@@ -15,12 +15,49 @@
 package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
 	"github.com/bmatcuk/doublestar/v4"
+	strptime "github.com/observiq/ctimefmt"
+)
+
+const (
+	sortTypeNumeric      = "numeric"
+	sortTypeAlphabetical = "alphabetical"
+	sortTypeTimestamp    = "timestamp"
+	sortTypeMtime        = "mtime"
+
+	defaultOrderingCriteriaTopN = 1
 )
 
 type Finder struct {
-	Include []string `mapstructure:"include,omitempty"`
-	Exclude []string `mapstructure:"exclude,omitempty"`
+	Include          []string         `mapstructure:"include,omitempty"`
+	Exclude          []string         `mapstructure:"exclude,omitempty"`
+	OrderingCriteria OrderingCriteria `mapstructure:"ordering_criteria,omitempty"`
+}
+
+// OrderingCriteria selects the first files matched by the include
+// patterns after sorting them with the configured rules
+type OrderingCriteria struct {
+	Regex  string     `mapstructure:"regex,omitempty"`
+	TopN   int        `mapstructure:"top_n,omitempty"`
+	SortBy []SortRule `mapstructure:"sort_by,omitempty"`
+}
+
+// SortRule sorts files by the value of a capture group of the ordering
+// regex extracted from their name, or by their modification time
+type SortRule struct {
+	SortType  string `mapstructure:"sort_type,omitempty"`
+	RegexKey  string `mapstructure:"regex_key,omitempty"`
+	Layout    string `mapstructure:"layout,omitempty"`
+	Location  string `mapstructure:"location,omitempty"`
+	Ascending bool   `mapstructure:"ascending,omitempty"`
 }
 
 // FindFiles gets a list of paths given an array of glob patterns to include and exclude
@@ -46,5 +83,178 @@ func (f Finder) FindFiles() []string {
 		}
 	}
 
-	return all
+	if len(f.OrderingCriteria.SortBy) == 0 {
+		return all
+	}
+	return f.OrderingCriteria.apply(all) // compile errors checked in build
+}
+
+func (c OrderingCriteria) validate() error {
+	if len(c.SortBy) == 0 {
+		return nil
+	}
+
+	if c.TopN < 0 {
+		return fmt.Errorf("`top_n` must not be negative")
+	}
+
+	var groups []string
+	if c.Regex != "" {
+		re, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return fmt.Errorf("compile ordering regex: %w", err)
+		}
+		groups = re.SubexpNames()
+	}
+
+	for _, rule := range c.SortBy {
+		switch rule.SortType {
+		case sortTypeMtime:
+			continue
+		case sortTypeNumeric, sortTypeAlphabetical:
+		case sortTypeTimestamp:
+			if rule.Layout == "" {
+				return fmt.Errorf("`layout` is required for sort type '%s'", rule.SortType)
+			}
+			if _, err := strptime.ToNative(rule.Layout); err != nil {
+				return fmt.Errorf("parse strptime layout: %w", err)
+			}
+			if _, err := time.LoadLocation(rule.Location); err != nil {
+				return fmt.Errorf("failed to load location %s: %w", rule.Location, err)
+			}
+		default:
+			return fmt.Errorf("invalid sort type '%s'", rule.SortType)
+		}
+
+		if c.Regex == "" {
+			return fmt.Errorf("`regex` is required for sort type '%s'", rule.SortType)
+		}
+		if !containsGroup(groups, rule.RegexKey) {
+			return fmt.Errorf("`regex_key` '%s' is not a named capture group of the ordering regex", rule.RegexKey)
+		}
+	}
+	return nil
+}
+
+func containsGroup(groups []string, key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, group := range groups {
+		if group == key {
+			return true
+		}
+	}
+	return false
+}
+
+// sortKey is the value by which a file is ordered for a single rule
+type sortKey struct {
+	num int64
+	str string
+}
+
+func (k sortKey) less(other sortKey) bool {
+	if k.num != other.num {
+		return k.num < other.num
+	}
+	return k.str < other.str
+}
+
+type sortedFile struct {
+	path string
+	keys []sortKey
+}
+
+// apply sorts the paths according to the sort rules, in order of precedence,
+// and returns the top N of them. Paths for which a sort key can not be
+// determined are not selected.
+func (c OrderingCriteria) apply(paths []string) []string {
+	var re *regexp.Regexp
+	if c.Regex != "" {
+		re = regexp.MustCompile(c.Regex)
+	}
+
+	files := make([]sortedFile, 0, len(paths))
+OUTER:
+	for _, path := range paths {
+		file := sortedFile{path: path, keys: make([]sortKey, 0, len(c.SortBy))}
+		for _, rule := range c.SortBy {
+			key, err := rule.sortKey(re, path)
+			if err != nil {
+				continue OUTER
+			}
+			file.keys = append(file.keys, key)
+		}
+		files = append(files, file)
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		for k, rule := range c.SortBy {
+			a, b := files[i].keys[k], files[j].keys[k]
+			if a == b {
+				continue
+			}
+			if rule.Ascending {
+				return a.less(b)
+			}
+			return b.less(a)
+		}
+		return false
+	})
+
+	topN := c.TopN
+	if topN == 0 {
+		topN = defaultOrderingCriteriaTopN
+	}
+	if len(files) > topN {
+		files = files[:topN]
+	}
+
+	selected := make([]string, 0, len(files))
+	for _, file := range files {
+		selected = append(selected, file.path)
+	}
+	return selected
+}
+
+func (r SortRule) sortKey(re *regexp.Regexp, path string) (sortKey, error) {
+	if r.SortType == sortTypeMtime {
+		info, err := os.Stat(path)
+		if err != nil {
+			return sortKey{}, err
+		}
+		return sortKey{num: info.ModTime().UnixNano()}, nil
+	}
+
+	matches := re.FindStringSubmatch(filepath.Base(path))
+	if matches == nil {
+		return sortKey{}, fmt.Errorf("file name does not match the ordering regex")
+	}
+	value := matches[re.SubexpIndex(r.RegexKey)]
+
+	switch r.SortType {
+	case sortTypeNumeric:
+		num, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return sortKey{}, err
+		}
+		return sortKey{num: num}, nil
+	case sortTypeTimestamp:
+		layout, err := strptime.ToNative(r.Layout)
+		if err != nil {
+			return sortKey{}, err
+		}
+		location, err := time.LoadLocation(r.Location)
+		if err != nil {
+			return sortKey{}, err
+		}
+		ts, err := time.ParseInLocation(layout, value, location)
+		if err != nil {
+			return sortKey{}, err
+		}
+		return sortKey{num: ts.UnixNano()}, nil
+	default:
+		return sortKey{str: value}, nil
+	}
 }
@@ -18,6 +18,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -149,12 +150,202 @@ func TestFinder(t *testing.T) {
 				require.NoError(t, os.WriteFile(f, []byte(filepath.Base(f)), 0000))
 			}
 
-			finder := Finder{include, exclude}
+			finder := Finder{Include: include, Exclude: exclude}
 			require.ElementsMatch(t, finder.FindFiles(), expected)
 		})
 	}
 }
 
+func TestFinderOrderingCriteria(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		files    []string
+		criteria OrderingCriteria
+		expected []string
+	}{
+		{
+			name:  "TimestampNewest",
+			files: []string{"err.2023020611.log", "err.2023020612.log", "err.2023020610.log"},
+			criteria: OrderingCriteria{
+				Regex: `err\.(?P<value>\d{10})\.log`,
+				SortBy: []SortRule{
+					{SortType: sortTypeTimestamp, RegexKey: "value", Layout: "%Y%m%d%H"},
+				},
+			},
+			expected: []string{"err.2023020612.log"},
+		},
+		{
+			name:  "TimestampNewestTopN",
+			files: []string{"err.2023020611.log", "err.2023020612.log", "err.2023020610.log"},
+			criteria: OrderingCriteria{
+				Regex: `err\.(?P<value>\d{10})\.log`,
+				TopN:  2,
+				SortBy: []SortRule{
+					{SortType: sortTypeTimestamp, RegexKey: "value", Layout: "%Y%m%d%H", Location: "America/New_York"},
+				},
+			},
+			expected: []string{"err.2023020612.log", "err.2023020611.log"},
+		},
+		{
+			name:  "NumericAscending",
+			files: []string{"err.2.log", "err.10.log", "err.1.log"},
+			criteria: OrderingCriteria{
+				Regex: `err\.(?P<value>\d+)\.log`,
+				TopN:  2,
+				SortBy: []SortRule{
+					{SortType: sortTypeNumeric, RegexKey: "value", Ascending: true},
+				},
+			},
+			expected: []string{"err.1.log", "err.2.log"},
+		},
+		{
+			name:  "Alphabetical",
+			files: []string{"err.b.log", "err.c.log", "err.a.log"},
+			criteria: OrderingCriteria{
+				Regex: `err\.(?P<value>[a-z]+)\.log`,
+				SortBy: []SortRule{
+					{SortType: sortTypeAlphabetical, RegexKey: "value"},
+				},
+			},
+			expected: []string{"err.c.log"},
+		},
+		{
+			name:  "NotMatchingRegex",
+			files: []string{"err.1.log", "err.2.log", "err.latest.log"},
+			criteria: OrderingCriteria{
+				Regex: `err\.(?P<value>\d+)\.log`,
+				TopN:  3,
+				SortBy: []SortRule{
+					{SortType: sortTypeNumeric, RegexKey: "value"},
+				},
+			},
+			expected: []string{"err.2.log", "err.1.log"},
+		},
+		{
+			name:  "MultipleRules",
+			files: []string{"a.2.log", "b.1.log", "b.2.log", "a.1.log"},
+			criteria: OrderingCriteria{
+				Regex: `(?P<name>[a-z])\.(?P<num>\d)\.log`,
+				TopN:  3,
+				SortBy: []SortRule{
+					{SortType: sortTypeAlphabetical, RegexKey: "name", Ascending: true},
+					{SortType: sortTypeNumeric, RegexKey: "num"},
+				},
+			},
+			expected: []string{"a.2.log", "a.1.log", "b.2.log"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			for _, f := range absPath(tempDir, tc.files) {
+				require.NoError(t, os.WriteFile(f, []byte(filepath.Base(f)), 0600))
+			}
+
+			require.NoError(t, tc.criteria.validate())
+			finder := Finder{
+				Include:          []string{filepath.Join(tempDir, "*")},
+				OrderingCriteria: tc.criteria,
+			}
+			require.Equal(t, absPath(tempDir, tc.expected), finder.FindFiles())
+		})
+	}
+}
+
+func TestFinderOrderingCriteriaMtime(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	files := absPath(tempDir, []string{"a.log", "b.log", "c.log"})
+	now := time.Now()
+	for i, f := range files {
+		require.NoError(t, os.WriteFile(f, []byte(filepath.Base(f)), 0600))
+		mtime := now.Add(time.Duration(i-len(files)) * time.Minute)
+		require.NoError(t, os.Chtimes(f, mtime, mtime))
+	}
+	// Make the first file the most recently modified one
+	require.NoError(t, os.Chtimes(files[0], now, now))
+
+	finder := Finder{
+		Include: []string{filepath.Join(tempDir, "*")},
+		OrderingCriteria: OrderingCriteria{
+			TopN:   2,
+			SortBy: []SortRule{{SortType: sortTypeMtime}},
+		},
+	}
+	require.Equal(t, []string{files[0], files[2]}, finder.FindFiles())
+}
+
+func TestOrderingCriteriaValidate(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		criteria OrderingCriteria
+		err      string
+	}{
+		{
+			name: "NegativeTopN",
+			criteria: OrderingCriteria{
+				TopN:   -1,
+				SortBy: []SortRule{{SortType: sortTypeMtime}},
+			},
+			err: "`top_n` must not be negative",
+		},
+		{
+			name: "InvalidSortType",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<value>\d+)`,
+				SortBy: []SortRule{{SortType: "size", RegexKey: "value"}},
+			},
+			err: "invalid sort type 'size'",
+		},
+		{
+			name: "MissingRegex",
+			criteria: OrderingCriteria{
+				SortBy: []SortRule{{SortType: sortTypeNumeric, RegexKey: "value"}},
+			},
+			err: "`regex` is required for sort type 'numeric'",
+		},
+		{
+			name: "UnknownRegexKey",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<value>\d+)`,
+				SortBy: []SortRule{{SortType: sortTypeNumeric, RegexKey: "other"}},
+			},
+			err: "`regex_key` 'other' is not a named capture group of the ordering regex",
+		},
+		{
+			name: "MissingLayout",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<value>\d+)`,
+				SortBy: []SortRule{{SortType: sortTypeTimestamp, RegexKey: "value"}},
+			},
+			err: "`layout` is required for sort type 'timestamp'",
+		},
+		{
+			name: "InvalidLocation",
+			criteria: OrderingCriteria{
+				Regex:  `(?P<value>\d+)`,
+				SortBy: []SortRule{{SortType: sortTypeTimestamp, RegexKey: "value", Layout: "%Y", Location: "Nowhere/Somewhere"}},
+			},
+			err: "failed to load location Nowhere/Somewhere",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.ErrorContains(t, tc.criteria.validate(), tc.err)
+		})
+	}
+}
+
 func absPath(tempDir string, files []string) []string {
 	absFiles := make([]string, 0, len(files))
 	for _, f := range files {
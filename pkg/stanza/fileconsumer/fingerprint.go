@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 )
 
@@ -47,6 +48,31 @@ func NewFingerprint(file *os.File, size int) (*Fingerprint, error) {
 	return fp, nil
 }
 
+// newDecompressedFingerprint creates a new fingerprint from the
+// decompressed content of an open file
+func newDecompressedFingerprint(file *os.File, size int, compression string) (*Fingerprint, error) {
+	dec, err := newDecompressor(io.NewSectionReader(file, 0, math.MaxInt64), compression)
+	if errors.Is(err, io.EOF) {
+		// The compressed header has not been written yet
+		return &Fingerprint{FirstBytes: []byte{}}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("decompressing fingerprint bytes: %w", err)
+	}
+	defer dec.Close()
+
+	buf := make([]byte, size)
+	n, err := io.ReadFull(dec, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("reading fingerprint bytes: %w", err)
+	}
+
+	fp := &Fingerprint{
+		FirstBytes: buf[:n],
+	}
+
+	return fp, nil
+}
+
 // Copy creates a new copy of the fingerprint
 func (f Fingerprint) Copy() *Fingerprint {
 	buf := make([]byte, len(f.FirstBytes), cap(f.FirstBytes))
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/pipeline"
+)
+
+const headerCollectorType = "header_metadata_collector"
+
+// HeaderConfig is the configuration of the header of the files.
+// The lines at the beginning of a file which match the pattern are
+// parsed by the metadata operators, and the resulting attributes are
+// attached to every subsequent entry read from the file.
+type HeaderConfig struct {
+	Pattern           string            `mapstructure:"pattern"`
+	MetadataOperators []operator.Config `mapstructure:"metadata_operators"`
+}
+
+func (c HeaderConfig) build(logger *zap.SugaredLogger) (*headerPipeline, error) {
+	if c.Pattern == "" {
+		return nil, fmt.Errorf("`header.pattern` is required")
+	}
+	if len(c.MetadataOperators) == 0 {
+		return nil, fmt.Errorf("`header.metadata_operators` must not be empty")
+	}
+
+	regex, err := regexp.Compile(c.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("compile header pattern: %w", err)
+	}
+
+	outputOperator, err := helper.NewOutputConfig(headerCollectorType, headerCollectorType).Build(logger)
+	if err != nil {
+		return nil, err
+	}
+	collector := &headerCollector{OutputOperator: outputOperator}
+
+	p, err := pipeline.Config{
+		Operators:     c.MetadataOperators,
+		DefaultOutput: collector,
+	}.Build(logger)
+	if err != nil {
+		return nil, fmt.Errorf("build header pipeline: %w", err)
+	}
+
+	var first operator.Operator
+	for _, op := range p.Operators() {
+		if op.ID() == c.MetadataOperators[0].ID() {
+			first = op
+			break
+		}
+	}
+	if first == nil {
+		return nil, fmt.Errorf("first header operator '%s' not found", c.MetadataOperators[0].ID())
+	}
+
+	return &headerPipeline{
+		regex:     regex,
+		pipeline:  p,
+		first:     first,
+		collector: collector,
+	}, nil
+}
+
+// headerPipeline parses header lines into attributes.
+// It is shared by all readers, which process header lines one at a time.
+type headerPipeline struct {
+	mu        sync.Mutex
+	regex     *regexp.Regexp
+	pipeline  *pipeline.DirectedPipeline
+	first     operator.Operator
+	collector *headerCollector
+}
+
+// matches returns true if the token is a header line
+func (h *headerPipeline) matches(token []byte) bool {
+	return h.regex.Match(token)
+}
+
+// process parses a header line and merges the resulting attributes into attrs
+func (h *headerPipeline) process(ctx context.Context, token []byte, attrs map[string]interface{}) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ent := entry.New()
+	ent.Body = string(token)
+
+	h.collector.last = nil
+	if err := h.first.Process(ctx, ent); err != nil {
+		return err
+	}
+	if h.collector.last == nil {
+		return nil
+	}
+	for k, v := range h.collector.last.Attributes {
+		attrs[k] = v
+	}
+	return nil
+}
+
+// headerCollector is the output of the header pipeline,
+// which retains the last entry it processed
+type headerCollector struct {
+	helper.OutputOperator
+	last *entry.Entry
+}
+
+// Process will retain the incoming entry.
+func (c *headerCollector) Process(_ context.Context, ent *entry.Entry) error {
+	c.last = ent
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconsumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/regex"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func w3cHeaderConfig() *HeaderConfig {
+	regexCfg := regex.NewConfig()
+	regexCfg.Regex = `^#(?P<header_key>[A-Za-z]+): (?P<header_value>.*)$`
+
+	return &HeaderConfig{
+		Pattern:           "^#",
+		MetadataOperators: []operator.Config{{Builder: regexCfg}},
+	}
+}
+
+func TestHeaderBuild(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		header *HeaderConfig
+		err    string
+	}{
+		{
+			name:   "MissingPattern",
+			header: &HeaderConfig{MetadataOperators: w3cHeaderConfig().MetadataOperators},
+			err:    "`header.pattern` is required",
+		},
+		{
+			name:   "InvalidPattern",
+			header: &HeaderConfig{Pattern: "^#(", MetadataOperators: w3cHeaderConfig().MetadataOperators},
+			err:    "compile header pattern",
+		},
+		{
+			name:   "MissingOperators",
+			header: &HeaderConfig{Pattern: "^#"},
+			err:    "`header.metadata_operators` must not be empty",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := tc.header.build(testutil.Logger(t))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestHeaderRequiresStartAtBeginning(t *testing.T) {
+	t.Parallel()
+
+	cfg := NewConfig().includeDir(t.TempDir())
+	cfg.Header = w3cHeaderConfig()
+	_, err := cfg.Build(testutil.Logger(t), testEmitFunc(make(chan *emitParams)))
+	require.ErrorContains(t, err, "`header` cannot be used with `start_at: end`")
+}
+
+func TestHeaderAttributes(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = w3cHeaderConfig()
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Version: 1.0\n#Fields: date time\n2023-02-06 10:00:00\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	emitted := waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("2023-02-06 10:00:00"), emitted.token)
+	require.Equal(t, map[string]interface{}{
+		"header_key":   "Fields",
+		"header_value": "date time",
+	}, emitted.attrs.HeaderAttributes, "Must merge the attributes of all header lines")
+
+	// Lines matching the pattern after the header are regular entries
+	writeString(t, temp, "#Comment: not a header\n")
+	emitted = waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("#Comment: not a header"), emitted.token)
+	require.Equal(t, "date time", emitted.attrs.HeaderAttributes["header_value"])
+	expectNoTokens(t, emitCalls)
+}
+
+func TestHeaderSplitAcrossPolls(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = w3cHeaderConfig()
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Fields: date time\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+	expectNoTokens(t, emitCalls)
+
+	writeString(t, temp, "2023-02-06 10:00:00\n")
+	emitted := waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("2023-02-06 10:00:00"), emitted.token)
+	require.Equal(t, "date time", emitted.attrs.HeaderAttributes["header_value"])
+}
+
+func TestHeaderRestart(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = w3cHeaderConfig()
+	persister := testutil.NewMockPersister("test")
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "#Fields: date time\n2023-02-06 10:00:00\n")
+
+	operatorOne, emitCallsOne := buildTestManager(t, cfg)
+	require.NoError(t, operatorOne.Start(persister))
+	waitForToken(t, emitCallsOne, []byte("2023-02-06 10:00:00"))
+	require.NoError(t, operatorOne.Stop())
+
+	writeString(t, temp, "2023-02-06 11:00:00\n")
+
+	operatorTwo, emitCallsTwo := buildTestManager(t, cfg)
+	require.NoError(t, operatorTwo.Start(persister))
+	defer func() {
+		require.NoError(t, operatorTwo.Stop())
+	}()
+
+	emitted := waitForEmit(t, emitCallsTwo)
+	require.Equal(t, []byte("2023-02-06 11:00:00"), emitted.token)
+	require.Equal(t, "date time", emitted.attrs.HeaderAttributes["header_value"], "Must restore the header attributes")
+}
+
+func TestNoHeader(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.Header = w3cHeaderConfig()
+	operator, emitCalls := buildTestManager(t, cfg)
+
+	temp := openTemp(t, tempDir)
+	writeString(t, temp, "2023-02-06 10:00:00\n#Fields: date time\n")
+
+	require.NoError(t, operator.Start(testutil.NewMockPersister("test")))
+	defer func() {
+		require.NoError(t, operator.Stop())
+	}()
+
+	emitted := waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("2023-02-06 10:00:00"), emitted.token)
+	require.Empty(t, emitted.attrs.HeaderAttributes)
+	emitted = waitForEmit(t, emitCalls)
+	require.Equal(t, []byte("#Fields: date time"), emitted.token)
+}
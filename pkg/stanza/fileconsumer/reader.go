@@ -16,8 +16,11 @@ package fileconsumer // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"go.uber.org/zap"
@@ -29,6 +32,10 @@ type readerConfig struct {
 	fingerprintSize int
 	maxLogSize      int
 	emit            EmitFunc
+	header          *headerPipeline
+	// flushAtEOF is true if the content remaining at the end of the file is emitted
+	// as a final token, for files which are not read again once read entirely
+	flushAtEOF bool
 }
 
 // Reader manages a single file
@@ -38,15 +45,39 @@ type Reader struct {
 	splitFunc bufio.SplitFunc
 	encoding  helper.Encoding
 
-	Fingerprint    *Fingerprint
-	Offset         int64
-	generation     int
-	file           *os.File
-	fileAttributes *FileAttributes
+	Fingerprint *Fingerprint
+	Offset      int64
+	// HeaderFinalized is true once the end of the header of the file was read
+	HeaderFinalized bool
+	// HeaderAttributes are the attributes parsed from the header of the file
+	HeaderAttributes map[string]interface{}
+	generation       int
+	file             *os.File
+	fileAttributes   *FileAttributes
+
+	// compression of the file. For compressed files, the
+	// Offset and Fingerprint refer to the decompressed content
+	compression string
+	// compressedSize is the size of the compressed file
+	// when its content was last read entirely
+	compressedSize int64
+	// src is the reader from which the content is consumed
+	src io.Reader
+	// eof is true if the end of the file was reached during the last read
+	eof bool
 }
 
 // offsetToEnd sets the starting offset
 func (r *Reader) offsetToEnd() error {
+	if r.compression != compressionNone {
+		size, err := decompressedSize(r.file, r.compression)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("decompress: %w", err)
+		}
+		r.Offset = size
+		return nil
+	}
+
 	info, err := r.file.Stat()
 	if err != nil {
 		return fmt.Errorf("stat: %w", err)
@@ -57,33 +88,103 @@ func (r *Reader) offsetToEnd() error {
 
 // ReadToEnd will read until the end of the file
 func (r *Reader) ReadToEnd(ctx context.Context) {
+	r.eof = false
+	if r.compression != compressionNone {
+		r.readCompressedToEnd(ctx)
+		return
+	}
+
 	if _, err := r.file.Seek(r.Offset, 0); err != nil {
 		r.Errorw("Failed to seek", zap.Error(err))
 		return
 	}
+	r.src = r.file
+	r.eof = r.scan(ctx)
+}
+
+// readCompressedToEnd decompresses the file from its beginning,
+// skips the content which was already read and reads until the end
+func (r *Reader) readCompressedToEnd(ctx context.Context) {
+	info, err := r.file.Stat()
+	if err != nil {
+		r.Errorw("Failed to stat", zap.Error(err))
+		return
+	}
+	// Compressed files are typically not written to after rotation,
+	// so avoid decompressing them again when nothing has changed
+	if info.Size() == r.compressedSize {
+		r.eof = true
+		return
+	}
+
+	if _, err = r.file.Seek(0, 0); err != nil {
+		r.Errorw("Failed to seek", zap.Error(err))
+		return
+	}
+	dec, err := newDecompressor(r.file, r.compression)
+	if err != nil {
+		r.Debugw("Failed to decompress", zap.Error(err))
+		return
+	}
+	defer dec.Close()
 
-	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, r.splitFunc)
+	src := &countingReader{Reader: dec}
+	if _, err = io.CopyN(io.Discard, src, r.Offset); err != nil {
+		r.Debugw("Failed to skip to offset", zap.Error(err))
+		return
+	}
+	r.src = src
+
+	r.eof = r.scan(ctx)
+	if r.eof && src.n == r.Offset {
+		r.compressedSize = info.Size()
+	}
+}
+
+// scan emits the tokens read from the source until it is exhausted.
+// It returns true if the end of the source was reached without error.
+func (r *Reader) scan(ctx context.Context) bool {
+	splitFunc := r.splitFunc
+	if r.flushAtEOF {
+		splitFunc = flushAtEOF(splitFunc)
+	}
+	scanner := NewPositionalScanner(r, r.maxLogSize, r.Offset, splitFunc)
 
 	// Iterate over the tokenized file, emitting entries as we go
 	for {
 		select {
 		case <-ctx.Done():
-			return
+			return false
 		default:
 		}
 
 		ok := scanner.Scan()
 		if !ok {
-			if err := scanner.getError(); err != nil {
+			err := scanner.getError()
+			switch {
+			case err == nil:
+				return true
+			case r.compression != compressionNone && errors.Is(scanner.Err(), io.ErrUnexpectedEOF):
+				// The compressed file is still being written
+				r.Debugw("Failed during scan", zap.Error(err))
+			default:
 				r.Errorw("Failed during scan", zap.Error(err))
 			}
-			break
+			return false
 		}
 
 		token, err := r.encoding.Decode(scanner.Bytes())
-		if err != nil {
+		switch {
+		case err != nil:
 			r.Errorw("decode: %w", zap.Error(err))
-		} else {
+		case r.header != nil && !r.HeaderFinalized && r.header.matches(token):
+			if err = r.header.process(ctx, token, r.HeaderAttributes); err != nil {
+				r.Errorw("Failed to process header", zap.Error(err))
+			}
+		default:
+			if r.header != nil && !r.HeaderFinalized {
+				r.finalizeHeader()
+			}
 			r.emit(ctx, r.fileAttributes, token)
 		}
 
@@ -91,6 +192,23 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 	}
 }
 
+// flushAtEOF returns a split function which returns the data remaining at the end
+// of the source as a final token, without its surrounding line breaks and trailing
+// whitespaces, instead of waiting for more data to terminate it
+func flushAtEOF(splitFunc bufio.SplitFunc) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		advance, token, err = splitFunc(data, atEOF)
+		if err != nil || advance > 0 || token != nil || !atEOF || len(data) == 0 {
+			return
+		}
+		token = bytes.TrimLeft(bytes.TrimRight(data, "\r\n\t "), "\r\n")
+		if len(token) == 0 {
+			token = nil
+		}
+		return len(data), token, nil
+	}
+}
+
 // Close will close the file
 func (r *Reader) Close() {
 	if r.file != nil {
@@ -100,14 +218,35 @@ func (r *Reader) Close() {
 	}
 }
 
+// finalizeHeader attaches the attributes parsed from the header
+// to the entries read from the rest of the file
+func (r *Reader) finalizeHeader() {
+	r.HeaderFinalized = true
+	if r.fileAttributes != nil {
+		r.fileAttributes.HeaderAttributes = r.HeaderAttributes
+	}
+}
+
+// Delete will close and delete the file
+func (r *Reader) Delete() {
+	if r.file == nil {
+		return
+	}
+	r.Close()
+	if err := os.Remove(r.file.Name()); err != nil {
+		r.Errorw("Failed to delete file", zap.Error(err))
+	}
+	r.file = nil
+}
+
 // Read from the file and update the fingerprint if necessary
 func (r *Reader) Read(dst []byte) (int, error) {
 	// Skip if fingerprint is already built
 	// or if fingerprint is behind Offset
 	if len(r.Fingerprint.FirstBytes) == r.fingerprintSize || int(r.Offset) > len(r.Fingerprint.FirstBytes) {
-		return r.file.Read(dst)
+		return r.src.Read(dst)
 	}
-	n, err := r.file.Read(dst)
+	n, err := r.src.Read(dst)
 	appendCount := min0(n, r.fingerprintSize-int(r.Offset))
 	// return for n == 0 or r.Offset >= r.fileInput.fingerprintSize
 	if appendCount == 0 {
@@ -29,6 +29,7 @@ type readerFactory struct {
 	fromBeginning   bool
 	splitterFactory splitterFactory
 	encodingConfig  helper.EncodingConfig
+	compression     string
 }
 
 func (f *readerFactory) newReader(file *os.File, fp *Fingerprint) (*Reader, error) {
@@ -44,6 +45,8 @@ func (f *readerFactory) copy(old *Reader, newFile *os.File) (*Reader, error) {
 		withFile(newFile).
 		withFingerprint(old.Fingerprint.Copy()).
 		withOffset(old.Offset).
+		withCompressedSize(old.compressedSize).
+		withHeader(old.HeaderFinalized, old.HeaderAttributes).
 		withSplitterFunc(old.splitFunc).
 		build()
 }
@@ -53,6 +56,13 @@ func (f *readerFactory) unsafeReader() (*Reader, error) {
 }
 
 func (f *readerFactory) newFingerprint(file *os.File) (*Fingerprint, error) {
+	compression, err := detectCompression(file, f.compression)
+	if err != nil {
+		return nil, err
+	}
+	if compression != compressionNone {
+		return newDecompressedFingerprint(file, f.readerConfig.fingerprintSize, compression)
+	}
 	return NewFingerprint(file, f.readerConfig.fingerprintSize)
 }
 
@@ -62,6 +72,11 @@ type readerBuilder struct {
 	fp        *Fingerprint
 	offset    int64
 	splitFunc bufio.SplitFunc
+
+	compressedSize int64
+
+	headerFinalized  bool
+	headerAttributes map[string]interface{}
 }
 
 func (f *readerFactory) newReaderBuilder() *readerBuilder {
@@ -88,10 +103,28 @@ func (b *readerBuilder) withOffset(offset int64) *readerBuilder {
 	return b
 }
 
+func (b *readerBuilder) withCompressedSize(size int64) *readerBuilder {
+	b.compressedSize = size
+	return b
+}
+
+func (b *readerBuilder) withHeader(finalized bool, attrs map[string]interface{}) *readerBuilder {
+	b.headerFinalized = finalized
+	b.headerAttributes = attrs
+	return b
+}
+
 func (b *readerBuilder) build() (r *Reader, err error) {
 	r = &Reader{
-		readerConfig: b.readerConfig,
-		Offset:       b.offset,
+		readerConfig:   b.readerConfig,
+		Offset:         b.offset,
+		compressedSize: b.compressedSize,
+
+		HeaderFinalized:  b.headerFinalized,
+		HeaderAttributes: b.headerAttributes,
+	}
+	if r.HeaderAttributes == nil {
+		r.HeaderAttributes = make(map[string]interface{})
 	}
 
 	if b.splitFunc != nil {
@@ -117,6 +150,15 @@ func (b *readerBuilder) build() (r *Reader, err error) {
 			b.Errorf("resolve attributes: %w", err)
 		}
 
+		if r.HeaderFinalized {
+			r.fileAttributes.HeaderAttributes = r.HeaderAttributes
+		}
+
+		r.compression, err = detectCompression(b.file, b.compression)
+		if err != nil {
+			return nil, err
+		}
+
 		// unsafeReader has the file set to nil, so don't try emending its offset.
 		if !b.fromBeginning {
 			if err := r.offsetToEnd(); err != nil {
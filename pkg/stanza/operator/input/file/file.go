@@ -62,6 +62,12 @@ func (f *Input) emit(ctx context.Context, attrs *fileconsumer.FileAttributes, to
 		}
 	}
 
+	for k, v := range attrs.HeaderAttributes {
+		if err := ent.Set(entry.NewAttributeField(k), v); err != nil {
+			f.Errorf("set header attribute: %w", err)
+		}
+	}
+
 	f.Write(ctx, ent)
 }
 
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// MinNativeHistogramSchema and MaxNativeHistogramSchema are the bounds of the schemas
+	// supported by Prometheus native histograms
+	MinNativeHistogramSchema = -4
+	MaxNativeHistogramSchema = 8
+	// DefaultZeroThreshold is the width of the zero bucket of the native histograms,
+	// as exponential histograms do not record it
+	DefaultZeroThreshold = 1e-128
+	// maxBucketsGap is the maximum number of empty buckets filled in a span,
+	// above which a new span is started
+	maxBucketsGap = 2
+)
+
+// BucketSpan is a span of consecutive native histogram buckets, whose offset is the index
+// of its first bucket, relative to the end of the previous span
+type BucketSpan struct {
+	Offset int32
+	Length uint32
+}
+
+// ConvertBucketsLayout converts the exponential histogram buckets into the spans and the
+// delta encoded counts of native histogram buckets, reducing the scale by scaleDown.
+//
+// The exponential histogram bucket at index i holds the values in (base^i, base^(i+1)], while
+// the native histogram bucket at index i holds the values in (base^(i-1), base^i]. Empty buckets
+// are omitted, unless they are less than maxBucketsGap between two buckets.
+func ConvertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) ([]BucketSpan, []int64) {
+	var (
+		spans     []BucketSpan
+		deltas    []int64
+		prevCount int64
+		// nextIndex is the index following the last bucket added to the spans
+		nextIndex int32
+	)
+
+	appendBucket := func(index int32, count int64) {
+		switch {
+		case len(spans) == 0:
+			spans = append(spans, BucketSpan{Offset: index})
+		case index-nextIndex > maxBucketsGap:
+			spans = append(spans, BucketSpan{Offset: index - nextIndex})
+		default:
+			// Fill the gap with empty buckets
+			for ; nextIndex < index; nextIndex++ {
+				deltas = append(deltas, -prevCount)
+				prevCount = 0
+				spans[len(spans)-1].Length++
+			}
+		}
+		spans[len(spans)-1].Length++
+		deltas = append(deltas, count-prevCount)
+		prevCount = count
+		nextIndex = index + 1
+	}
+
+	ForEachBucket(buckets, scaleDown, func(index int32, count uint64) {
+		appendBucket(index+1, int64(count))
+	})
+	return spans, deltas
+}
+
+// ForEachBucket calls f for each non-empty exponential histogram bucket, with the index of the
+// bucket once the scale is reduced by scaleDown, in increasing order of index
+func ForEachBucket(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32, f func(index int32, count uint64)) {
+	var (
+		index   int32
+		count   uint64
+		started bool
+	)
+	offset := buckets.Offset()
+	bucketCounts := buckets.BucketCounts()
+	for i := 0; i < bucketCounts.Len(); i++ {
+		// The buckets merged when reducing the scale are consecutive
+		bucketIndex := (offset + int32(i)) >> scaleDown
+		if started && bucketIndex != index {
+			if count != 0 {
+				f(index, count)
+			}
+			count = 0
+		}
+		index = bucketIndex
+		count += bucketCounts.At(i)
+		started = true
+	}
+	if count != 0 {
+		f(index, count)
+	}
+}
+
+// LowerBoundary returns the lower boundary of the exponential histogram bucket at the index, base^index
+func LowerBoundary(index, scale int32) float64 {
+	return math.Exp2(math.Ldexp(float64(index), -int(scale)))
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestConvertBucketsLayout(t *testing.T) {
+	tests := []struct {
+		name       string
+		offset     int32
+		counts     []uint64
+		scaleDown  int32
+		wantSpans  []BucketSpan
+		wantDeltas []int64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:       "small gaps are filled",
+			offset:     0,
+			counts:     []uint64{1, 2, 0, 0, 3},
+			wantSpans:  []BucketSpan{{Offset: 1, Length: 5}},
+			wantDeltas: []int64{1, 1, -2, 0, 3},
+		},
+		{
+			name:       "large gaps start a new span",
+			offset:     -1,
+			counts:     []uint64{1, 0, 0, 0, 2},
+			wantSpans:  []BucketSpan{{Offset: 0, Length: 1}, {Offset: 3, Length: 1}},
+			wantDeltas: []int64{1, 1},
+		},
+		{
+			name:       "leading and trailing empty buckets are omitted",
+			offset:     2,
+			counts:     []uint64{0, 4, 4, 0},
+			wantSpans:  []BucketSpan{{Offset: 4, Length: 2}},
+			wantDeltas: []int64{4, 0},
+		},
+		{
+			name:       "scale down",
+			offset:     0,
+			counts:     []uint64{1, 2, 3, 4},
+			scaleDown:  1,
+			wantSpans:  []BucketSpan{{Offset: 1, Length: 2}},
+			wantDeltas: []int64{3, 4},
+		},
+		{
+			name:       "scale down negative offset",
+			offset:     -3,
+			counts:     []uint64{1, 1, 1, 4},
+			scaleDown:  1,
+			wantSpans:  []BucketSpan{{Offset: -1, Length: 3}},
+			wantDeltas: []int64{1, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := pmetric.NewExponentialHistogramDataPointBuckets()
+			buckets.SetOffset(tt.offset)
+			buckets.BucketCounts().FromRaw(tt.counts)
+
+			spans, deltas := ConvertBucketsLayout(buckets, tt.scaleDown)
+			assert.Equal(t, tt.wantSpans, spans)
+			assert.Equal(t, tt.wantDeltas, deltas)
+		})
+	}
+}
+
+func TestForEachBucket(t *testing.T) {
+	buckets := pmetric.NewExponentialHistogramDataPointBuckets()
+	buckets.SetOffset(-3)
+	buckets.BucketCounts().FromRaw([]uint64{1, 0, 2, 0, 0, 3})
+
+	var indexes []int32
+	var counts []uint64
+	ForEachBucket(buckets, 1, func(index int32, count uint64) {
+		indexes = append(indexes, index)
+		counts = append(counts, count)
+	})
+	assert.Equal(t, []int32{-2, -1, 1}, indexes)
+	assert.Equal(t, []uint64{1, 2, 3}, counts)
+}
+
+func TestLowerBoundary(t *testing.T) {
+	assert.Equal(t, 1.0, LowerBoundary(0, 0))
+	assert.Equal(t, 8.0, LowerBoundary(3, 0))
+	assert.Equal(t, 0.25, LowerBoundary(-1, -1))
+	assert.InDelta(t, math.Sqrt2, LowerBoundary(1, 1), 1e-12)
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// addSingleExponentialHistogramDataPoint converts the exponential histogram data point
+// into a native histogram, or into classic buckets if native histograms are disabled
+func addSingleExponentialHistogramDataPoint(pt pmetric.ExponentialHistogramDataPoint, resource pcommon.Resource,
+	metric pmetric.Metric, settings Settings, tsMap map[string]*prompb.TimeSeries) error {
+	if settings.DisableNativeHistograms {
+		addSingleHistogramDataPoint(exponentialToExplicitHistogram(pt), resource, metric, settings, tsMap)
+		return nil
+	}
+
+	histogram, err := exponentialToNativeHistogram(pt)
+	if err != nil {
+		return err
+	}
+
+	baseName := prometheustranslator.BuildPromCompliantName(metric, settings.Namespace)
+	labels := createAttributes(resource, pt.Attributes(), settings.ExternalLabels, nameStr, baseName)
+	sig := addHistogram(tsMap, histogram, labels, metric.Type().String())
+	tsMap[sig].Exemplars = append(tsMap[sig].Exemplars, getPromExemplars(pt)...)
+	return nil
+}
+
+// addHistogram adds the native histogram to the time series of the labels, returning its signature
+func addHistogram(tsMap map[string]*prompb.TimeSeries, histogram prompb.Histogram, labels []prompb.Label, datatype string) string {
+	sig := timeSeriesSignature(datatype, &labels)
+	if ts, ok := tsMap[sig]; ok {
+		ts.Histograms = append(ts.Histograms, histogram)
+	} else {
+		tsMap[sig] = &prompb.TimeSeries{
+			Labels:     labels,
+			Histograms: []prompb.Histogram{histogram},
+		}
+	}
+	return sig
+}
+
+// exponentialToNativeHistogram converts the exponential histogram data point into a native histogram.
+// Scales above the maximum schema of native histograms are reduced by merging buckets.
+func exponentialToNativeHistogram(pt pmetric.ExponentialHistogramDataPoint) (prompb.Histogram, error) {
+	scale := pt.Scale()
+	if scale < prometheustranslator.MinNativeHistogramSchema {
+		return prompb.Histogram{}, fmt.Errorf("cannot convert exponential to native histogram."+
+			" Scale must be >= %d, was %d", prometheustranslator.MinNativeHistogramSchema, scale)
+	}
+
+	var scaleDown int32
+	if scale > prometheustranslator.MaxNativeHistogramSchema {
+		scaleDown = scale - prometheustranslator.MaxNativeHistogramSchema
+		scale = prometheustranslator.MaxNativeHistogramSchema
+	}
+
+	positiveSpans, positiveDeltas := convertBucketsLayout(pt.Positive(), scaleDown)
+	negativeSpans, negativeDeltas := convertBucketsLayout(pt.Negative(), scaleDown)
+
+	histogram := prompb.Histogram{
+		Schema:         scale,
+		ZeroThreshold:  prometheustranslator.DefaultZeroThreshold,
+		ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: pt.ZeroCount()},
+		PositiveSpans:  positiveSpans,
+		PositiveDeltas: positiveDeltas,
+		NegativeSpans:  negativeSpans,
+		NegativeDeltas: negativeDeltas,
+		Timestamp:      convertTimeStamp(pt.Timestamp()),
+	}
+
+	if pt.Flags().NoRecordedValue() {
+		histogram.Sum = math.Float64frombits(value.StaleNaN)
+		histogram.Count = &prompb.Histogram_CountInt{CountInt: value.StaleNaN}
+	} else {
+		if pt.HasSum() {
+			histogram.Sum = pt.Sum()
+		}
+		histogram.Count = &prompb.Histogram_CountInt{CountInt: pt.Count()}
+	}
+
+	return histogram, nil
+}
+
+// convertBucketsLayout converts the exponential histogram buckets into the spans and the
+// delta encoded counts of native histogram buckets, reducing the scale by scaleDown
+func convertBucketsLayout(buckets pmetric.ExponentialHistogramDataPointBuckets, scaleDown int32) ([]*prompb.BucketSpan, []int64) {
+	layout, deltas := prometheustranslator.ConvertBucketsLayout(buckets, scaleDown)
+	if len(layout) == 0 {
+		return nil, deltas
+	}
+	spans := make([]*prompb.BucketSpan, 0, len(layout))
+	for _, span := range layout {
+		spans = append(spans, &prompb.BucketSpan{Offset: span.Offset, Length: span.Length})
+	}
+	return spans, deltas
+}
+
+// exponentialToExplicitHistogram converts the exponential histogram data point into an explicit
+// bounds histogram data point, whose bounds are the upper bounds of the exponential buckets.
+// The zero bucket is converted into a bucket whose upper bound is 0.
+func exponentialToExplicitHistogram(pt pmetric.ExponentialHistogramDataPoint) pmetric.HistogramDataPoint {
+	explicit := pmetric.NewHistogramDataPoint()
+	pt.Attributes().CopyTo(explicit.Attributes())
+	explicit.SetStartTimestamp(pt.StartTimestamp())
+	explicit.SetTimestamp(pt.Timestamp())
+	explicit.SetFlags(pt.Flags())
+	explicit.SetCount(pt.Count())
+	if pt.HasSum() {
+		explicit.SetSum(pt.Sum())
+	}
+	pt.Exemplars().CopyTo(explicit.Exemplars())
+
+	scale := pt.Scale()
+	bounds := explicit.ExplicitBounds()
+	counts := explicit.BucketCounts()
+
+	// The negative buckets come first, from the lowest values to the highest ones
+	negative := pt.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		bounds.Append(-prometheustranslator.LowerBoundary(negative.Offset()+int32(i), scale))
+		counts.Append(negative.BucketCounts().At(i))
+	}
+
+	bounds.Append(0)
+	counts.Append(pt.ZeroCount())
+
+	positive := pt.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		bounds.Append(prometheustranslator.LowerBoundary(positive.Offset()+int32(i)+1, scale))
+		counts.Append(positive.BucketCounts().At(i))
+	}
+
+	// The +Inf bucket
+	counts.Append(0)
+
+	return explicit
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+func Test_convertBucketsLayout(t *testing.T) {
+	tests := []struct {
+		name       string
+		offset     int32
+		counts     []uint64
+		scaleDown  int32
+		wantSpans  []*prompb.BucketSpan
+		wantDeltas []int64
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:       "small gaps are filled",
+			offset:     0,
+			counts:     []uint64{1, 2, 0, 0, 3},
+			wantSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 5}},
+			wantDeltas: []int64{1, 1, -2, 0, 3},
+		},
+		{
+			name:       "large gaps start a new span",
+			offset:     -1,
+			counts:     []uint64{1, 0, 0, 0, 2},
+			wantSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 1}, {Offset: 3, Length: 1}},
+			wantDeltas: []int64{1, 1},
+		},
+		{
+			name:       "leading and trailing empty buckets are omitted",
+			offset:     2,
+			counts:     []uint64{0, 4, 4, 0},
+			wantSpans:  []*prompb.BucketSpan{{Offset: 4, Length: 2}},
+			wantDeltas: []int64{4, 0},
+		},
+		{
+			name:       "scale down",
+			offset:     0,
+			counts:     []uint64{1, 2, 3, 4},
+			scaleDown:  1,
+			wantSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 2}},
+			wantDeltas: []int64{3, 4},
+		},
+		{
+			name:       "scale down negative offset",
+			offset:     -3,
+			counts:     []uint64{1, 1, 1},
+			scaleDown:  1,
+			wantSpans:  []*prompb.BucketSpan{{Offset: -1, Length: 2}},
+			wantDeltas: []int64{1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := pmetric.NewExponentialHistogramDataPointBuckets()
+			buckets.SetOffset(tt.offset)
+			buckets.BucketCounts().FromRaw(tt.counts)
+
+			spans, deltas := convertBucketsLayout(buckets, tt.scaleDown)
+			assert.Equal(t, tt.wantSpans, spans)
+			assert.Equal(t, tt.wantDeltas, deltas)
+		})
+	}
+}
+
+func Test_exponentialToNativeHistogram(t *testing.T) {
+	newPoint := func(scale int32) pmetric.ExponentialHistogramDataPoint {
+		pt := pmetric.NewExponentialHistogramDataPoint()
+		pt.SetTimestamp(pcommon.Timestamp(time1))
+		pt.SetScale(scale)
+		pt.SetCount(7)
+		pt.SetSum(12.5)
+		pt.SetZeroCount(1)
+		pt.Positive().SetOffset(0)
+		pt.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+		pt.Negative().SetOffset(1)
+		pt.Negative().BucketCounts().FromRaw([]uint64{3})
+		return pt
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		histogram, err := exponentialToNativeHistogram(newPoint(1))
+		require.NoError(t, err)
+		assert.Equal(t, prompb.Histogram{
+			Count:          &prompb.Histogram_CountInt{CountInt: 7},
+			Sum:            12.5,
+			Schema:         1,
+			ZeroThreshold:  prometheustranslator.DefaultZeroThreshold,
+			ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+			PositiveSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 2}},
+			PositiveDeltas: []int64{1, 1},
+			NegativeSpans:  []*prompb.BucketSpan{{Offset: 2, Length: 1}},
+			NegativeDeltas: []int64{3},
+			Timestamp:      msTime1,
+		}, histogram)
+	})
+
+	t.Run("scale too low", func(t *testing.T) {
+		_, err := exponentialToNativeHistogram(newPoint(-5))
+		assert.Error(t, err)
+	})
+
+	t.Run("scale too high", func(t *testing.T) {
+		histogram, err := exponentialToNativeHistogram(newPoint(10))
+		require.NoError(t, err)
+		assert.Equal(t, int32(prometheustranslator.MaxNativeHistogramSchema), histogram.Schema)
+		assert.Equal(t, []*prompb.BucketSpan{{Offset: 1, Length: 1}}, histogram.PositiveSpans)
+		assert.Equal(t, []int64{3}, histogram.PositiveDeltas)
+	})
+
+	t.Run("no recorded value", func(t *testing.T) {
+		pt := newPoint(0)
+		pt.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		histogram, err := exponentialToNativeHistogram(pt)
+		require.NoError(t, err)
+		assert.True(t, value.IsStaleNaN(histogram.Sum))
+		assert.Equal(t, &prompb.Histogram_CountInt{CountInt: value.StaleNaN}, histogram.Count)
+	})
+}
+
+func Test_exponentialToExplicitHistogram(t *testing.T) {
+	pt := pmetric.NewExponentialHistogramDataPoint()
+	pt.Attributes().PutStr(label11, value11)
+	pt.SetTimestamp(pcommon.Timestamp(time1))
+	pt.SetScale(0)
+	pt.SetCount(10)
+	pt.SetSum(3)
+	pt.SetZeroCount(4)
+	pt.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+	pt.Negative().BucketCounts().FromRaw([]uint64{3})
+
+	explicit := exponentialToExplicitHistogram(pt)
+	assert.Equal(t, []float64{-1, 0, 2, 4}, explicit.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{3, 4, 1, 2, 0}, explicit.BucketCounts().AsRaw())
+	assert.Equal(t, uint64(10), explicit.Count())
+	assert.Equal(t, 3.0, explicit.Sum())
+	assert.Equal(t, pcommon.Timestamp(time1), explicit.Timestamp())
+	assert.Equal(t, pt.Attributes().AsRaw(), explicit.Attributes().AsRaw())
+}
+
+func TestFromMetricsExponentialHistogram(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	getExpHistogramMetric("exp_hist", lbs1, time1, floatVal1, 3, 0, 0, []uint64{1, 2}).CopyTo(metric)
+
+	t.Run("native histograms", func(t *testing.T) {
+		tsMap, err := FromMetrics(md, Settings{DisableTargetInfo: true})
+		require.NoError(t, err)
+		require.Len(t, tsMap, 1)
+		for _, ts := range tsMap {
+			assert.Empty(t, ts.Samples)
+			require.Len(t, ts.Histograms, 1)
+			assert.Equal(t, []*prompb.BucketSpan{{Offset: 1, Length: 2}}, ts.Histograms[0].PositiveSpans)
+			assert.Equal(t, []int64{1, 1}, ts.Histograms[0].PositiveDeltas)
+			assert.Contains(t, ts.Labels, prompb.Label{Name: nameStr, Value: "exp_hist"})
+		}
+	})
+
+	t.Run("classic histograms", func(t *testing.T) {
+		tsMap, err := FromMetrics(md, Settings{DisableTargetInfo: true, DisableNativeHistograms: true})
+		require.NoError(t, err)
+		// sum, count and one series per bucket: the zero bucket, the two positive ones and +Inf
+		require.Len(t, tsMap, 6)
+		for _, ts := range tsMap {
+			assert.Empty(t, ts.Histograms)
+			assert.Len(t, ts.Samples, 1)
+		}
+	})
+
+	t.Run("invalid scale", func(t *testing.T) {
+		invalid := pmetric.NewMetrics()
+		md.CopyTo(invalid)
+		invalid.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).
+			ExponentialHistogram().DataPoints().At(0).SetScale(-10)
+		_, err := FromMetrics(invalid, Settings{DisableTargetInfo: true})
+		assert.Error(t, err)
+	})
+}
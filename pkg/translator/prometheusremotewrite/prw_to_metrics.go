@@ -0,0 +1,589 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/timestamp"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/multierr"
+)
+
+const (
+	totalStr = "_total"
+
+	// maxNativeBucketsRange is the maximum number of buckets of a native histogram, including
+	// the empty buckets between its spans
+	maxNativeBucketsRange = 1 << 14
+)
+
+var errMissingMetricName = errors.New("time series without metric name")
+
+// ToMetrics converts a prometheus remote write request to pmetric.Metrics.
+// The series are grouped into resources by their job and instance labels. Classic histograms and
+// summaries are reconstructed from their _bucket, _sum and _count series, counters are converted to
+// cumulative monotonic sums and native histograms to exponential histograms. Any other series is
+// converted to a gauge. Series which cannot be converted are reported in the returned error, and
+// the remaining series are still converted.
+func ToMetrics(req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	b := newMetricsBuilder(req)
+	var errs error
+	for i := range req.Timeseries {
+		if err := b.addTimeSeries(&req.Timeseries[i]); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	b.buildCompositePoints()
+	return b.metrics, errs
+}
+
+type resourceKey struct {
+	job      string
+	instance string
+}
+
+type metricKey struct {
+	name       string
+	metricType pmetric.MetricType
+}
+
+// metricsBuilder accumulates the time series of a write request into pmetric.Metrics
+type metricsBuilder struct {
+	metadata map[string]prompb.MetricMetadata
+	// histograms and summaries hold the families having _bucket and quantile series in the request
+	histograms map[string]bool
+	summaries  map[string]bool
+
+	metrics   pmetric.Metrics
+	resources map[resourceKey]*resourceBuilder
+}
+
+// resourceBuilder accumulates the metrics of a single job and instance
+type resourceBuilder struct {
+	resource        pcommon.Resource
+	metricSlice     pmetric.MetricSlice
+	metrics         map[metricKey]pmetric.Metric
+	points          map[string]*compositePoint
+	compositePoints []*compositePoint
+}
+
+// compositePoint gathers the series of a histogram or summary data point
+type compositePoint struct {
+	metric    pmetric.Metric
+	labels    []prompb.Label
+	timestamp int64
+	buckets   map[float64]float64
+	quantiles map[float64]float64
+	sum       float64
+	count     float64
+	hasSum    bool
+	hasCount  bool
+	stale     bool
+}
+
+func newMetricsBuilder(req *prompb.WriteRequest) *metricsBuilder {
+	b := &metricsBuilder{
+		metadata:   make(map[string]prompb.MetricMetadata, len(req.Metadata)),
+		histograms: make(map[string]bool),
+		summaries:  make(map[string]bool),
+		metrics:    pmetric.NewMetrics(),
+		resources:  make(map[resourceKey]*resourceBuilder),
+	}
+	for _, md := range req.Metadata {
+		b.metadata[md.MetricFamilyName] = md
+	}
+	for _, ts := range req.Timeseries {
+		name := labelValue(ts.Labels, nameStr)
+		if hasLabel(ts.Labels, leStr) && strings.HasSuffix(name, bucketStr) {
+			b.histograms[strings.TrimSuffix(name, bucketStr)] = true
+		}
+		if hasLabel(ts.Labels, quantileStr) {
+			b.summaries[name] = true
+		}
+	}
+	return b
+}
+
+func (b *metricsBuilder) addTimeSeries(ts *prompb.TimeSeries) error {
+	name := labelValue(ts.Labels, nameStr)
+	if name == "" {
+		return errMissingMetricName
+	}
+
+	rb := b.resourceBuilder(ts.Labels)
+	if name == targetMetricName {
+		// target_info carries the resource attributes rather than a metric
+		for _, l := range ts.Labels {
+			if !isReservedLabel(l.Name) {
+				rb.resource.Attributes().PutStr(l.Name, l.Value)
+			}
+		}
+		return nil
+	}
+
+	if len(ts.Histograms) > 0 {
+		for _, h := range ts.Histograms {
+			if err := validateNativeHistogram(h); err != nil {
+				return fmt.Errorf("invalid native histogram of %s: %w", name, err)
+			}
+		}
+		metric := rb.metric(name, pmetric.MetricTypeExponentialHistogram, b.metadata[name])
+		metric.ExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dps := metric.ExponentialHistogram().DataPoints()
+		for _, h := range ts.Histograms {
+			addExponentialHistogramDataPoint(dps.AppendEmpty(), h, ts.Labels)
+		}
+	}
+	if len(ts.Samples) == 0 {
+		return nil
+	}
+
+	family, familyType := b.family(name, ts.Labels)
+	switch familyType {
+	case prompb.MetricMetadata_HISTOGRAM, prompb.MetricMetadata_GAUGEHISTOGRAM:
+		metric := rb.metric(family, pmetric.MetricTypeHistogram, b.metadata[family])
+		metric.Histogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		return rb.addCompositeSamples(metric, strings.TrimPrefix(name, family), ts)
+	case prompb.MetricMetadata_SUMMARY:
+		metric := rb.metric(family, pmetric.MetricTypeSummary, b.metadata[family])
+		return rb.addCompositeSamples(metric, strings.TrimPrefix(name, family), ts)
+	case prompb.MetricMetadata_COUNTER:
+		md, ok := b.metadata[family]
+		if !ok {
+			md = b.metadata[strings.TrimSuffix(family, totalStr)]
+		}
+		metric := rb.metric(family, pmetric.MetricTypeSum, md)
+		metric.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		metric.Sum().SetIsMonotonic(true)
+		addNumberDataPoints(metric.Sum().DataPoints(), ts)
+	default:
+		metric := rb.metric(family, pmetric.MetricTypeGauge, b.metadata[family])
+		addNumberDataPoints(metric.Gauge().DataPoints(), ts)
+	}
+	return nil
+}
+
+// family returns the name and the type of the metric family the series belongs to,
+// using the metadata of the request when available and the naming conventions otherwise
+func (b *metricsBuilder) family(name string, labels []prompb.Label) (string, prompb.MetricMetadata_MetricType) {
+	for _, suffix := range []string{bucketStr, sumStr, countStr} {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		base := strings.TrimSuffix(name, suffix)
+		if md, ok := b.metadata[base]; ok && isCompositeType(md.Type) {
+			return base, md.Type
+		}
+		if b.histograms[base] && (suffix != bucketStr || hasLabel(labels, leStr)) {
+			return base, prompb.MetricMetadata_HISTOGRAM
+		}
+		if b.summaries[base] && suffix != bucketStr {
+			return base, prompb.MetricMetadata_SUMMARY
+		}
+	}
+	if b.summaries[name] {
+		return name, prompb.MetricMetadata_SUMMARY
+	}
+
+	if md, ok := b.metadata[name]; ok {
+		return name, md.Type
+	}
+	if strings.HasSuffix(name, totalStr) {
+		// OpenMetrics counter families are named without the _total suffix
+		if md, ok := b.metadata[strings.TrimSuffix(name, totalStr)]; ok && md.Type != prompb.MetricMetadata_COUNTER {
+			return name, md.Type
+		}
+		return name, prompb.MetricMetadata_COUNTER
+	}
+	return name, prompb.MetricMetadata_GAUGE
+}
+
+// resourceBuilder returns the builder of the resource identified by the job and instance labels,
+// creating it if needed
+func (b *metricsBuilder) resourceBuilder(labels []prompb.Label) *resourceBuilder {
+	key := resourceKey{
+		job:      labelValue(labels, model.JobLabel),
+		instance: labelValue(labels, model.InstanceLabel),
+	}
+	if rb, ok := b.resources[key]; ok {
+		return rb
+	}
+
+	rm := b.metrics.ResourceMetrics().AppendEmpty()
+	attrs := rm.Resource().Attributes()
+	if key.job != "" {
+		// job is the concatenation of the service namespace and name
+		if namespace, name, found := strings.Cut(key.job, "/"); found {
+			attrs.PutStr(conventions.AttributeServiceNamespace, namespace)
+			attrs.PutStr(conventions.AttributeServiceName, name)
+		} else {
+			attrs.PutStr(conventions.AttributeServiceName, key.job)
+		}
+	}
+	if key.instance != "" {
+		attrs.PutStr(conventions.AttributeServiceInstanceID, key.instance)
+	}
+
+	rb := &resourceBuilder{
+		resource:    rm.Resource(),
+		metricSlice: rm.ScopeMetrics().AppendEmpty().Metrics(),
+		metrics:     make(map[metricKey]pmetric.Metric),
+		points:      make(map[string]*compositePoint),
+	}
+	b.resources[key] = rb
+	return rb
+}
+
+// buildCompositePoints converts the histogram and summary points gathered from the series into data points
+func (b *metricsBuilder) buildCompositePoints() {
+	for _, rb := range b.resources {
+		for _, p := range rb.compositePoints {
+			switch p.metric.Type() {
+			case pmetric.MetricTypeHistogram:
+				p.toHistogramDataPoint(p.metric.Histogram().DataPoints().AppendEmpty())
+			case pmetric.MetricTypeSummary:
+				p.toSummaryDataPoint(p.metric.Summary().DataPoints().AppendEmpty())
+			}
+		}
+	}
+}
+
+// metric returns the metric of the given name and type, creating it if needed
+func (rb *resourceBuilder) metric(name string, metricType pmetric.MetricType, md prompb.MetricMetadata) pmetric.Metric {
+	key := metricKey{name: name, metricType: metricType}
+	if metric, ok := rb.metrics[key]; ok {
+		return metric
+	}
+
+	metric := rb.metricSlice.AppendEmpty()
+	metric.SetName(name)
+	metric.SetDescription(md.Help)
+	metric.SetUnit(md.Unit)
+	switch metricType {
+	case pmetric.MetricTypeGauge:
+		metric.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		metric.SetEmptySum()
+	case pmetric.MetricTypeHistogram:
+		metric.SetEmptyHistogram()
+	case pmetric.MetricTypeExponentialHistogram:
+		metric.SetEmptyExponentialHistogram()
+	case pmetric.MetricTypeSummary:
+		metric.SetEmptySummary()
+	}
+	rb.metrics[key] = metric
+	return metric
+}
+
+// addCompositeSamples adds the samples of a _bucket, _sum, _count or quantile series
+// to the points of the histogram or summary
+func (rb *resourceBuilder) addCompositeSamples(metric pmetric.Metric, suffix string, ts *prompb.TimeSeries) error {
+	var bound float64
+	switch {
+	case suffix == bucketStr:
+		var err error
+		if bound, err = parseBound(ts.Labels, leStr); err != nil {
+			return fmt.Errorf("invalid bucket of %s: %w", metric.Name(), err)
+		}
+	case suffix == "" && metric.Type() == pmetric.MetricTypeSummary:
+		var err error
+		if bound, err = parseBound(ts.Labels, quantileStr); err != nil {
+			return fmt.Errorf("invalid quantile of %s: %w", metric.Name(), err)
+		}
+	case suffix != sumStr && suffix != countStr:
+		return fmt.Errorf("unexpected series %s%s of %s", metric.Name(), suffix, metric.Type())
+	}
+
+	labels := make([]prompb.Label, 0, len(ts.Labels))
+	for _, l := range ts.Labels {
+		if l.Name != nameStr && l.Name != leStr && l.Name != quantileStr {
+			labels = append(labels, l)
+		}
+	}
+	sig := timeSeriesSignature(metric.Name()+metric.Type().String(), &labels)
+
+	for _, sample := range ts.Samples {
+		p := rb.compositePoint(metric, sig, labels, sample.Timestamp)
+		if value.IsStaleNaN(sample.Value) {
+			p.stale = true
+			continue
+		}
+		switch suffix {
+		case sumStr:
+			p.sum, p.hasSum = sample.Value, true
+		case countStr:
+			p.count, p.hasCount = sample.Value, true
+		case bucketStr:
+			p.buckets[bound] = sample.Value
+		default:
+			p.quantiles[bound] = sample.Value
+		}
+	}
+	return nil
+}
+
+// compositePoint returns the point of the series signature at the timestamp, creating it if needed
+func (rb *resourceBuilder) compositePoint(metric pmetric.Metric, sig string, labels []prompb.Label, ts int64) *compositePoint {
+	key := sig + "-" + strconv.FormatInt(ts, 10)
+	if p, ok := rb.points[key]; ok {
+		return p
+	}
+	p := &compositePoint{
+		metric:    metric,
+		labels:    labels,
+		timestamp: ts,
+		buckets:   make(map[float64]float64),
+		quantiles: make(map[float64]float64),
+	}
+	rb.points[key] = p
+	rb.compositePoints = append(rb.compositePoints, p)
+	return p
+}
+
+// toHistogramDataPoint converts the cumulative buckets of the point into a histogram data point
+func (p *compositePoint) toHistogramDataPoint(dp pmetric.HistogramDataPoint) {
+	setAttributes(dp.Attributes(), p.labels)
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timestamp.Time(p.timestamp)))
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	bounds := make([]float64, 0, len(p.buckets))
+	for bound := range p.buckets {
+		bounds = append(bounds, bound)
+	}
+	sort.Float64s(bounds)
+
+	count := p.count
+	if !p.hasCount && len(bounds) > 0 {
+		count = p.buckets[bounds[len(bounds)-1]]
+	}
+	dp.SetCount(uint64(count))
+	if p.hasSum {
+		dp.SetSum(p.sum)
+	}
+	if len(bounds) == 0 {
+		return
+	}
+
+	var previous float64
+	for _, bound := range bounds {
+		cumulative := p.buckets[bound]
+		dp.BucketCounts().Append(uint64(math.Max(cumulative-previous, 0)))
+		previous = cumulative
+		if !math.IsInf(bound, 1) {
+			dp.ExplicitBounds().Append(bound)
+		}
+	}
+	if !math.IsInf(bounds[len(bounds)-1], 1) {
+		// the +Inf bucket is missing, derive it from the count
+		dp.BucketCounts().Append(uint64(math.Max(count-previous, 0)))
+	}
+}
+
+// toSummaryDataPoint converts the quantiles of the point into a summary data point
+func (p *compositePoint) toSummaryDataPoint(dp pmetric.SummaryDataPoint) {
+	setAttributes(dp.Attributes(), p.labels)
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timestamp.Time(p.timestamp)))
+	if p.stale {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	dp.SetCount(uint64(p.count))
+	dp.SetSum(p.sum)
+	quantiles := make([]float64, 0, len(p.quantiles))
+	for quantile := range p.quantiles {
+		quantiles = append(quantiles, quantile)
+	}
+	sort.Float64s(quantiles)
+	for _, quantile := range quantiles {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(quantile)
+		qv.SetValue(p.quantiles[quantile])
+	}
+}
+
+// addNumberDataPoints adds a data point for each sample of the series
+func addNumberDataPoints(dps pmetric.NumberDataPointSlice, ts *prompb.TimeSeries) {
+	for _, sample := range ts.Samples {
+		dp := dps.AppendEmpty()
+		setAttributes(dp.Attributes(), ts.Labels)
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(timestamp.Time(sample.Timestamp)))
+		if value.IsStaleNaN(sample.Value) {
+			dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			continue
+		}
+		dp.SetDoubleValue(sample.Value)
+	}
+}
+
+// addExponentialHistogramDataPoint converts the native histogram into the exponential histogram data point
+func addExponentialHistogramDataPoint(dp pmetric.ExponentialHistogramDataPoint, h prompb.Histogram, labels []prompb.Label) {
+	setAttributes(dp.Attributes(), labels)
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timestamp.Time(h.Timestamp)))
+	if value.IsStaleNaN(h.Sum) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	dp.SetScale(h.Schema)
+	dp.SetSum(h.Sum)
+	switch count := h.Count.(type) {
+	case *prompb.Histogram_CountInt:
+		dp.SetCount(count.CountInt)
+	case *prompb.Histogram_CountFloat:
+		dp.SetCount(uint64(count.CountFloat))
+	}
+	switch zeroCount := h.ZeroCount.(type) {
+	case *prompb.Histogram_ZeroCountInt:
+		dp.SetZeroCount(zeroCount.ZeroCountInt)
+	case *prompb.Histogram_ZeroCountFloat:
+		dp.SetZeroCount(uint64(zeroCount.ZeroCountFloat))
+	}
+	convertNativeBuckets(h.PositiveSpans, h.PositiveDeltas, h.PositiveCounts, dp.Positive())
+	convertNativeBuckets(h.NegativeSpans, h.NegativeDeltas, h.NegativeCounts, dp.Negative())
+}
+
+// validateNativeHistogram checks that the spans of the native histogram, which are set by the client,
+// match its buckets, so that converting them doesn't allocate an arbitrary number of buckets
+func validateNativeHistogram(h prompb.Histogram) error {
+	if err := validateNativeSpans(h.PositiveSpans, len(h.PositiveDeltas)+len(h.PositiveCounts)); err != nil {
+		return fmt.Errorf("positive buckets: %w", err)
+	}
+	if err := validateNativeSpans(h.NegativeSpans, len(h.NegativeDeltas)+len(h.NegativeCounts)); err != nil {
+		return fmt.Errorf("negative buckets: %w", err)
+	}
+	return nil
+}
+
+func validateNativeSpans(spans []*prompb.BucketSpan, bucketCount int) error {
+	var length, indexRange int64
+	for i, span := range spans {
+		switch {
+		case i == 0 && span.Offset == math.MinInt32:
+			return fmt.Errorf("offset %d out of range", span.Offset)
+		case i > 0 && span.Offset < 0:
+			return fmt.Errorf("negative offset %d between spans", span.Offset)
+		case i > 0:
+			indexRange += int64(span.Offset)
+		}
+		length += int64(span.Length)
+		indexRange += int64(span.Length)
+		if indexRange > maxNativeBucketsRange {
+			return fmt.Errorf("spans covering more than %d buckets", maxNativeBucketsRange)
+		}
+	}
+	if length != int64(bucketCount) {
+		return fmt.Errorf("spans of %d buckets for %d bucket counts", length, bucketCount)
+	}
+	return nil
+}
+
+// convertNativeBuckets converts the spans and the delta encoded (or float) counts of native histogram
+// buckets into exponential histogram buckets. The native bucket of index i covers (base^(i-1), base^i],
+// which is the exponential histogram bucket of index i-1.
+func convertNativeBuckets(spans []*prompb.BucketSpan, deltas []int64, counts []float64, buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+
+	first := spans[0].Offset
+	buckets.SetOffset(first - 1)
+	bucketCounts := buckets.BucketCounts()
+
+	var current int64
+	idx, pos := first, 0
+	for i, span := range spans {
+		if i > 0 {
+			idx += span.Offset
+		}
+		// fill the gap between spans with empty buckets
+		for int32(bucketCounts.Len()) < idx-first {
+			bucketCounts.Append(0)
+		}
+		for j := uint32(0); j < span.Length; j++ {
+			var count uint64
+			switch {
+			case pos < len(deltas):
+				current += deltas[pos]
+				count = uint64(current)
+			case pos < len(counts):
+				count = uint64(counts[pos])
+			}
+			bucketCounts.Append(count)
+			idx++
+			pos++
+		}
+	}
+}
+
+// parseBound parses the float value of the label, which must be present
+func parseBound(labels []prompb.Label, name string) (float64, error) {
+	for _, l := range labels {
+		if l.Name == name {
+			return strconv.ParseFloat(l.Value, 64)
+		}
+	}
+	return 0, fmt.Errorf("missing %s label", name)
+}
+
+// setAttributes sets the labels, other than the metric name, job and instance, as attributes
+func setAttributes(attrs pcommon.Map, labels []prompb.Label) {
+	for _, l := range labels {
+		if !isReservedLabel(l.Name) {
+			attrs.PutStr(l.Name, l.Value)
+		}
+	}
+}
+
+// isReservedLabel returns true for the labels which are not converted into attributes
+func isReservedLabel(name string) bool {
+	return name == nameStr || name == model.JobLabel || name == model.InstanceLabel
+}
+
+func isCompositeType(t prompb.MetricMetadata_MetricType) bool {
+	return t == prompb.MetricMetadata_HISTOGRAM || t == prompb.MetricMetadata_GAUGEHISTOGRAM || t == prompb.MetricMetadata_SUMMARY
+}
+
+func labelValue(labels []prompb.Label, name string) string {
+	for _, l := range labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func hasLabel(labels []prompb.Label, name string) bool {
+	for _, l := range labels {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,357 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewrite
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+const testTimestampMs = int64(1672531200000)
+
+var testTimestamp = pcommon.NewTimestampFromTime(time.UnixMilli(testTimestampMs))
+
+// newTestMetrics returns metrics with a single resource of the job and instance, and its metric slice
+func newTestMetrics(namespace, name, instance string) (pmetric.Metrics, pmetric.MetricSlice) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	if namespace != "" {
+		rm.Resource().Attributes().PutStr("service.namespace", namespace)
+	}
+	rm.Resource().Attributes().PutStr("service.name", name)
+	rm.Resource().Attributes().PutStr("service.instance.id", instance)
+	return md, rm.ScopeMetrics().AppendEmpty().Metrics()
+}
+
+func TestToMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *prompb.WriteRequest
+		expected func() pmetric.Metrics
+	}{
+		{
+			name: "gauge",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(getPromLabels(nameStr, "temperature", "job", "ns/app", "instance", "host:80", "room", "kitchen"),
+						getSample(21.5, testTimestampMs)),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md, metrics := newTestMetrics("ns", "app", "host:80")
+				metric := metrics.AppendEmpty()
+				metric.SetName("temperature")
+				dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.Attributes().PutStr("room", "kitchen")
+				dp.SetTimestamp(testTimestamp)
+				dp.SetDoubleValue(21.5)
+				return md
+			},
+		},
+		{
+			name: "counter",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(getPromLabels(nameStr, "requests_total", "job", "app", "instance", "host:80"),
+						getSample(3, testTimestampMs), getSample(5, testTimestampMs+1000)),
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests", Help: "Number of requests"},
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md, metrics := newTestMetrics("", "app", "host:80")
+				metric := metrics.AppendEmpty()
+				metric.SetName("requests_total")
+				metric.SetDescription("Number of requests")
+				sum := metric.SetEmptySum()
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				sum.SetIsMonotonic(true)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(testTimestamp)
+				dp.SetDoubleValue(3)
+				dp = sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(testTimestamp + pcommon.Timestamp(time.Second))
+				dp.SetDoubleValue(5)
+				return md
+			},
+		},
+		{
+			name: "histogram",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(getPromLabels(nameStr, "latency_bucket", "job", "app", "instance", "host:80", leStr, "0.1"),
+						getSample(2, testTimestampMs)),
+					*getTimeSeries(getPromLabels(nameStr, "latency_bucket", "job", "app", "instance", "host:80", leStr, "1"),
+						getSample(5, testTimestampMs)),
+					*getTimeSeries(getPromLabels(nameStr, "latency_bucket", "job", "app", "instance", "host:80", leStr, pInfStr),
+						getSample(6, testTimestampMs)),
+					*getTimeSeries(getPromLabels(nameStr, "latency_sum", "job", "app", "instance", "host:80"),
+						getSample(3.5, testTimestampMs)),
+					*getTimeSeries(getPromLabels(nameStr, "latency_count", "job", "app", "instance", "host:80"),
+						getSample(6, testTimestampMs)),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md, metrics := newTestMetrics("", "app", "host:80")
+				metric := metrics.AppendEmpty()
+				metric.SetName("latency")
+				histogram := metric.SetEmptyHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := histogram.DataPoints().AppendEmpty()
+				dp.SetTimestamp(testTimestamp)
+				dp.SetCount(6)
+				dp.SetSum(3.5)
+				dp.ExplicitBounds().FromRaw([]float64{0.1, 1})
+				dp.BucketCounts().FromRaw([]uint64{2, 3, 1})
+				return md
+			},
+		},
+		{
+			name: "summary",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(getPromLabels(nameStr, "rpc", "job", "app", "instance", "host:80", quantileStr, "0.9"),
+						getSample(2, testTimestampMs)),
+					*getTimeSeries(getPromLabels(nameStr, "rpc", "job", "app", "instance", "host:80", quantileStr, "0.5"),
+						getSample(1, testTimestampMs)),
+					*getTimeSeries(getPromLabels(nameStr, "rpc_sum", "job", "app", "instance", "host:80"),
+						getSample(10, testTimestampMs)),
+					*getTimeSeries(getPromLabels(nameStr, "rpc_count", "job", "app", "instance", "host:80"),
+						getSample(4, testTimestampMs)),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md, metrics := newTestMetrics("", "app", "host:80")
+				metric := metrics.AppendEmpty()
+				metric.SetName("rpc")
+				dp := metric.SetEmptySummary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(testTimestamp)
+				dp.SetCount(4)
+				dp.SetSum(10)
+				getQuantiles([]float64{0.5, 0.9}, []float64{1, 2}).CopyTo(dp.QuantileValues())
+				return md
+			},
+		},
+		{
+			name: "native histogram",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels: getPromLabels(nameStr, "latency", "job", "app", "instance", "host:80"),
+						Histograms: []prompb.Histogram{{
+							Count:          &prompb.Histogram_CountInt{CountInt: 6},
+							Sum:            12,
+							Schema:         1,
+							ZeroThreshold:  prometheustranslator.DefaultZeroThreshold,
+							ZeroCount:      &prompb.Histogram_ZeroCountInt{ZeroCountInt: 1},
+							PositiveSpans:  []*prompb.BucketSpan{{Offset: 1, Length: 2}, {Offset: 1, Length: 1}},
+							PositiveDeltas: []int64{2, -1, 1},
+							Timestamp:      testTimestampMs,
+						}},
+					},
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md, metrics := newTestMetrics("", "app", "host:80")
+				metric := metrics.AppendEmpty()
+				metric.SetName("latency")
+				histogram := metric.SetEmptyExponentialHistogram()
+				histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := histogram.DataPoints().AppendEmpty()
+				dp.SetTimestamp(testTimestamp)
+				dp.SetCount(6)
+				dp.SetSum(12)
+				dp.SetScale(1)
+				dp.SetZeroCount(1)
+				dp.Positive().SetOffset(0)
+				dp.Positive().BucketCounts().FromRaw([]uint64{2, 1, 0, 2})
+				return md
+			},
+		},
+		{
+			name: "target info and stale markers",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(getPromLabels(nameStr, "up", "job", "app", "instance", "host:80"),
+						getSample(math.Float64frombits(value.StaleNaN), testTimestampMs)),
+					*getTimeSeries(getPromLabels(nameStr, targetMetricName, "job", "app", "instance", "host:80", "region", "eu"),
+						getSample(1, testTimestampMs)),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md, metrics := newTestMetrics("", "app", "host:80")
+				md.ResourceMetrics().At(0).Resource().Attributes().PutStr("region", "eu")
+				metric := metrics.AppendEmpty()
+				metric.SetName("up")
+				dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(testTimestamp)
+				dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+				return md
+			},
+		},
+		{
+			name: "multiple resources",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					*getTimeSeries(getPromLabels(nameStr, "up", "job", "app", "instance", "host1:80"),
+						getSample(1, testTimestampMs)),
+					*getTimeSeries(getPromLabels(nameStr, "up", "job", "app", "instance", "host2:80"),
+						getSample(0, testTimestampMs)),
+				},
+			},
+			expected: func() pmetric.Metrics {
+				md, metrics := newTestMetrics("", "app", "host1:80")
+				metric := metrics.AppendEmpty()
+				metric.SetName("up")
+				dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(testTimestamp)
+				dp.SetDoubleValue(1)
+
+				other, otherMetrics := newTestMetrics("", "app", "host2:80")
+				metric = otherMetrics.AppendEmpty()
+				metric.SetName("up")
+				dp = metric.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(testTimestamp)
+				dp.SetDoubleValue(0)
+				other.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+				return md
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md, err := ToMetrics(tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected(), md)
+		})
+	}
+}
+
+func TestToMetricsInvalidSeries(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			*getTimeSeries(getPromLabels("job", "app"), getSample(1, testTimestampMs)),
+			*getTimeSeries(getPromLabels(nameStr, "latency_bucket", "job", "app", leStr, "invalid"),
+				getSample(1, testTimestampMs)),
+			*getTimeSeries(getPromLabels(nameStr, "up", "job", "app"), getSample(1, testTimestampMs)),
+		},
+	}
+
+	md, err := ToMetrics(req)
+	assert.ErrorIs(t, err, errMissingMetricName)
+	assert.ErrorContains(t, err, "invalid bucket of latency")
+	// the valid series are still converted
+	assert.Equal(t, 1, md.DataPointCount())
+}
+
+func TestToMetricsInvalidNativeHistogram(t *testing.T) {
+	tests := []struct {
+		name      string
+		histogram prompb.Histogram
+		err       string
+	}{
+		{
+			name: "more buckets than counts",
+			histogram: prompb.Histogram{
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 1 << 30}},
+				PositiveDeltas: []int64{1, 1},
+			},
+			err: "positive buckets: spans covering more than 16384 buckets",
+		},
+		{
+			name: "fewer buckets than counts",
+			histogram: prompb.Histogram{
+				NegativeSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 1}},
+				NegativeCounts: []float64{1, 1},
+			},
+			err: "negative buckets: spans of 1 buckets for 2 bucket counts",
+		},
+		{
+			name: "huge gap",
+			histogram: prompb.Histogram{
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 1}, {Offset: math.MaxInt32, Length: 1}},
+				PositiveDeltas: []int64{1, 1},
+			},
+			err: "positive buckets: spans covering more than 16384 buckets",
+		},
+		{
+			name: "negative gap",
+			histogram: prompb.Histogram{
+				PositiveSpans:  []*prompb.BucketSpan{{Offset: 0, Length: 1}, {Offset: -1, Length: 1}},
+				PositiveDeltas: []int64{1, 1},
+			},
+			err: "positive buckets: negative offset -1 between spans",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					{
+						Labels:     getPromLabels(nameStr, "exp_hist", "job", "app"),
+						Histograms: []prompb.Histogram{tt.histogram},
+					},
+					*getTimeSeries(getPromLabels(nameStr, "up", "job", "app"), getSample(1, testTimestampMs)),
+				},
+			}
+
+			md, err := ToMetrics(req)
+			assert.EqualError(t, err, "invalid native histogram of exp_hist: "+tt.err)
+			// the valid series are still converted, and no empty metric is left for the invalid one
+			assert.Equal(t, 1, md.MetricCount())
+			assert.Equal(t, 1, md.DataPointCount())
+		})
+	}
+}
+
+func TestToMetricsNativeHistogramRoundTrip(t *testing.T) {
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	expected := getExpHistogramMetric("exp_hist", lbs1, time1, floatVal1, 9, 0, -2, []uint64{1, 2, 0, 0, 0, 3})
+	expected.CopyTo(md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0))
+
+	tsMap, err := FromMetrics(md, Settings{DisableTargetInfo: true})
+	require.NoError(t, err)
+	req := &prompb.WriteRequest{}
+	for _, ts := range tsMap {
+		req.Timeseries = append(req.Timeseries, *ts)
+	}
+
+	converted, err := ToMetrics(req)
+	require.NoError(t, err)
+	require.Equal(t, 1, converted.MetricCount())
+	metric := converted.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, metric.Type())
+	dp := metric.ExponentialHistogram().DataPoints().At(0)
+	expectedDp := expected.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, expectedDp.Count(), dp.Count())
+	assert.Equal(t, expectedDp.Sum(), dp.Sum())
+	assert.Equal(t, expectedDp.Scale(), dp.Scale())
+	assert.Equal(t, expectedDp.Positive().Offset(), dp.Positive().Offset())
+	assert.Equal(t, expectedDp.Positive().BucketCounts().AsRaw(), dp.Positive().BucketCounts().AsRaw())
+}
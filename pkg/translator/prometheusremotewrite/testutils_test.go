@@ -113,46 +113,52 @@ var (
 	quantileValues = []float64{7, 8, 9}
 	quantiles      = getQuantiles(quantileBounds, quantileValues)
 
-	validIntGauge    = "valid_IntGauge"
-	validDoubleGauge = "valid_DoubleGauge"
-	validIntSum      = "valid_IntSum"
-	validSum         = "valid_Sum"
-	validHistogram   = "valid_Histogram"
-	validSummary     = "valid_Summary"
-	suffixedCounter  = "valid_IntSum_total"
+	validIntGauge     = "valid_IntGauge"
+	validDoubleGauge  = "valid_DoubleGauge"
+	validIntSum       = "valid_IntSum"
+	validSum          = "valid_Sum"
+	validHistogram    = "valid_Histogram"
+	validExpHistogram = "valid_ExpHistogram"
+	validSummary      = "valid_Summary"
+	suffixedCounter   = "valid_IntSum_total"
 
 	// valid metrics as input should not return error
 	validMetrics1 = map[string]pmetric.Metric{
-		validIntGauge:    getIntGaugeMetric(validIntGauge, lbs1, intVal1, time1),
-		validDoubleGauge: getDoubleGaugeMetric(validDoubleGauge, lbs1, floatVal1, time1),
-		validIntSum:      getIntSumMetric(validIntSum, lbs1, intVal1, time1),
-		suffixedCounter:  getIntSumMetric(suffixedCounter, lbs1, intVal1, time1),
-		validSum:         getSumMetric(validSum, lbs1, floatVal1, time1),
-		validHistogram:   getHistogramMetric(validHistogram, lbs1, time1, floatVal1, uint64(intVal1), bounds, buckets),
-		validSummary:     getSummaryMetric(validSummary, lbs1, time1, floatVal1, uint64(intVal1), quantiles),
+		validIntGauge:     getIntGaugeMetric(validIntGauge, lbs1, intVal1, time1),
+		validDoubleGauge:  getDoubleGaugeMetric(validDoubleGauge, lbs1, floatVal1, time1),
+		validIntSum:       getIntSumMetric(validIntSum, lbs1, intVal1, time1),
+		suffixedCounter:   getIntSumMetric(suffixedCounter, lbs1, intVal1, time1),
+		validSum:          getSumMetric(validSum, lbs1, floatVal1, time1),
+		validHistogram:    getHistogramMetric(validHistogram, lbs1, time1, floatVal1, uint64(intVal1), bounds, buckets),
+		validExpHistogram: getExpHistogramMetric(validExpHistogram, lbs1, time1, floatVal1, uint64(intVal1), 0, 0, []uint64{1}),
+		validSummary:      getSummaryMetric(validSummary, lbs1, time1, floatVal1, uint64(intVal1), quantiles),
 	}
 
 	empty = "empty"
 
 	// Category 1: type and data field doesn't match
-	emptyGauge     = "emptyGauge"
-	emptySum       = "emptySum"
-	emptyHistogram = "emptyHistogram"
-	emptySummary   = "emptySummary"
+	emptyGauge        = "emptyGauge"
+	emptySum          = "emptySum"
+	emptyHistogram    = "emptyHistogram"
+	emptyExpHistogram = "emptyExpHistogram"
+	emptySummary      = "emptySummary"
 
 	// Category 2: invalid type and temporality combination
-	emptyCumulativeSum       = "emptyCumulativeSum"
-	emptyCumulativeHistogram = "emptyCumulativeHistogram"
+	emptyCumulativeSum          = "emptyCumulativeSum"
+	emptyCumulativeHistogram    = "emptyCumulativeHistogram"
+	emptyCumulativeExpHistogram = "emptyCumulativeExpHistogram"
 
 	// different metrics that will not pass validate metrics and will cause the exporter to return an error
 	invalidMetrics = map[string]pmetric.Metric{
-		empty:                    pmetric.NewMetric(),
-		emptyGauge:               getEmptyGaugeMetric(emptyGauge),
-		emptySum:                 getEmptySumMetric(emptySum),
-		emptyHistogram:           getEmptyHistogramMetric(emptyHistogram),
-		emptySummary:             getEmptySummaryMetric(emptySummary),
-		emptyCumulativeSum:       getEmptyCumulativeSumMetric(emptyCumulativeSum),
-		emptyCumulativeHistogram: getEmptyCumulativeHistogramMetric(emptyCumulativeHistogram),
+		empty:                       pmetric.NewMetric(),
+		emptyGauge:                  getEmptyGaugeMetric(emptyGauge),
+		emptySum:                    getEmptySumMetric(emptySum),
+		emptyHistogram:              getEmptyHistogramMetric(emptyHistogram),
+		emptySummary:                getEmptySummaryMetric(emptySummary),
+		emptyCumulativeSum:          getEmptyCumulativeSumMetric(emptyCumulativeSum),
+		emptyCumulativeHistogram:    getEmptyCumulativeHistogramMetric(emptyCumulativeHistogram),
+		emptyExpHistogram:           getEmptyExpHistogramMetric(emptyExpHistogram),
+		emptyCumulativeExpHistogram: getEmptyCumulativeExpHistogramMetric(emptyCumulativeExpHistogram),
 	}
 )
 
@@ -369,6 +375,40 @@ func getHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum floa
 	return metric
 }
 
+func getEmptyExpHistogramMetric(name string) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetEmptyExponentialHistogram()
+	return metric
+}
+
+func getEmptyCumulativeExpHistogramMetric(name string) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	return metric
+}
+
+func getExpHistogramMetric(name string, attributes pcommon.Map, ts uint64, sum float64, count uint64, scale int32,
+	offset int32, buckets []uint64) pmetric.Metric {
+	metric := pmetric.NewMetric()
+	metric.SetName(name)
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	if strings.HasPrefix(name, "staleNaN") {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	dp.SetScale(scale)
+	dp.Positive().SetOffset(offset)
+	dp.Positive().BucketCounts().FromRaw(buckets)
+	attributes.CopyTo(dp.Attributes())
+
+	dp.SetTimestamp(pcommon.Timestamp(ts))
+	return metric
+}
+
 func getEmptySummaryMetric(name string) pmetric.Metric {
 	metric := pmetric.NewMetric()
 	metric.SetName(name)
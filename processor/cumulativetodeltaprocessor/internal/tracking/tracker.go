@@ -41,10 +41,11 @@ type State struct {
 }
 
 type DeltaValue struct {
-	StartTimestamp pcommon.Timestamp
-	FloatValue     float64
-	IntValue       int64
-	HistogramValue *HistogramPoint
+	StartTimestamp    pcommon.Timestamp
+	FloatValue        float64
+	IntValue          int64
+	HistogramValue    *HistogramPoint
+	ExpHistogramValue *ExpHistogramPoint
 }
 
 func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
@@ -92,10 +93,11 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 	if !ok {
 		if metricID.MetricIsMonotonic {
 			out = DeltaValue{
-				StartTimestamp: metricPoint.ObservedTimestamp,
-				FloatValue:     metricPoint.FloatValue,
-				IntValue:       metricPoint.IntValue,
-				HistogramValue: metricPoint.HistogramValue,
+				StartTimestamp:    metricPoint.ObservedTimestamp,
+				FloatValue:        metricPoint.FloatValue,
+				IntValue:          metricPoint.IntValue,
+				HistogramValue:    metricPoint.HistogramValue,
+				ExpHistogramValue: metricPoint.ExpHistogramValue,
 			}
 			valid = true
 		}
@@ -133,6 +135,14 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		}
 
 		out.HistogramValue = &delta
+	case pmetric.MetricTypeExponentialHistogram:
+		value := metricPoint.ExpHistogramValue
+		prevValue := state.PrevPoint.ExpHistogramValue
+		if math.IsNaN(value.Sum) {
+			value.Sum = prevValue.Sum
+		}
+
+		out.ExpHistogramValue = expHistogramDelta(value, prevValue)
 	case pmetric.MetricTypeSum:
 		if metricID.IsFloatVal() {
 			value := metricPoint.FloatValue
@@ -163,6 +173,36 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 	return
 }
 
+// expHistogramDelta returns the difference between the exponential histogram and the previous one,
+// both reduced to the lowest of their scales, as the buckets of the previous histogram may have been
+// merged since. If the histogram was reset, the delta is the histogram itself.
+func expHistogramDelta(value, prevValue *ExpHistogramPoint) *ExpHistogramPoint {
+	delta := value.Clone()
+	if value.Count < prevValue.Count || value.ZeroCount < prevValue.ZeroCount {
+		return &delta
+	}
+
+	prevPositive, prevNegative := prevValue.Positive, prevValue.Negative
+	if delta.Scale > prevValue.Scale {
+		delta.Positive = delta.Positive.Downscale(delta.Scale - prevValue.Scale)
+		delta.Negative = delta.Negative.Downscale(delta.Scale - prevValue.Scale)
+		delta.Scale = prevValue.Scale
+	} else if delta.Scale < prevValue.Scale {
+		prevPositive = prevPositive.Downscale(prevValue.Scale - delta.Scale)
+		prevNegative = prevNegative.Downscale(prevValue.Scale - delta.Scale)
+	}
+
+	if !delta.Positive.Subtract(prevPositive) || !delta.Negative.Subtract(prevNegative) {
+		reset := value.Clone()
+		return &reset
+	}
+
+	delta.Count -= prevValue.Count
+	delta.Sum -= prevValue.Sum
+	delta.ZeroCount -= prevValue.ZeroCount
+	return &delta
+}
+
 func (t *MetricTracker) removeStale(staleBefore pcommon.Timestamp) {
 	t.states.Range(func(key, value interface{}) bool {
 		s := value.(*State)
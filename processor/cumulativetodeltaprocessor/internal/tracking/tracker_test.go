@@ -151,6 +151,119 @@ func TestMetricTracker_Convert(t *testing.T) {
 	})
 }
 
+func TestMetricTracker_ConvertExpHistogram(t *testing.T) {
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeExponentialHistogram,
+		MetricIsMonotonic:      true,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		Attributes:             pcommon.NewMap(),
+	}
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	tests := []struct {
+		name    string
+		value   ExpHistogramPoint
+		wantOut ExpHistogramPoint
+	}{
+		{
+			name: "Initial Value recorded",
+			value: ExpHistogramPoint{
+				Count: 6, Sum: 10, ZeroCount: 1, Scale: 2,
+				Positive: ExpBuckets{Offset: 1, Counts: []uint64{1, 2}},
+				Negative: ExpBuckets{Offset: 0, Counts: []uint64{2}},
+			},
+			wantOut: ExpHistogramPoint{
+				Count: 6, Sum: 10, ZeroCount: 1, Scale: 2,
+				Positive: ExpBuckets{Offset: 1, Counts: []uint64{1, 2}},
+				Negative: ExpBuckets{Offset: 0, Counts: []uint64{2}},
+			},
+		},
+		{
+			name: "Offset shifted",
+			value: ExpHistogramPoint{
+				Count: 10, Sum: 15, ZeroCount: 2, Scale: 2,
+				Positive: ExpBuckets{Offset: 0, Counts: []uint64{1, 1, 3}},
+				Negative: ExpBuckets{Offset: 0, Counts: []uint64{2, 1}},
+			},
+			wantOut: ExpHistogramPoint{
+				Count: 4, Sum: 5, ZeroCount: 1, Scale: 2,
+				Positive: ExpBuckets{Offset: 0, Counts: []uint64{1, 0, 1}},
+				Negative: ExpBuckets{Offset: 0, Counts: []uint64{0, 1}},
+			},
+		},
+		{
+			name: "Scale reduced",
+			value: ExpHistogramPoint{
+				Count: 12, Sum: 20, ZeroCount: 2, Scale: 1,
+				Positive: ExpBuckets{Offset: 0, Counts: []uint64{3, 4}},
+				Negative: ExpBuckets{Offset: 0, Counts: []uint64{3}},
+			},
+			wantOut: ExpHistogramPoint{
+				Count: 2, Sum: 5, ZeroCount: 0, Scale: 1,
+				Positive: ExpBuckets{Offset: 0, Counts: []uint64{1, 1}},
+				Negative: ExpBuckets{Offset: 0, Counts: []uint64{0}},
+			},
+		},
+		{
+			name: "Reset",
+			value: ExpHistogramPoint{
+				Count: 3, Sum: 4, ZeroCount: 0, Scale: 3,
+				Positive: ExpBuckets{Offset: 2, Counts: []uint64{3}},
+			},
+			wantOut: ExpHistogramPoint{
+				Count: 3, Sum: 4, ZeroCount: 0, Scale: 3,
+				Positive: ExpBuckets{Offset: 2, Counts: []uint64{3}},
+				Negative: ExpBuckets{Counts: []uint64{}},
+			},
+		},
+		{
+			name: "Scale increased",
+			value: ExpHistogramPoint{
+				Count: 5, Sum: 6, ZeroCount: 0, Scale: 4,
+				Positive: ExpBuckets{Offset: 4, Counts: []uint64{4, 0, 1}},
+			},
+			wantOut: ExpHistogramPoint{
+				Count: 2, Sum: 2, ZeroCount: 0, Scale: 3,
+				Positive: ExpBuckets{Offset: 2, Counts: []uint64{1, 1}},
+				Negative: ExpBuckets{Counts: []uint64{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := tt.value
+			gotOut, valid := m.Convert(MetricPoint{
+				Identity: mi,
+				Value:    ValuePoint{ExpHistogramValue: &value},
+			})
+			if !valid || !reflect.DeepEqual(*gotOut.ExpHistogramValue, tt.wantOut) {
+				t.Errorf("MetricTracker.Convert(MetricTypeExponentialHistogram) = %v, want %v", *gotOut.ExpHistogramValue, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestExpBuckets_Downscale(t *testing.T) {
+	buckets := ExpBuckets{Offset: -3, Counts: []uint64{1, 2, 3, 4, 5}}
+
+	if got := buckets.Downscale(0); !reflect.DeepEqual(got, buckets) {
+		t.Errorf("ExpBuckets.Downscale(0) = %v, want %v", got, buckets)
+	}
+
+	want := ExpBuckets{Offset: -2, Counts: []uint64{1, 5, 9}}
+	if got := buckets.Downscale(1); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpBuckets.Downscale(1) = %v, want %v", got, want)
+	}
+
+	want = ExpBuckets{Offset: -1, Counts: []uint64{6, 9}}
+	if got := buckets.Downscale(2); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpBuckets.Downscale(2) = %v, want %v", got, want)
+	}
+}
+
 func Test_metricTracker_removeStale(t *testing.T) {
 	currentTime := pcommon.Timestamp(100)
 	freshPoint := ValuePoint{
@@ -21,6 +21,7 @@ type ValuePoint struct {
 	FloatValue        float64
 	IntValue          int64
 	HistogramValue    *HistogramPoint
+	ExpHistogramValue *ExpHistogramPoint
 }
 
 type HistogramPoint struct {
@@ -39,3 +40,88 @@ func (point *HistogramPoint) Clone() HistogramPoint {
 		Buckets: bucketValues,
 	}
 }
+
+type ExpHistogramPoint struct {
+	Count     uint64
+	Sum       float64
+	ZeroCount uint64
+	Scale     int32
+	Positive  ExpBuckets
+	Negative  ExpBuckets
+}
+
+// ExpBuckets are the buckets of an exponential histogram, the first of which is at index Offset
+type ExpBuckets struct {
+	Offset int32
+	Counts []uint64
+}
+
+func (point *ExpHistogramPoint) Clone() ExpHistogramPoint {
+	return ExpHistogramPoint{
+		Count:     point.Count,
+		Sum:       point.Sum,
+		ZeroCount: point.ZeroCount,
+		Scale:     point.Scale,
+		Positive:  point.Positive.Clone(),
+		Negative:  point.Negative.Clone(),
+	}
+}
+
+func (buckets ExpBuckets) Clone() ExpBuckets {
+	counts := make([]uint64, len(buckets.Counts))
+	copy(counts, buckets.Counts)
+
+	return ExpBuckets{
+		Offset: buckets.Offset,
+		Counts: counts,
+	}
+}
+
+// Downscale returns the buckets with a scale reduced by scaleDown, whose
+// consecutive buckets are merged
+func (buckets ExpBuckets) Downscale(scaleDown int32) ExpBuckets {
+	if scaleDown == 0 || len(buckets.Counts) == 0 {
+		return buckets.Clone()
+	}
+
+	offset := buckets.Offset >> scaleDown
+	last := (buckets.Offset + int32(len(buckets.Counts)-1)) >> scaleDown
+	counts := make([]uint64, last-offset+1)
+	for i, count := range buckets.Counts {
+		counts[((buckets.Offset+int32(i))>>scaleDown)-offset] += count
+	}
+
+	return ExpBuckets{
+		Offset: offset,
+		Counts: counts,
+	}
+}
+
+// at returns the count of the bucket at the index
+func (buckets ExpBuckets) at(index int32) uint64 {
+	i := index - buckets.Offset
+	if i < 0 || int(i) >= len(buckets.Counts) {
+		return 0
+	}
+	return buckets.Counts[i]
+}
+
+// Subtract subtracts in place the counts of the previous buckets from the buckets, which must have
+// the same scale. It returns false, leaving the buckets unchanged, if a count would become negative
+// or if the previous buckets have counts outside the range of the buckets, as the histogram was reset.
+func (buckets ExpBuckets) Subtract(prev ExpBuckets) bool {
+	for i, count := range prev.Counts {
+		if count == 0 {
+			continue
+		}
+		index := prev.Offset + int32(i)
+		j := index - buckets.Offset
+		if j < 0 || int(j) >= len(buckets.Counts) || buckets.Counts[j] < count {
+			return false
+		}
+	}
+	for j := range buckets.Counts {
+		buckets.Counts[j] -= prev.at(buckets.Offset + int32(j))
+	}
+	return true
+}
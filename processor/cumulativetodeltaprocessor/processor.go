@@ -121,6 +121,30 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 
 					ctdp.convertHistogramDataPoints(ms.DataPoints(), baseIdentity)
 
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						return false
+					}
+
+					if ms.DataPoints().Len() == 0 {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricType:             m.Type(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+						MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+					}
+
+					ctdp.convertExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity)
+
 					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
 					return ms.DataPoints().Len() == 0
 				default:
@@ -226,3 +250,53 @@ func (ctdp *cumulativeToDeltaProcessor) convertHistogramDataPoints(in interface{
 		})
 	}
 }
+
+func (ctdp *cumulativeToDeltaProcessor) convertExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+		id := baseIdentity
+		id.StartTimestamp = dp.StartTimestamp()
+		id.Attributes = dp.Attributes()
+
+		point := tracking.ValuePoint{
+			ObservedTimestamp: dp.Timestamp(),
+			ExpHistogramValue: &tracking.ExpHistogramPoint{
+				Count:     dp.Count(),
+				Sum:       dp.Sum(),
+				ZeroCount: dp.ZeroCount(),
+				Scale:     dp.Scale(),
+				Positive: tracking.ExpBuckets{
+					Offset: dp.Positive().Offset(),
+					Counts: dp.Positive().BucketCounts().AsRaw(),
+				},
+				Negative: tracking.ExpBuckets{
+					Offset: dp.Negative().Offset(),
+					Counts: dp.Negative().BucketCounts().AsRaw(),
+				},
+			},
+		}
+
+		trackingPoint := tracking.MetricPoint{
+			Identity: id,
+			Value:    point,
+		}
+		delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+		if !valid {
+			return true
+		}
+
+		dp.SetStartTimestamp(delta.StartTimestamp)
+		dp.SetCount(delta.ExpHistogramValue.Count)
+		if dp.HasSum() && !math.IsNaN(dp.Sum()) {
+			dp.SetSum(delta.ExpHistogramValue.Sum)
+		}
+		dp.SetZeroCount(delta.ExpHistogramValue.ZeroCount)
+		dp.SetScale(delta.ExpHistogramValue.Scale)
+		dp.Positive().SetOffset(delta.ExpHistogramValue.Positive.Offset)
+		dp.Positive().BucketCounts().FromRaw(delta.ExpHistogramValue.Positive.Counts)
+		dp.Negative().SetOffset(delta.ExpHistogramValue.Negative.Offset)
+		dp.Negative().BucketCounts().FromRaw(delta.ExpHistogramValue.Negative.Counts)
+		dp.RemoveMin()
+		dp.RemoveMax()
+		return false
+	})
+}
@@ -49,6 +49,17 @@ type testHistogramMetric struct {
 	isCumulative  []bool
 }
 
+type testExpHistogramMetric struct {
+	metricNames      []string
+	metricCounts     [][]uint64
+	metricSums       [][]float64
+	metricZeroCounts [][]uint64
+	metricScales     [][]int32
+	metricOffsets    [][]int32
+	metricBuckets    [][][]uint64
+	isCumulative     []bool
+}
+
 type cumulativeToDeltaTest struct {
 	name       string
 	include    MatchMetrics
@@ -282,6 +293,36 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 				isCumulative: []bool{false, true},
 			}),
 		},
+		{
+			name: "cumulative_to_delta_exponential_histogram",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestExpHistogramMetrics(testExpHistogramMetric{
+				metricNames:      []string{"metric_1", "metric_2"},
+				metricCounts:     [][]uint64{{4, 7, 9}, {4}},
+				metricSums:       [][]float64{{10, 20, 30}, {10}},
+				metricZeroCounts: [][]uint64{{1, 2, 2}, {1}},
+				metricScales:     [][]int32{{1, 1, 0}, {1}},
+				metricOffsets:    [][]int32{{0, -1, -1}, {0}},
+				metricBuckets:    [][][]uint64{{{1, 2}, {1, 1, 3, 0}, {2, 5}}, {{1, 2}}},
+				isCumulative:     []bool{true, true},
+			}),
+			outMetrics: generateTestExpHistogramMetrics(testExpHistogramMetric{
+				metricNames:      []string{"metric_1", "metric_2"},
+				metricCounts:     [][]uint64{{4, 3, 2}, {4}},
+				metricSums:       [][]float64{{10, 10, 10}, {10}},
+				metricZeroCounts: [][]uint64{{1, 1, 0}, {1}},
+				metricScales:     [][]int32{{1, 1, 0}, {1}},
+				metricOffsets:    [][]int32{{0, -1, -1}, {0}},
+				metricBuckets:    [][][]uint64{{{1, 2}, {1, 0, 1, 0}, {1, 1}}, {{1, 2}}},
+				isCumulative:     []bool{false, true},
+			}),
+		},
 		{
 			name: "cumulative_to_delta_all",
 			include: MatchMetrics{
@@ -418,6 +459,23 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 						require.Equal(t, eDataPoints.At(j).BucketCounts(), aDataPoints.At(j).BucketCounts())
 					}
 				}
+
+				if eM.Type() == pmetric.MetricTypeExponentialHistogram {
+					eDataPoints := eM.ExponentialHistogram().DataPoints()
+					aDataPoints := aM.ExponentialHistogram().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.ExponentialHistogram().AggregationTemporality(), aM.ExponentialHistogram().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).Count(), aDataPoints.At(j).Count())
+						require.Equal(t, eDataPoints.At(j).Sum(), aDataPoints.At(j).Sum())
+						require.Equal(t, eDataPoints.At(j).ZeroCount(), aDataPoints.At(j).ZeroCount())
+						require.Equal(t, eDataPoints.At(j).Scale(), aDataPoints.At(j).Scale())
+						require.Equal(t, eDataPoints.At(j).Positive().Offset(), aDataPoints.At(j).Positive().Offset())
+						require.Equal(t, eDataPoints.At(j).Positive().BucketCounts(), aDataPoints.At(j).Positive().BucketCounts())
+					}
+				}
 			}
 
 			require.NoError(t, mgp.Shutdown(ctx))
@@ -498,6 +556,38 @@ func generateTestHistogramMetrics(tm testHistogramMetric) pmetric.Metrics {
 	return md
 }
 
+func generateTestExpHistogramMetrics(tm testExpHistogramMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	now := time.Now()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		hist := m.SetEmptyExponentialHistogram()
+
+		if tm.isCumulative[i] {
+			hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		} else {
+			hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		}
+
+		for index, count := range tm.metricCounts[i] {
+			dp := hist.DataPoints().AppendEmpty()
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(10 * time.Second)))
+			dp.SetCount(count)
+			dp.SetSum(tm.metricSums[i][index])
+			dp.SetZeroCount(tm.metricZeroCounts[i][index])
+			dp.SetScale(tm.metricScales[i][index])
+			dp.Positive().SetOffset(tm.metricOffsets[i][index])
+			dp.Positive().BucketCounts().FromRaw(tm.metricBuckets[i][index])
+		}
+	}
+
+	return md
+}
+
 func BenchmarkConsumeMetrics(b *testing.B) {
 	c := consumertest.NewNop()
 	params := processor.CreateSettings{
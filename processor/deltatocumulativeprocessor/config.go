@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+
+	// MaxStaleness is the total time a stream will be accumulated past the time it was last seen.
+	// Its next point then starts a new cumulative stream. Set to 0 to retain streams indefinitely.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
+
+	// MaxStreams is the maximum number of streams accumulated at a time. Once it is reached,
+	// the data points of new streams are dropped. Set to 0 to accumulate an unlimited number of streams.
+	MaxStreams int `mapstructure:"max_streams"`
+
+	// Include specifies a filter on the metrics that should be converted.
+	// Exclude specifies a filter on the metrics that should not be converted.
+	// If neither `include` nor `exclude` are set, all metrics will be converted.
+	Include MatchMetrics `mapstructure:"include"`
+	Exclude MatchMetrics `mapstructure:"exclude"`
+}
+
+type MatchMetrics struct {
+	filterset.Config `mapstructure:",squash"`
+
+	Metrics []string `mapstructure:"metrics"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (config *Config) Validate() error {
+	if (len(config.Include.Metrics) > 0 && len(config.Include.MatchType) == 0) ||
+		(len(config.Exclude.Metrics) > 0 && len(config.Exclude.MatchType) == 0) {
+		return fmt.Errorf("match_type must be set if metrics are supplied")
+	}
+	if (len(config.Include.MatchType) > 0 && len(config.Include.Metrics) == 0) ||
+		(len(config.Exclude.MatchType) > 0 && len(config.Exclude.Metrics) == 0) {
+		return fmt.Errorf("metrics must be supplied if match_type is set")
+	}
+	if config.MaxStaleness < 0 {
+		return errors.New("max_staleness must not be negative")
+	}
+	if config.MaxStreams < 0 {
+		return errors.New("max_streams must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+// Allocate a minimum of 64 bytes to the builder initially
+const initialBytes = 64
+
+var identityBufferPool = sync.Pool{
+	New: func() interface{} {
+		return bytes.NewBuffer(make([]byte, initialBytes))
+	},
+}
+
+// State is the cumulative value of a stream, accumulated since StartTimestamp
+type State struct {
+	sync.Mutex
+	StartTimestamp pcommon.Timestamp
+	Point          ValuePoint
+}
+
+type CumulativeValue struct {
+	StartTimestamp    pcommon.Timestamp
+	FloatValue        float64
+	IntValue          int64
+	HistogramValue    *HistogramPoint
+	ExpHistogramValue *ExpHistogramPoint
+}
+
+// NewMetricTracker creates a tracker accumulating at most maxStreams streams at a time,
+// or an unlimited number of them if maxStreams is 0
+func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration, maxStreams int) *MetricTracker {
+	t := &MetricTracker{logger: logger, maxStaleness: maxStaleness, maxStreams: int64(maxStreams)}
+	if maxStaleness > 0 {
+		go t.sweeper(ctx, t.removeStale)
+	}
+	return t
+}
+
+type MetricTracker struct {
+	logger       *zap.Logger
+	maxStaleness time.Duration
+	maxStreams   int64
+	streams      atomic.Int64
+	states       sync.Map
+}
+
+// Accumulate adds the delta point to the cumulative value of its stream. It returns false if the
+// point must be dropped, as it is older than the last point of the stream or as its stream cannot
+// be tracked once the limit of streams is reached.
+func (t *MetricTracker) Accumulate(in MetricPoint) (out CumulativeValue, valid bool) {
+	metricID := in.Identity
+	metricPoint := in.Value
+	if !metricID.IsSupportedMetricType() {
+		return
+	}
+
+	// NaN is used to signal "stale" metrics.
+	// These are ignored for now.
+	if metricID.IsFloatVal() && math.IsNaN(metricPoint.FloatValue) {
+		return
+	}
+
+	b := identityBufferPool.Get().(*bytes.Buffer)
+	b.Reset()
+	metricID.Write(b)
+	hashableID := b.String()
+	identityBufferPool.Put(b)
+
+	s, ok := t.states.Load(hashableID)
+	if !ok {
+		if t.maxStreams > 0 && t.streams.Load() >= t.maxStreams {
+			t.logger.Debug("dropping data point of a new stream, the maximum number of streams is reached",
+				zap.String("metric", metricID.MetricName), zap.Int64("max_streams", t.maxStreams))
+			return
+		}
+
+		state := newState(metricPoint)
+		if s, ok = t.states.LoadOrStore(hashableID, state); !ok {
+			t.streams.Inc()
+			state.Lock()
+			defer state.Unlock()
+			return state.cumulativeValue(), true
+		}
+	}
+
+	state := s.(*State)
+	state.Lock()
+	defer state.Unlock()
+
+	// Drop duplicated and out of order points, as well as points overlapping the last one
+	if metricPoint.ObservedTimestamp <= state.Point.ObservedTimestamp ||
+		metricPoint.StartTimestamp != 0 && metricPoint.StartTimestamp < state.Point.ObservedTimestamp {
+		return
+	}
+
+	switch metricID.MetricType {
+	case pmetric.MetricTypeHistogram:
+		value := metricPoint.HistogramValue
+		if math.IsNaN(value.Sum) {
+			value.Sum = 0
+		}
+
+		// Start accumulating again if the buckets have changed
+		if !state.Point.HistogramValue.HasSameBounds(value) {
+			restarted := newState(metricPoint)
+			state.StartTimestamp, state.Point = restarted.StartTimestamp, restarted.Point
+			return state.cumulativeValue(), true
+		}
+		state.Point.HistogramValue.Add(value)
+	case pmetric.MetricTypeExponentialHistogram:
+		value := metricPoint.ExpHistogramValue
+		if math.IsNaN(value.Sum) {
+			value.Sum = 0
+		}
+		state.Point.ExpHistogramValue.Add(value)
+	case pmetric.MetricTypeSum:
+		if metricID.IsFloatVal() {
+			state.Point.FloatValue += metricPoint.FloatValue
+		} else {
+			state.Point.IntValue += metricPoint.IntValue
+		}
+	}
+
+	state.Point.ObservedTimestamp = metricPoint.ObservedTimestamp
+	return state.cumulativeValue(), true
+}
+
+// newState creates the state of a stream starting with the point
+func newState(point ValuePoint) *State {
+	start := point.StartTimestamp
+	if start == 0 {
+		start = point.ObservedTimestamp
+	}
+	if point.HistogramValue != nil {
+		histogram := point.HistogramValue.Clone()
+		point.HistogramValue = &histogram
+	}
+	if point.ExpHistogramValue != nil {
+		histogram := point.ExpHistogramValue.Clone()
+		point.ExpHistogramValue = &histogram
+	}
+	return &State{
+		StartTimestamp: start,
+		Point:          point,
+	}
+}
+
+// cumulativeValue returns a copy of the accumulated value, which must be called with the state locked
+func (s *State) cumulativeValue() CumulativeValue {
+	out := CumulativeValue{
+		StartTimestamp: s.StartTimestamp,
+		FloatValue:     s.Point.FloatValue,
+		IntValue:       s.Point.IntValue,
+	}
+	if s.Point.HistogramValue != nil {
+		histogram := s.Point.HistogramValue.Clone()
+		out.HistogramValue = &histogram
+	}
+	if s.Point.ExpHistogramValue != nil {
+		histogram := s.Point.ExpHistogramValue.Clone()
+		out.ExpHistogramValue = &histogram
+	}
+	return out
+}
+
+func (t *MetricTracker) removeStale(staleBefore pcommon.Timestamp) {
+	t.states.Range(func(key, value interface{}) bool {
+		s := value.(*State)
+
+		// As in the cumulativetodelta processor, a point arriving around the
+		// expiration time may update a state which is being removed. The
+		// update is then lost, and the next point starts a new stream.
+		s.Lock()
+		lastObserved := s.Point.ObservedTimestamp
+		s.Unlock()
+		if lastObserved < staleBefore {
+			t.logger.Debug("removing stale state key", zap.String("key", key.(string)))
+			if _, loaded := t.states.LoadAndDelete(key); loaded {
+				t.streams.Dec()
+			}
+		}
+		return true
+	})
+}
+
+func (t *MetricTracker) sweeper(ctx context.Context, remove func(pcommon.Timestamp)) {
+	ticker := time.NewTicker(t.maxStaleness)
+	for {
+		select {
+		case currentTime := <-ticker.C:
+			staleBefore := pcommon.NewTimestampFromTime(currentTime.Add(-t.maxStaleness))
+			remove(staleBefore)
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+func newTestIdentity(metricType pmetric.MetricType, name string) MetricIdentity {
+	return MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             metricType,
+		MetricIsMonotonic:      true,
+		MetricName:             name,
+		Attributes:             pcommon.NewMap(),
+	}
+}
+
+func TestMetricTracker_Accumulate(t *testing.T) {
+	miIntSum := newTestIdentity(pmetric.MetricTypeSum, "int")
+	miIntSum.MetricValueType = pmetric.NumberDataPointValueTypeInt
+	miFloatSum := newTestIdentity(pmetric.MetricTypeSum, "float")
+	miFloatSum.MetricValueType = pmetric.NumberDataPointValueTypeDouble
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+
+	tests := []struct {
+		name       string
+		start      pcommon.Timestamp
+		ts         pcommon.Timestamp
+		intValue   int64
+		floatValue float64
+		wantInt    int64
+		wantFloat  float64
+		wantStart  pcommon.Timestamp
+		wantValid  bool
+	}{
+		{
+			name:       "First point starts the stream",
+			start:      10,
+			ts:         20,
+			intValue:   5,
+			floatValue: 0.5,
+			wantInt:    5,
+			wantFloat:  0.5,
+			wantStart:  10,
+			wantValid:  true,
+		},
+		{
+			name:       "Next point is accumulated",
+			start:      20,
+			ts:         30,
+			intValue:   3,
+			floatValue: 1.5,
+			wantInt:    8,
+			wantFloat:  2,
+			wantStart:  10,
+			wantValid:  true,
+		},
+		{
+			name:       "Point after a gap is accumulated",
+			start:      40,
+			ts:         50,
+			intValue:   2,
+			floatValue: 1,
+			wantInt:    10,
+			wantFloat:  3,
+			wantStart:  10,
+			wantValid:  true,
+		},
+		{
+			name:       "Out of order point is dropped",
+			start:      30,
+			ts:         40,
+			intValue:   100,
+			floatValue: 100,
+		},
+		{
+			name:       "Duplicated point is dropped",
+			start:      40,
+			ts:         50,
+			intValue:   2,
+			floatValue: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intOut, intValid := m.Accumulate(MetricPoint{
+				Identity: miIntSum,
+				Value:    ValuePoint{StartTimestamp: tt.start, ObservedTimestamp: tt.ts, IntValue: tt.intValue},
+			})
+			floatOut, floatValid := m.Accumulate(MetricPoint{
+				Identity: miFloatSum,
+				Value:    ValuePoint{StartTimestamp: tt.start, ObservedTimestamp: tt.ts, FloatValue: tt.floatValue},
+			})
+
+			assert.Equal(t, tt.wantValid, intValid)
+			assert.Equal(t, tt.wantValid, floatValid)
+			if tt.wantValid {
+				assert.Equal(t, tt.wantInt, intOut.IntValue)
+				assert.Equal(t, tt.wantFloat, floatOut.FloatValue)
+				assert.Equal(t, tt.wantStart, intOut.StartTimestamp)
+				assert.Equal(t, tt.wantStart, floatOut.StartTimestamp)
+			}
+		})
+	}
+}
+
+func TestMetricTracker_AccumulateMaxStreams(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 1)
+
+	first := newTestIdentity(pmetric.MetricTypeSum, "first")
+	first.MetricValueType = pmetric.NumberDataPointValueTypeDouble
+	_, valid := m.Accumulate(MetricPoint{Identity: first, Value: ValuePoint{ObservedTimestamp: 10, FloatValue: 1}})
+	assert.True(t, valid)
+
+	second := newTestIdentity(pmetric.MetricTypeSum, "second")
+	second.MetricValueType = pmetric.NumberDataPointValueTypeDouble
+	_, valid = m.Accumulate(MetricPoint{Identity: second, Value: ValuePoint{ObservedTimestamp: 10, FloatValue: 1}})
+	assert.False(t, valid, "the point of a new stream must be dropped once the limit is reached")
+
+	out, valid := m.Accumulate(MetricPoint{Identity: first, Value: ValuePoint{ObservedTimestamp: 20, FloatValue: 2}})
+	assert.True(t, valid, "the tracked streams must still be accumulated")
+	assert.Equal(t, 3.0, out.FloatValue)
+	assert.Equal(t, pcommon.Timestamp(10), out.StartTimestamp)
+
+	// once the first stream is stale, the second one can be tracked
+	m.removeStale(30)
+	_, valid = m.Accumulate(MetricPoint{Identity: second, Value: ValuePoint{ObservedTimestamp: 40, FloatValue: 1}})
+	assert.True(t, valid)
+}
+
+func TestMetricTracker_AccumulateHistogram(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	mi := newTestIdentity(pmetric.MetricTypeHistogram, "histogram")
+
+	accumulate := func(start, ts pcommon.Timestamp, histogram HistogramPoint) (CumulativeValue, bool) {
+		return m.Accumulate(MetricPoint{
+			Identity: mi,
+			Value:    ValuePoint{StartTimestamp: start, ObservedTimestamp: ts, HistogramValue: &histogram},
+		})
+	}
+
+	out, valid := accumulate(10, 20, HistogramPoint{
+		Count: 3, Sum: 6, Min: 1, Max: 3, HasMin: true, HasMax: true, Bounds: []float64{2}, Buckets: []uint64{1, 2},
+	})
+	assert.True(t, valid)
+	assert.Equal(t, &HistogramPoint{
+		Count: 3, Sum: 6, Min: 1, Max: 3, HasMin: true, HasMax: true, Bounds: []float64{2}, Buckets: []uint64{1, 2},
+	}, out.HistogramValue)
+
+	out, valid = accumulate(20, 30, HistogramPoint{
+		Count: 2, Sum: 10, Min: 0.5, Max: 9.5, HasMin: true, Bounds: []float64{2}, Buckets: []uint64{1, 1},
+	})
+	assert.True(t, valid)
+	assert.Equal(t, pcommon.Timestamp(10), out.StartTimestamp)
+	assert.Equal(t, &HistogramPoint{
+		Count: 5, Sum: 16, Min: 0.5, HasMin: true, Bounds: []float64{2}, Buckets: []uint64{2, 3},
+	}, out.HistogramValue)
+
+	// the stream starts again when the bounds change
+	out, valid = accumulate(30, 40, HistogramPoint{
+		Count: 1, Sum: 1, Bounds: []float64{2, 4}, Buckets: []uint64{1, 0, 0},
+	})
+	assert.True(t, valid)
+	assert.Equal(t, pcommon.Timestamp(30), out.StartTimestamp)
+	assert.Equal(t, &HistogramPoint{
+		Count: 1, Sum: 1, Bounds: []float64{2, 4}, Buckets: []uint64{1, 0, 0},
+	}, out.HistogramValue)
+}
+
+func TestMetricTracker_AccumulateExpHistogram(t *testing.T) {
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0, 0)
+	mi := newTestIdentity(pmetric.MetricTypeExponentialHistogram, "exp_histogram")
+
+	accumulate := func(start, ts pcommon.Timestamp, histogram ExpHistogramPoint) (CumulativeValue, bool) {
+		return m.Accumulate(MetricPoint{
+			Identity: mi,
+			Value:    ValuePoint{StartTimestamp: start, ObservedTimestamp: ts, ExpHistogramValue: &histogram},
+		})
+	}
+
+	out, valid := accumulate(10, 20, ExpHistogramPoint{
+		Count: 4, Sum: 10, ZeroCount: 1, Scale: 1,
+		Positive: ExpBuckets{Offset: 2, Counts: []uint64{1, 2}},
+	})
+	assert.True(t, valid)
+	assert.Equal(t, int32(1), out.ExpHistogramValue.Scale)
+
+	// a delta of lower scale reduces the scale of the accumulated buckets
+	out, valid = accumulate(20, 30, ExpHistogramPoint{
+		Count: 3, Sum: 5, ZeroCount: 1, Scale: 0,
+		Positive: ExpBuckets{Offset: 0, Counts: []uint64{1}},
+		Negative: ExpBuckets{Offset: -1, Counts: []uint64{1}},
+	})
+	assert.True(t, valid)
+	assert.Equal(t, pcommon.Timestamp(10), out.StartTimestamp)
+	assert.Equal(t, &ExpHistogramPoint{
+		Count: 7, Sum: 15, ZeroCount: 2, Scale: 0,
+		Positive: ExpBuckets{Offset: 0, Counts: []uint64{1, 3}},
+		Negative: ExpBuckets{Offset: -1, Counts: []uint64{1}},
+	}, out.ExpHistogramValue)
+
+	// a delta of higher scale is reduced to the accumulated scale
+	out, valid = accumulate(30, 40, ExpHistogramPoint{
+		Count: 2, Sum: 20, Scale: 2,
+		Positive: ExpBuckets{Offset: 8, Counts: []uint64{2}},
+	})
+	assert.True(t, valid)
+	assert.Equal(t, &ExpHistogramPoint{
+		Count: 9, Sum: 35, ZeroCount: 2, Scale: 0,
+		Positive: ExpBuckets{Offset: 0, Counts: []uint64{1, 3, 2}},
+		Negative: ExpBuckets{Offset: -1, Counts: []uint64{1}},
+	}, out.ExpHistogramValue)
+}
+
+func TestExpBuckets_Add(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     ExpBuckets
+		b     ExpBuckets
+		wantB ExpBuckets
+	}{
+		{
+			name:  "empty",
+			a:     ExpBuckets{Offset: 3, Counts: []uint64{1}},
+			wantB: ExpBuckets{Offset: 3, Counts: []uint64{1}},
+		},
+		{
+			name:  "overlapping",
+			a:     ExpBuckets{Offset: 0, Counts: []uint64{1, 2, 3}},
+			b:     ExpBuckets{Offset: 1, Counts: []uint64{1, 1}},
+			wantB: ExpBuckets{Offset: 0, Counts: []uint64{1, 3, 4}},
+		},
+		{
+			name:  "disjoint",
+			a:     ExpBuckets{Offset: 5, Counts: []uint64{1}},
+			b:     ExpBuckets{Offset: 2, Counts: []uint64{2}},
+			wantB: ExpBuckets{Offset: 2, Counts: []uint64{2, 0, 0, 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantB, tt.a.Add(tt.b))
+			assert.Equal(t, tt.wantB, tt.b.Add(tt.a))
+		})
+	}
+}
+
+func TestExpHistogramPoint_AddMaxBuckets(t *testing.T) {
+	point := ExpHistogramPoint{Scale: 4, Positive: ExpBuckets{Offset: 0, Counts: []uint64{1}}}
+	point.Add(&ExpHistogramPoint{Scale: 4, Positive: ExpBuckets{Offset: maxExpBuckets, Counts: []uint64{1}}})
+
+	// the 161 buckets are merged into 81 buckets of lower scale
+	wantCounts := make([]uint64, maxExpBuckets/2+1)
+	wantCounts[0], wantCounts[maxExpBuckets/2] = 1, 1
+	assert.Equal(t, int32(3), point.Scale)
+	assert.Equal(t, ExpBuckets{Offset: 0, Counts: wantCounts}, point.Positive)
+}
+
+func TestExpHistogramPoint_AddDistantBuckets(t *testing.T) {
+	tests := []struct {
+		name         string
+		offset       int32
+		deltaOffset  int32
+		wantScale    int32
+		wantPositive ExpBuckets
+	}{
+		{
+			name:         "far apart",
+			offset:       0,
+			deltaOffset:  20_900_000,
+			wantScale:    3,
+			wantPositive: ExpBuckets{Offset: 0, Counts: edgeCounts(160)},
+		},
+		{
+			name:         "whole index range",
+			offset:       math.MinInt32,
+			deltaOffset:  math.MaxInt32,
+			wantScale:    -5,
+			wantPositive: ExpBuckets{Offset: -64, Counts: edgeCounts(128)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			point := ExpHistogramPoint{Scale: 20, Positive: ExpBuckets{Offset: tt.offset, Counts: []uint64{1}}}
+			point.Add(&ExpHistogramPoint{Scale: 20, Positive: ExpBuckets{Offset: tt.deltaOffset, Counts: []uint64{1}}})
+
+			assert.Equal(t, tt.wantScale, point.Scale)
+			assert.Equal(t, tt.wantPositive, point.Positive)
+			assert.LessOrEqual(t, len(point.Positive.Counts), maxExpBuckets)
+		})
+	}
+}
+
+// edgeCounts returns n bucket counts, of which only the first and last are set
+func edgeCounts(n int) []uint64 {
+	counts := make([]uint64, n)
+	counts[0], counts[n-1] = 1, 1
+	return counts
+}
+
+func Test_metricTracker_removeStale(t *testing.T) {
+	currentTime := pcommon.Timestamp(100)
+	freshPoint := ValuePoint{
+		ObservedTimestamp: currentTime,
+	}
+	stalePoint := ValuePoint{
+		ObservedTimestamp: currentTime - 1,
+	}
+
+	tr := &MetricTracker{
+		logger: zap.NewNop(),
+	}
+	tr.states.Store("stale", &State{Point: stalePoint})
+	tr.states.Store("fresh", &State{Point: freshPoint})
+	tr.streams.Store(2)
+
+	tr.removeStale(currentTime)
+
+	gotOut := make(map[string]*State)
+	tr.states.Range(func(key, value interface{}) bool {
+		gotOut[key.(string)] = value.(*State)
+		return true
+	})
+	wantOut := map[string]*State{
+		"fresh": {Point: freshPoint},
+	}
+	if !reflect.DeepEqual(gotOut, wantOut) {
+		t.Errorf("MetricTracker.removeStale() = %v, want %v", gotOut, wantOut)
+	}
+	assert.Equal(t, int64(1), tr.streams.Load())
+}
+
+func Test_metricTracker_sweeper(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sweepEvent := make(chan pcommon.Timestamp)
+	closed := atomic.NewBool(false)
+
+	onSweep := func(staleBefore pcommon.Timestamp) {
+		sweepEvent <- staleBefore
+	}
+
+	tr := &MetricTracker{
+		logger:       zap.NewNop(),
+		maxStaleness: 1 * time.Millisecond,
+	}
+
+	go func() {
+		tr.sweeper(ctx, onSweep)
+		closed.Store(true)
+		close(sweepEvent)
+	}()
+
+	for i := 1; i <= 2; i++ {
+		staleBefore := <-sweepEvent
+		if closed.Load() {
+			t.Fatalf("Sweeper returned prematurely.")
+		}
+		if time.Since(staleBefore.AsTime()) < tr.maxStaleness {
+			t.Errorf("Sweeper called with invalid staleBefore value = %v", staleBefore.AsTime())
+		}
+	}
+	cancel()
+	for range sweepEvent {
+	}
+	if !closed.Load() {
+		t.Errorf("Sweeper did not terminate.")
+	}
+}
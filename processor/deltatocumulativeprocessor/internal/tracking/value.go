@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracking // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// maxExpBuckets is the maximum number of positive or negative buckets of accumulated
+// exponential histograms, above which their scale is reduced
+const maxExpBuckets = 160
+
+type ValuePoint struct {
+	StartTimestamp    pcommon.Timestamp
+	ObservedTimestamp pcommon.Timestamp
+	FloatValue        float64
+	IntValue          int64
+	HistogramValue    *HistogramPoint
+	ExpHistogramValue *ExpHistogramPoint
+}
+
+type HistogramPoint struct {
+	Count   uint64
+	Sum     float64
+	Min     float64
+	Max     float64
+	HasMin  bool
+	HasMax  bool
+	Bounds  []float64
+	Buckets []uint64
+}
+
+func (point *HistogramPoint) Clone() HistogramPoint {
+	bounds := make([]float64, len(point.Bounds))
+	copy(bounds, point.Bounds)
+	bucketValues := make([]uint64, len(point.Buckets))
+	copy(bucketValues, point.Buckets)
+
+	return HistogramPoint{
+		Count:   point.Count,
+		Sum:     point.Sum,
+		Min:     point.Min,
+		Max:     point.Max,
+		HasMin:  point.HasMin,
+		HasMax:  point.HasMax,
+		Bounds:  bounds,
+		Buckets: bucketValues,
+	}
+}
+
+// HasSameBounds returns true if the buckets of the histograms have the same bounds
+func (point *HistogramPoint) HasSameBounds(other *HistogramPoint) bool {
+	if len(point.Bounds) != len(other.Bounds) || len(point.Buckets) != len(other.Buckets) {
+		return false
+	}
+	for i, bound := range point.Bounds {
+		if bound != other.Bounds[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Add adds in place the delta histogram, which must have the same bounds, to the histogram
+func (point *HistogramPoint) Add(delta *HistogramPoint) {
+	point.Count += delta.Count
+	point.Sum += delta.Sum
+	for i, count := range delta.Buckets {
+		point.Buckets[i] += count
+	}
+	point.HasMin, point.Min = addMin(point.HasMin, point.Min, delta.HasMin, delta.Min)
+	point.HasMax, point.Max = addMax(point.HasMax, point.Max, delta.HasMax, delta.Max)
+}
+
+type ExpHistogramPoint struct {
+	Count     uint64
+	Sum       float64
+	Min       float64
+	Max       float64
+	HasMin    bool
+	HasMax    bool
+	ZeroCount uint64
+	Scale     int32
+	Positive  ExpBuckets
+	Negative  ExpBuckets
+}
+
+// ExpBuckets are the buckets of an exponential histogram, the first of which is at index Offset
+type ExpBuckets struct {
+	Offset int32
+	Counts []uint64
+}
+
+func (point *ExpHistogramPoint) Clone() ExpHistogramPoint {
+	return ExpHistogramPoint{
+		Count:     point.Count,
+		Sum:       point.Sum,
+		Min:       point.Min,
+		Max:       point.Max,
+		HasMin:    point.HasMin,
+		HasMax:    point.HasMax,
+		ZeroCount: point.ZeroCount,
+		Scale:     point.Scale,
+		Positive:  point.Positive.Clone(),
+		Negative:  point.Negative.Clone(),
+	}
+}
+
+// Add adds in place the delta exponential histogram to the histogram. Both are reduced to the
+// lowest of their scales, and further until their accumulated buckets fit in the maximum size
+func (point *ExpHistogramPoint) Add(delta *ExpHistogramPoint) {
+	scale := point.Scale
+	if delta.Scale < scale {
+		scale = delta.Scale
+	}
+	// the reduction is computed from the indexes of both histograms before merging their
+	// buckets, so that they are never merged over a wider range than the maximum size
+	scaleDown := mergeScaleDown(point.Positive, point.Scale-scale, delta.Positive, delta.Scale-scale)
+	if negativeScaleDown := mergeScaleDown(point.Negative, point.Scale-scale, delta.Negative, delta.Scale-scale); negativeScaleDown > scaleDown {
+		scaleDown = negativeScaleDown
+	}
+	scale -= scaleDown
+
+	point.downscale(point.Scale - scale)
+	point.Positive = point.Positive.Add(delta.Positive.Downscale(delta.Scale - scale))
+	point.Negative = point.Negative.Add(delta.Negative.Downscale(delta.Scale - scale))
+
+	point.Count += delta.Count
+	point.Sum += delta.Sum
+	point.ZeroCount += delta.ZeroCount
+	point.HasMin, point.Min = addMin(point.HasMin, point.Min, delta.HasMin, delta.Min)
+	point.HasMax, point.Max = addMax(point.HasMax, point.Max, delta.HasMax, delta.Max)
+}
+
+// mergeScaleDown returns by how much the scale of the buckets, once reduced respectively by
+// scaleDown and otherScaleDown to the same scale, must be further reduced for them to fit
+// together in maxExpBuckets buckets
+func mergeScaleDown(buckets ExpBuckets, scaleDown int32, other ExpBuckets, otherScaleDown int32) int32 {
+	if len(buckets.Counts) == 0 && len(other.Counts) == 0 {
+		return 0
+	}
+	if len(buckets.Counts) == 0 {
+		buckets, scaleDown = other, otherScaleDown
+	} else if len(other.Counts) == 0 {
+		other, otherScaleDown = buckets, scaleDown
+	}
+
+	first, last := buckets.indexRange(scaleDown)
+	otherFirst, otherLast := other.indexRange(otherScaleDown)
+	if otherFirst < first {
+		first = otherFirst
+	}
+	if otherLast > last {
+		last = otherLast
+	}
+
+	var further int32
+	for last>>further-first>>further >= maxExpBuckets {
+		further++
+	}
+	return further
+}
+
+// indexRange returns the indexes of the first and last buckets once their scale is reduced
+// by scaleDown, computed on 64 bits so that the range of distant buckets does not overflow
+func (buckets ExpBuckets) indexRange(scaleDown int32) (int64, int64) {
+	first := int64(buckets.Offset)
+	last := first + int64(len(buckets.Counts)) - 1
+	return first >> scaleDown, last >> scaleDown
+}
+
+// addMin returns the minimum of the accumulated and delta minimums,
+// which is known only if both of them are
+func addMin(hasMin bool, min float64, hasDeltaMin bool, deltaMin float64) (bool, float64) {
+	if !hasMin || !hasDeltaMin {
+		return false, 0
+	}
+	return true, math.Min(min, deltaMin)
+}
+
+// addMax returns the maximum of the accumulated and delta maximums,
+// which is known only if both of them are
+func addMax(hasMax bool, max float64, hasDeltaMax bool, deltaMax float64) (bool, float64) {
+	if !hasMax || !hasDeltaMax {
+		return false, 0
+	}
+	return true, math.Max(max, deltaMax)
+}
+
+// downscale reduces the scale of the histogram by scaleDown
+func (point *ExpHistogramPoint) downscale(scaleDown int32) {
+	point.Positive = point.Positive.Downscale(scaleDown)
+	point.Negative = point.Negative.Downscale(scaleDown)
+	point.Scale -= scaleDown
+}
+
+func (buckets ExpBuckets) Clone() ExpBuckets {
+	counts := make([]uint64, len(buckets.Counts))
+	copy(counts, buckets.Counts)
+
+	return ExpBuckets{
+		Offset: buckets.Offset,
+		Counts: counts,
+	}
+}
+
+// Downscale returns the buckets with a scale reduced by scaleDown, whose
+// consecutive buckets are merged
+func (buckets ExpBuckets) Downscale(scaleDown int32) ExpBuckets {
+	if scaleDown == 0 || len(buckets.Counts) == 0 {
+		return buckets.Clone()
+	}
+
+	first, last := buckets.indexRange(scaleDown)
+	offset := int32(first)
+	counts := make([]uint64, last-first+1)
+	for i, count := range buckets.Counts {
+		counts[((buckets.Offset+int32(i))>>scaleDown)-offset] += count
+	}
+
+	return ExpBuckets{
+		Offset: offset,
+		Counts: counts,
+	}
+}
+
+// Add returns the sum of the buckets, which must have the same scale,
+// covering the indexes of both. The caller reduces their scale beforehand
+// for the range of both to fit in the maximum size
+func (buckets ExpBuckets) Add(other ExpBuckets) ExpBuckets {
+	if len(other.Counts) == 0 {
+		return buckets.Clone()
+	}
+	if len(buckets.Counts) == 0 {
+		return other.Clone()
+	}
+
+	first, last := buckets.indexRange(0)
+	otherFirst, otherLast := other.indexRange(0)
+	if otherFirst < first {
+		first = otherFirst
+	}
+	if otherLast > last {
+		last = otherLast
+	}
+
+	offset := int32(first)
+	counts := make([]uint64, last-first+1)
+	for i, count := range buckets.Counts {
+		counts[buckets.Offset+int32(i)-offset] += count
+	}
+	for i, count := range other.Counts {
+		counts[other.Offset+int32(i)-offset] += count
+	}
+
+	return ExpBuckets{
+		Offset: offset,
+		Counts: counts,
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/tracking"
+)
+
+type deltaToCumulativeProcessor struct {
+	includeFS   filterset.FilterSet
+	excludeFS   filterset.FilterSet
+	logger      *zap.Logger
+	accumulator *tracking.MetricTracker
+	cancelFunc  context.CancelFunc
+}
+
+func newDeltaToCumulativeProcessor(config *Config, logger *zap.Logger) *deltaToCumulativeProcessor {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &deltaToCumulativeProcessor{
+		logger:      logger,
+		accumulator: tracking.NewMetricTracker(ctx, logger, config.MaxStaleness, config.MaxStreams),
+		cancelFunc:  cancel,
+	}
+	if len(config.Include.Metrics) > 0 {
+		p.includeFS, _ = filterset.CreateFilterSet(config.Include.Metrics, &config.Include.Config)
+	}
+	if len(config.Exclude.Metrics) > 0 {
+		p.excludeFS, _ = filterset.CreateFilterSet(config.Exclude.Metrics, &config.Exclude.Config)
+	}
+	return p
+}
+
+// processMetrics implements the ProcessMetricsFunc type.
+func (dtcp *deltaToCumulativeProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(ilm pmetric.ScopeMetrics) bool {
+			ilm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				if !dtcp.shouldConvertMetric(m.Name()) {
+					return false
+				}
+				baseIdentity := tracking.MetricIdentity{
+					Resource:               rm.Resource(),
+					InstrumentationLibrary: ilm.Scope(),
+					MetricType:             m.Type(),
+					MetricName:             m.Name(),
+					MetricUnit:             m.Unit(),
+					MetricIsMonotonic:      true,
+				}
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					ms := m.Sum()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+
+					baseIdentity.MetricIsMonotonic = ms.IsMonotonic()
+					dtcp.convertNumberDataPoints(ms.DataPoints(), baseIdentity)
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeHistogram:
+					ms := m.Histogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+
+					dtcp.convertHistogramDataPoints(ms.DataPoints(), baseIdentity)
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+
+					dtcp.convertExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity)
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				default:
+					return false
+				}
+			})
+			return ilm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md, nil
+}
+
+func (dtcp *deltaToCumulativeProcessor) shutdown(context.Context) error {
+	dtcp.cancelFunc()
+	return nil
+}
+
+func (dtcp *deltaToCumulativeProcessor) shouldConvertMetric(metricName string) bool {
+	return (dtcp.includeFS == nil || dtcp.includeFS.Matches(metricName)) &&
+		(dtcp.excludeFS == nil || !dtcp.excludeFS.Matches(metricName))
+}
+
+func (dtcp *deltaToCumulativeProcessor) convertNumberDataPoints(dps pmetric.NumberDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+		id := baseIdentity
+		id.Attributes = dp.Attributes()
+		id.MetricValueType = dp.ValueType()
+		point := tracking.ValuePoint{
+			StartTimestamp:    dp.StartTimestamp(),
+			ObservedTimestamp: dp.Timestamp(),
+		}
+		if id.IsFloatVal() {
+			// Do not attempt to transform NaN values
+			if math.IsNaN(dp.DoubleValue()) {
+				return false
+			}
+			point.FloatValue = dp.DoubleValue()
+		} else {
+			point.IntValue = dp.IntValue()
+		}
+
+		cumulative, valid := dtcp.accumulator.Accumulate(tracking.MetricPoint{
+			Identity: id,
+			Value:    point,
+		})
+		if !valid {
+			return true
+		}
+
+		dp.SetStartTimestamp(cumulative.StartTimestamp)
+		if id.IsFloatVal() {
+			dp.SetDoubleValue(cumulative.FloatValue)
+		} else {
+			dp.SetIntValue(cumulative.IntValue)
+		}
+		return false
+	})
+}
+
+func (dtcp *deltaToCumulativeProcessor) convertHistogramDataPoints(dps pmetric.HistogramDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+		id := baseIdentity
+		id.Attributes = dp.Attributes()
+
+		point := tracking.ValuePoint{
+			StartTimestamp:    dp.StartTimestamp(),
+			ObservedTimestamp: dp.Timestamp(),
+			HistogramValue: &tracking.HistogramPoint{
+				Count:   dp.Count(),
+				Sum:     dp.Sum(),
+				Min:     dp.Min(),
+				Max:     dp.Max(),
+				HasMin:  dp.HasMin(),
+				HasMax:  dp.HasMax(),
+				Bounds:  dp.ExplicitBounds().AsRaw(),
+				Buckets: dp.BucketCounts().AsRaw(),
+			},
+		}
+
+		cumulative, valid := dtcp.accumulator.Accumulate(tracking.MetricPoint{
+			Identity: id,
+			Value:    point,
+		})
+		if !valid {
+			return true
+		}
+
+		dp.SetStartTimestamp(cumulative.StartTimestamp)
+		dp.SetCount(cumulative.HistogramValue.Count)
+		if dp.HasSum() && !math.IsNaN(dp.Sum()) {
+			dp.SetSum(cumulative.HistogramValue.Sum)
+		}
+		dp.BucketCounts().FromRaw(cumulative.HistogramValue.Buckets)
+		if cumulative.HistogramValue.HasMin {
+			dp.SetMin(cumulative.HistogramValue.Min)
+		} else {
+			dp.RemoveMin()
+		}
+		if cumulative.HistogramValue.HasMax {
+			dp.SetMax(cumulative.HistogramValue.Max)
+		} else {
+			dp.RemoveMax()
+		}
+		return false
+	})
+}
+
+func (dtcp *deltaToCumulativeProcessor) convertExponentialHistogramDataPoints(dps pmetric.ExponentialHistogramDataPointSlice, baseIdentity tracking.MetricIdentity) {
+	dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+		id := baseIdentity
+		id.Attributes = dp.Attributes()
+
+		point := tracking.ValuePoint{
+			StartTimestamp:    dp.StartTimestamp(),
+			ObservedTimestamp: dp.Timestamp(),
+			ExpHistogramValue: &tracking.ExpHistogramPoint{
+				Count:     dp.Count(),
+				Sum:       dp.Sum(),
+				Min:       dp.Min(),
+				Max:       dp.Max(),
+				HasMin:    dp.HasMin(),
+				HasMax:    dp.HasMax(),
+				ZeroCount: dp.ZeroCount(),
+				Scale:     dp.Scale(),
+				Positive: tracking.ExpBuckets{
+					Offset: dp.Positive().Offset(),
+					Counts: dp.Positive().BucketCounts().AsRaw(),
+				},
+				Negative: tracking.ExpBuckets{
+					Offset: dp.Negative().Offset(),
+					Counts: dp.Negative().BucketCounts().AsRaw(),
+				},
+			},
+		}
+
+		cumulative, valid := dtcp.accumulator.Accumulate(tracking.MetricPoint{
+			Identity: id,
+			Value:    point,
+		})
+		if !valid {
+			return true
+		}
+
+		dp.SetStartTimestamp(cumulative.StartTimestamp)
+		dp.SetCount(cumulative.ExpHistogramValue.Count)
+		if dp.HasSum() && !math.IsNaN(dp.Sum()) {
+			dp.SetSum(cumulative.ExpHistogramValue.Sum)
+		}
+		dp.SetZeroCount(cumulative.ExpHistogramValue.ZeroCount)
+		dp.SetScale(cumulative.ExpHistogramValue.Scale)
+		dp.Positive().SetOffset(cumulative.ExpHistogramValue.Positive.Offset)
+		dp.Positive().BucketCounts().FromRaw(cumulative.ExpHistogramValue.Positive.Counts)
+		dp.Negative().SetOffset(cumulative.ExpHistogramValue.Negative.Offset)
+		dp.Negative().BucketCounts().FromRaw(cumulative.ExpHistogramValue.Negative.Counts)
+		if cumulative.ExpHistogramValue.HasMin {
+			dp.SetMin(cumulative.ExpHistogramValue.Min)
+		} else {
+			dp.RemoveMin()
+		}
+		if cumulative.ExpHistogramValue.HasMax {
+			dp.SetMax(cumulative.ExpHistogramValue.Max)
+		} else {
+			dp.RemoveMax()
+		}
+		return false
+	})
+}
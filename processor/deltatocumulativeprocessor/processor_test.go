@@ -0,0 +1,628 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+)
+
+// testStartTime is the start of the first delta point of every metric,
+// the following points being reported every 10 seconds.
+var testStartTime = time.Unix(1672531200, 0)
+
+type testSumMetric struct {
+	metricNames  []string
+	metricValues [][]float64
+	// metricTimes are the indexes of the reporting intervals of the points,
+	// which default to consecutive intervals
+	metricTimes [][]int
+	isDelta     []bool
+}
+
+type testHistogramMetric struct {
+	metricNames   []string
+	metricCounts  [][]uint64
+	metricSums    [][]float64
+	metricMins    [][]float64
+	metricMaxes   [][]float64
+	metricBuckets [][][]uint64
+	isDelta       []bool
+}
+
+type testExpHistogramMetric struct {
+	metricNames      []string
+	metricCounts     [][]uint64
+	metricSums       [][]float64
+	metricZeroCounts [][]uint64
+	metricScales     [][]int32
+	metricOffsets    [][]int32
+	metricBuckets    [][][]uint64
+	isDelta          []bool
+}
+
+type deltaToCumulativeTest struct {
+	name       string
+	include    MatchMetrics
+	exclude    MatchMetrics
+	maxStreams int
+	inMetrics  pmetric.Metrics
+	outMetrics pmetric.Metrics
+}
+
+func TestDeltaToCumulativeProcessor(t *testing.T) {
+	testCases := []deltaToCumulativeTest{
+		{
+			name: "delta_to_cumulative_convert_nothing",
+			exclude: MatchMetrics{
+				Metrics: []string{".*"},
+				Config: filterset.Config{
+					MatchType:    "regexp",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 200}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 200}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_one_positive",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 100, 300}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 200, 500}, {4}},
+				isDelta:      []bool{false, true},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_nan_value",
+			include: MatchMetrics{
+				Metrics: []string{"_1"},
+				Config: filterset.Config{
+					MatchType:    "regexp",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 100, math.NaN()}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 200, math.NaN()}, {4}},
+				isDelta:      []bool{false, true},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_exclude_precedence",
+			include: MatchMetrics{
+				Metrics: []string{".*"},
+				Config: filterset.Config{
+					MatchType:    "regexp",
+					RegexpConfig: nil,
+				},
+			},
+			exclude: MatchMetrics{
+				Metrics: []string{".*"},
+				Config: filterset.Config{
+					MatchType:    "regexp",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100}, {4}},
+				isDelta:      []bool{true, true},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_cumulative_passthrough",
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 200, 500}, {4, 1}},
+				isDelta:      []bool{false, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 200, 500}, {4, 5}},
+				isDelta:      []bool{false, false},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_out_of_order",
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1"},
+				metricValues: [][]float64{{100, 200, 50, 200}},
+				metricTimes:  [][]int{{0, 2, 1, 2}},
+				isDelta:      []bool{true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1"},
+				metricValues: [][]float64{{100, 300}},
+				isDelta:      []bool{false},
+			}),
+		},
+		{
+			name:       "delta_to_cumulative_max_streams",
+			maxStreams: 1,
+			inMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricValues: [][]float64{{100, 100}, {4, 4}},
+				isDelta:      []bool{true, true},
+			}),
+			outMetrics: generateTestSumMetrics(testSumMetric{
+				metricNames:  []string{"metric_1"},
+				metricValues: [][]float64{{100, 200}},
+				isDelta:      []bool{false},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_histogram_min_and_max",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricCounts: [][]uint64{{100, 100, 300}, {4}},
+				metricSums:   [][]float64{{100, 100, 300}, {4}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {50, 25, 25}, {150, 75, 75}},
+					{{4, 4, 4}},
+				},
+				metricMins: [][]float64{
+					{5.0, 2.0, 3.0},
+					{2.0},
+				},
+				metricMaxes: [][]float64{
+					{800.0, 825.0, 800.0},
+					{3.0},
+				},
+				isDelta: []bool{true, true},
+			}),
+			outMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricCounts: [][]uint64{{100, 200, 500}, {4}},
+				metricSums:   [][]float64{{100, 200, 500}, {4}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {100, 50, 50}, {250, 125, 125}},
+					{{4, 4, 4}},
+				},
+				metricMins: [][]float64{
+					{5.0, 2.0, 2.0},
+					{2.0},
+				},
+				metricMaxes: [][]float64{
+					{800.0, 825.0, 825.0},
+					{3.0},
+				},
+				isDelta: []bool{false, true},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_histogram_nan_sum",
+			include: MatchMetrics{
+				Metrics: []string{"metric_1"},
+				Config: filterset.Config{
+					MatchType:    "strict",
+					RegexpConfig: nil,
+				},
+			},
+			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricCounts: [][]uint64{{100, 100, 300}, {4}},
+				metricSums:   [][]float64{{100, math.NaN(), 300}, {4}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {50, 25, 25}, {150, 75, 75}},
+					{{4, 4, 4}},
+				},
+				isDelta: []bool{true, true},
+			}),
+			outMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1", "metric_2"},
+				metricCounts: [][]uint64{{100, 200, 500}, {4}},
+				metricSums:   [][]float64{{100, math.NaN(), 400}, {4}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {100, 50, 50}, {250, 125, 125}},
+					{{4, 4, 4}},
+				},
+				isDelta: []bool{false, true},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_histogram_changed_buckets",
+			inMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1"},
+				metricCounts: [][]uint64{{100, 100, 4}},
+				metricSums:   [][]float64{{100, 100, 4}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {50, 25, 25}, {2, 2}},
+				},
+				isDelta: []bool{true},
+			}),
+			outMetrics: generateTestHistogramMetrics(testHistogramMetric{
+				metricNames:  []string{"metric_1"},
+				metricCounts: [][]uint64{{100, 200, 4}},
+				metricSums:   [][]float64{{100, 200, 4}},
+				metricBuckets: [][][]uint64{
+					{{50, 25, 25}, {100, 50, 50}, {2, 2}},
+				},
+				isDelta: []bool{false},
+			}),
+		},
+		{
+			name: "delta_to_cumulative_exponential_histogram",
+			inMetrics: generateTestExpHistogramMetrics(testExpHistogramMetric{
+				metricNames:      []string{"metric_1", "metric_2"},
+				metricCounts:     [][]uint64{{4, 8, 3}, {4}},
+				metricSums:       [][]float64{{10, 20, 8}, {5}},
+				metricZeroCounts: [][]uint64{{1, 1, 0}, {0}},
+				metricScales:     [][]int32{{2, 2, 1}, {1}},
+				metricOffsets:    [][]int32{{0, 1, 0}, {0}},
+				metricBuckets:    [][][]uint64{{{1, 2}, {3, 4}, {2, 1}}, {{4}}},
+				isDelta:          []bool{true, false},
+			}),
+			outMetrics: generateTestExpHistogramMetrics(testExpHistogramMetric{
+				metricNames:      []string{"metric_1", "metric_2"},
+				metricCounts:     [][]uint64{{4, 12, 15}, {4}},
+				metricSums:       [][]float64{{10, 30, 38}, {5}},
+				metricZeroCounts: [][]uint64{{1, 2, 2}, {0}},
+				metricScales:     [][]int32{{2, 2, 1}, {1}},
+				metricOffsets:    [][]int32{{0, 0, 0}, {0}},
+				metricBuckets:    [][][]uint64{{{1, 2}, {1, 5, 4}, {8, 5}}, {{4}}},
+				isDelta:          []bool{false, false},
+			}),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// next stores the results of the delta to cumulative processor
+			next := new(consumertest.MetricsSink)
+			cfg := &Config{
+				Include:    test.include,
+				Exclude:    test.exclude,
+				MaxStreams: test.maxStreams,
+			}
+			factory := NewFactory()
+			mgp, err := factory.CreateMetricsProcessor(
+				context.Background(),
+				processortest.NewNopCreateSettings(),
+				cfg,
+				next,
+			)
+			assert.NotNil(t, mgp)
+			assert.Nil(t, err)
+
+			caps := mgp.Capabilities()
+			assert.True(t, caps.MutatesData)
+			ctx := context.Background()
+			require.NoError(t, mgp.Start(ctx, nil))
+
+			cErr := mgp.ConsumeMetrics(context.Background(), test.inMetrics)
+			assert.Nil(t, cErr)
+			got := next.AllMetrics()
+
+			require.Equal(t, 1, len(got))
+			require.Equal(t, test.outMetrics.ResourceMetrics().Len(), got[0].ResourceMetrics().Len())
+
+			expectedMetrics := test.outMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			actualMetrics := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+
+			require.Equal(t, expectedMetrics.Len(), actualMetrics.Len())
+
+			for i := 0; i < expectedMetrics.Len(); i++ {
+				eM := expectedMetrics.At(i)
+				aM := actualMetrics.At(i)
+
+				require.Equal(t, eM.Name(), aM.Name())
+
+				if eM.Type() == pmetric.MetricTypeSum {
+					eDataPoints := eM.Sum().DataPoints()
+					aDataPoints := aM.Sum().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.Sum().AggregationTemporality(), aM.Sum().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						if math.IsNaN(eDataPoints.At(j).DoubleValue()) {
+							assert.True(t, math.IsNaN(aDataPoints.At(j).DoubleValue()))
+						} else {
+							require.Equal(t, eDataPoints.At(j).DoubleValue(), aDataPoints.At(j).DoubleValue())
+						}
+					}
+				}
+
+				if eM.Type() == pmetric.MetricTypeHistogram {
+					eDataPoints := eM.Histogram().DataPoints()
+					aDataPoints := aM.Histogram().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.Histogram().AggregationTemporality(), aM.Histogram().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).Count(), aDataPoints.At(j).Count())
+						require.Equal(t, eDataPoints.At(j).HasSum(), aDataPoints.At(j).HasSum())
+						require.Equal(t, eDataPoints.At(j).HasMin(), aDataPoints.At(j).HasMin())
+						require.Equal(t, eDataPoints.At(j).HasMax(), aDataPoints.At(j).HasMax())
+						require.Equal(t, eDataPoints.At(j).Min(), aDataPoints.At(j).Min())
+						require.Equal(t, eDataPoints.At(j).Max(), aDataPoints.At(j).Max())
+						if math.IsNaN(eDataPoints.At(j).Sum()) {
+							require.True(t, math.IsNaN(aDataPoints.At(j).Sum()))
+						} else {
+							require.Equal(t, eDataPoints.At(j).Sum(), aDataPoints.At(j).Sum())
+						}
+						require.Equal(t, eDataPoints.At(j).BucketCounts(), aDataPoints.At(j).BucketCounts())
+					}
+				}
+
+				if eM.Type() == pmetric.MetricTypeExponentialHistogram {
+					eDataPoints := eM.ExponentialHistogram().DataPoints()
+					aDataPoints := aM.ExponentialHistogram().DataPoints()
+
+					require.Equal(t, eDataPoints.Len(), aDataPoints.Len())
+					require.Equal(t, eM.ExponentialHistogram().AggregationTemporality(), aM.ExponentialHistogram().AggregationTemporality())
+
+					for j := 0; j < eDataPoints.Len(); j++ {
+						require.Equal(t, eDataPoints.At(j).Count(), aDataPoints.At(j).Count())
+						require.Equal(t, eDataPoints.At(j).Sum(), aDataPoints.At(j).Sum())
+						require.Equal(t, eDataPoints.At(j).ZeroCount(), aDataPoints.At(j).ZeroCount())
+						require.Equal(t, eDataPoints.At(j).Scale(), aDataPoints.At(j).Scale())
+						require.Equal(t, eDataPoints.At(j).Positive().Offset(), aDataPoints.At(j).Positive().Offset())
+						require.Equal(t, eDataPoints.At(j).Positive().BucketCounts(), aDataPoints.At(j).Positive().BucketCounts())
+					}
+				}
+			}
+
+			require.NoError(t, mgp.Shutdown(ctx))
+		})
+	}
+}
+
+func TestDeltaToCumulativeProcessorStartTimestamp(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	factory := NewFactory()
+	mgp, err := factory.CreateMetricsProcessor(
+		context.Background(),
+		processortest.NewNopCreateSettings(),
+		factory.CreateDefaultConfig(),
+		next,
+	)
+	require.NoError(t, err)
+	require.NoError(t, mgp.Start(context.Background(), nil))
+	defer func() {
+		require.NoError(t, mgp.Shutdown(context.Background()))
+	}()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, mgp.ConsumeMetrics(context.Background(), generateTestSumMetrics(testSumMetric{
+			metricNames:  []string{"metric_1"},
+			metricValues: [][]float64{{10}},
+			metricTimes:  [][]int{{i}},
+			isDelta:      []bool{true},
+		})))
+	}
+
+	got := next.AllMetrics()
+	require.Equal(t, 3, len(got))
+	for i, md := range got {
+		dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+		assert.Equal(t, pcommon.NewTimestampFromTime(testStartTime), dp.StartTimestamp())
+		assert.Equal(t, pcommon.NewTimestampFromTime(testStartTime.Add(time.Duration(i+1)*10*time.Second)), dp.Timestamp())
+		assert.Equal(t, float64(10*(i+1)), dp.DoubleValue())
+	}
+}
+
+// setTestTimestamps sets the timestamps of a point reported in the interval of index i
+func setTestTimestamps(i int, setStart, setTimestamp func(pcommon.Timestamp)) {
+	setStart(pcommon.NewTimestampFromTime(testStartTime.Add(time.Duration(i) * 10 * time.Second)))
+	setTimestamp(pcommon.NewTimestampFromTime(testStartTime.Add(time.Duration(i+1) * 10 * time.Second)))
+}
+
+func generateTestSumMetrics(tm testSumMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+
+		if tm.isDelta[i] {
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		} else {
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		}
+
+		for index, value := range tm.metricValues[i] {
+			dp := m.Sum().DataPoints().AppendEmpty()
+			interval := index
+			if tm.metricTimes != nil {
+				interval = tm.metricTimes[i][index]
+			}
+			setTestTimestamps(interval, dp.SetStartTimestamp, dp.SetTimestamp)
+			dp.SetDoubleValue(value)
+		}
+	}
+
+	return md
+}
+
+func generateTestHistogramMetrics(tm testHistogramMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		hist := m.SetEmptyHistogram()
+
+		if tm.isDelta[i] {
+			hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		} else {
+			hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		}
+
+		for index, count := range tm.metricCounts[i] {
+			dp := m.Histogram().DataPoints().AppendEmpty()
+			setTestTimestamps(index, dp.SetStartTimestamp, dp.SetTimestamp)
+			dp.SetCount(count)
+
+			sums := tm.metricSums[i]
+			if len(sums) > 0 {
+				dp.SetSum(sums[index])
+			}
+			if tm.metricMins != nil {
+				mins := tm.metricMins[i]
+				if len(mins) > 0 {
+					dp.SetMin(mins[index])
+				}
+			}
+			if tm.metricMaxes != nil {
+				maxes := tm.metricMaxes[i]
+				if len(maxes) > 0 {
+					dp.SetMax(maxes[index])
+				}
+			}
+			buckets := tm.metricBuckets[i][index]
+			for b := 1; b < len(buckets); b++ {
+				dp.ExplicitBounds().Append(float64(b))
+			}
+			dp.BucketCounts().FromRaw(buckets)
+		}
+	}
+
+	return md
+}
+
+func generateTestExpHistogramMetrics(tm testExpHistogramMetric) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for i, name := range tm.metricNames {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		hist := m.SetEmptyExponentialHistogram()
+
+		if tm.isDelta[i] {
+			hist.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		} else {
+			hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		}
+
+		for index, count := range tm.metricCounts[i] {
+			dp := hist.DataPoints().AppendEmpty()
+			setTestTimestamps(index, dp.SetStartTimestamp, dp.SetTimestamp)
+			dp.SetCount(count)
+			dp.SetSum(tm.metricSums[i][index])
+			dp.SetZeroCount(tm.metricZeroCounts[i][index])
+			dp.SetScale(tm.metricScales[i][index])
+			dp.Positive().SetOffset(tm.metricOffsets[i][index])
+			dp.Positive().BucketCounts().FromRaw(tm.metricBuckets[i][index])
+		}
+	}
+
+	return md
+}
+
+func BenchmarkConsumeMetrics(b *testing.B) {
+	c := consumertest.NewNop()
+	params := processor.CreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zap.NewNop(),
+		},
+		BuildInfo: component.BuildInfo{},
+	}
+	cfg := createDefaultConfig().(*Config)
+	p, err := createMetricsProcessor(context.Background(), params, cfg, c)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	metrics := pmetric.NewMetrics()
+	rms := metrics.ResourceMetrics().AppendEmpty()
+	r := rms.Resource()
+	r.Attributes().PutBool("resource", true)
+	ilms := rms.ScopeMetrics().AppendEmpty()
+	ilms.Scope().SetName("test")
+	ilms.Scope().SetVersion("0.1")
+	m := ilms.Metrics().AppendEmpty()
+	m.SetEmptySum().SetIsMonotonic(true)
+	dp := m.Sum().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("tag", "value")
+
+	var interval int
+	reset := func() {
+		m.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		setTestTimestamps(interval, dp.SetStartTimestamp, dp.SetTimestamp)
+		dp.SetDoubleValue(100.0)
+		interval++
+	}
+
+	// Load initial value
+	reset()
+	assert.NoError(b, p.ConsumeMetrics(context.Background(), metrics))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		reset()
+		assert.NoError(b, p.ConsumeMetrics(context.Background(), metrics))
+	}
+}
@@ -16,6 +16,8 @@ package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config is the configuration for the processor.
@@ -40,8 +42,13 @@ type Config struct {
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
 	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
-	// Useful when the duration to wait for traces to complete is high.
+	// Useful when the duration to wait for traces to complete is high. The traces which haven't been released
+	// before a shutdown are released after the processor is restarted.
+	// Requires a storage extension to be set with Storage.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// Storage is the ID of the storage extension, such as the file storage, used to hold the traces when
+	// StoreOnDisk is enabled.
+	Storage *component.ID `mapstructure:"storage"`
 }
@@ -39,7 +39,7 @@ const (
 )
 
 var (
-	errDiskStorageNotSupported    = fmt.Errorf("option 'disk storage' not supported in this release")
+	errMissingStorageExtension    = fmt.Errorf("option 'store_on_disk' requires a storage extension to be set in 'storage'")
 	errDiscardOrphansNotSupported = fmt.Errorf("option 'discard orphans' not supported in this release")
 )
 
@@ -60,10 +60,10 @@ func createDefaultConfig() component.Config {
 		NumTraces:    defaultNumTraces,
 		NumWorkers:   defaultNumWorkers,
 		WaitDuration: defaultWaitDuration,
+		StoreOnDisk:  defaultStoreOnDisk,
 
 		// not supported for now
 		DiscardOrphans: defaultDiscardOrphans,
-		StoreOnDisk:    defaultStoreOnDisk,
 	}
 }
 
@@ -76,16 +76,19 @@ func createTracesProcessor(
 
 	oCfg := cfg.(*Config)
 
-	var st storage
-	if oCfg.StoreOnDisk {
-		return nil, errDiskStorageNotSupported
-	}
 	if oCfg.DiscardOrphans {
 		return nil, errDiscardOrphansNotSupported
 	}
 
-	// the only supported storage for now
-	st = newMemoryStorage()
+	var st storage
+	if oCfg.StoreOnDisk {
+		if oCfg.Storage == nil {
+			return nil, errMissingStorageExtension
+		}
+		st = newPersistentStorage(*oCfg.Storage, params.ID)
+	} else {
+		st = newMemoryStorage()
+	}
 
 	return newGroupByTraceProcessor(params.Logger, st, nextConsumer, *oCfg), nil
 }
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/processor/processortest"
 )
 
@@ -47,6 +48,22 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
+func TestCreateTestProcessorWithStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	storageID := component.NewID("file_storage")
+	c.StoreOnDisk = true
+	c.Storage = &storageID
+
+	next := &mockProcessor{}
+
+	// test
+	p, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), c, next)
+
+	// verify
+	assert.NoError(t, err)
+	assert.IsType(t, &persistentStorage{}, p.(*groupByTraceProcessor).st)
+}
+
 func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 	// prepare
 	f := NewFactory()
@@ -67,7 +84,7 @@ func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
 			&Config{
 				StoreOnDisk: true,
 			},
-			errDiskStorageNotSupported,
+			errMissingStorageExtension,
 		},
 	} {
 		p, err := f.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), tt.config, next)
@@ -3,6 +3,7 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/processor/group
 go 1.18
 
 require (
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage v0.69.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal v0.69.0
 	github.com/stretchr/testify v1.8.1
 	go.opencensus.io v0.24.0
@@ -47,4 +48,6 @@ require (
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal => ../../pkg/batchpersignal
 
+replace github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage => ../../extension/storage
+
 retract v0.65.0
@@ -94,14 +94,36 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	return sp.resumeRecoveredTraces()
+}
+
+// resumeRecoveredTraces places the traces left in the storage by a previous run back into the event machine,
+// so that they are released once the wait duration expires
+func (sp *groupByTraceProcessor) resumeRecoveredTraces() error {
+	traces, err := sp.st.recover()
+	if err != nil {
+		return fmt.Errorf("couldn't recover the traces from the storage: %w", err)
+	}
+	if len(traces) == 0 {
+		return nil
+	}
+
+	sp.logger.Info("resuming the traces recovered from the storage", zap.Int("traces", len(traces)))
+	var errs error
+	for _, trace := range traces {
+		errs = multierr.Append(errs, sp.eventMachine.consume(trace))
+	}
+	return errs
 }
 
 // Shutdown is invoked during service shutdown.
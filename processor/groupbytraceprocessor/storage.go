@@ -15,6 +15,9 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
@@ -36,7 +39,11 @@ type storage interface {
 	delete(pcommon.TraceID) ([]ptrace.ResourceSpans, error)
 
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(ctx context.Context, host component.Host) error
+
+	// recover removes and returns the traces left in the storage by a previous run of the processor,
+	// so that they can be released again. Storages which don't persist traces return nil.
+	recover() ([]ptrace.Traces, error)
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	storageext "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// traceKeyPrefix is the prefix of the keys holding the batches of spans of each trace
+	traceKeyPrefix = "trace_"
+	// traceIDsKey is the key holding the IDs of all the traces in the storage
+	traceIDsKey = "trace_ids"
+)
+
+// persistentStorage holds the traces in a storage extension, such as the file storage, so that the traces
+// which haven't been released yet survive a restart. Each batch of spans is stored under its own key, and
+// the IDs of the traces are written along with the first batch of a trace and its removal, so that the
+// traces can be recovered on start, even if the collector didn't shut down cleanly. Only the trace IDs,
+// with the number of batches of each trace, are kept in memory.
+type persistentStorage struct {
+	sync.Mutex
+	storageID   component.ID
+	processorID component.ID
+	client      storageext.Client
+
+	// the number of batches of each trace in the storage
+	traceIDs map[pcommon.TraceID]int
+
+	marshaler   ptrace.Marshaler
+	unmarshaler ptrace.Unmarshaler
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+var _ storage = (*persistentStorage)(nil)
+
+func newPersistentStorage(storageID component.ID, processorID component.ID) *persistentStorage {
+	return &persistentStorage{
+		storageID:                 storageID,
+		processorID:               processorID,
+		traceIDs:                  make(map[pcommon.TraceID]int),
+		marshaler:                 &ptrace.ProtoMarshaler{},
+		unmarshaler:               &ptrace.ProtoUnmarshaler{},
+		metricsCollectionInterval: time.Second,
+	}
+}
+
+func (st *persistentStorage) createOrAppend(traceID pcommon.TraceID, td ptrace.Traces) error {
+	st.Lock()
+	defer st.Unlock()
+
+	data, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal trace: %w", err)
+	}
+
+	batches, ok := st.traceIDs[traceID]
+	ops := []storageext.Operation{storageext.SetOperation(batchKey(traceID, batches), data)}
+	if !ok {
+		// the trace IDs are updated in the same transaction, so that the trace can always be recovered
+		st.traceIDs[traceID] = 0
+		ops = append(ops, storageext.SetOperation(traceIDsKey, st.encodeTraceIDs()))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		if !ok {
+			delete(st.traceIDs, traceID)
+		}
+		return err
+	}
+	st.traceIDs[traceID] = batches + 1
+
+	return nil
+}
+
+func (st *persistentStorage) get(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	trace, err := st.load(traceID)
+	if err != nil || trace == nil {
+		return nil, err
+	}
+	return resourceSpansOf(*trace), nil
+}
+
+func (st *persistentStorage) delete(traceID pcommon.TraceID) ([]ptrace.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	trace, err := st.remove(traceID)
+	if err != nil || trace == nil {
+		return nil, err
+	}
+	return resourceSpansOf(*trace), nil
+}
+
+func (st *persistentStorage) start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[st.storageID]
+	if !ok {
+		return fmt.Errorf("storage extension '%s' not found", st.storageID)
+	}
+	storageExt, ok := ext.(storageext.Extension)
+	if !ok {
+		return fmt.Errorf("non-storage extension '%s' found", st.storageID)
+	}
+	client, err := storageExt.GetClient(ctx, component.KindProcessor, st.processorID, "")
+	if err != nil {
+		return fmt.Errorf("couldn't get the storage client: %w", err)
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	st.client = client
+	data, err := client.Get(ctx, traceIDsKey)
+	if err != nil {
+		return fmt.Errorf("couldn't retrieve the trace IDs from the storage: %w", err)
+	}
+	for i := 0; i+len(pcommon.TraceID{}) <= len(data); i += len(pcommon.TraceID{}) {
+		var traceID pcommon.TraceID
+		copy(traceID[:], data[i:])
+		batches, err := st.countBatches(ctx, traceID)
+		if err != nil {
+			return fmt.Errorf("couldn't retrieve the trace %q from the storage: %w", traceID, err)
+		}
+		st.traceIDs[traceID] = batches
+	}
+
+	go st.periodicMetrics()
+	return nil
+}
+
+func (st *persistentStorage) recover() ([]ptrace.Traces, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	var traces []ptrace.Traces
+	for traceID := range st.traceIDs {
+		trace, err := st.remove(traceID)
+		if err != nil {
+			return nil, err
+		}
+		if trace != nil {
+			traces = append(traces, *trace)
+		}
+	}
+	return traces, nil
+}
+
+func (st *persistentStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	st.stopped = true
+	st.stoppedLock.Unlock()
+
+	st.Lock()
+	defer st.Unlock()
+
+	if st.client == nil {
+		return nil
+	}
+	return st.client.Close(context.Background())
+}
+
+// load retrieves the batches of the trace from the storage, returning nil if it can't be found
+func (st *persistentStorage) load(traceID pcommon.TraceID) (*ptrace.Traces, error) {
+	batches, ok := st.traceIDs[traceID]
+	if !ok {
+		return nil, nil
+	}
+
+	ops := make([]storageext.Operation, 0, batches)
+	for i := 0; i < batches; i++ {
+		ops = append(ops, storageext.GetOperation(batchKey(traceID, i)))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+
+	trace := ptrace.NewTraces()
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+		batch, err := st.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal trace %q: %w", traceID, err)
+		}
+		batch.ResourceSpans().MoveAndAppendTo(trace.ResourceSpans())
+	}
+	return &trace, nil
+}
+
+// remove deletes the trace from the storage, returning it or nil if it can't be found
+func (st *persistentStorage) remove(traceID pcommon.TraceID) (*ptrace.Traces, error) {
+	trace, err := st.load(traceID)
+	if err != nil || trace == nil {
+		return nil, err
+	}
+
+	batches := st.traceIDs[traceID]
+	delete(st.traceIDs, traceID)
+	ops := make([]storageext.Operation, 0, batches+1)
+	for i := 0; i < batches; i++ {
+		ops = append(ops, storageext.DeleteOperation(batchKey(traceID, i)))
+	}
+	ops = append(ops, storageext.SetOperation(traceIDsKey, st.encodeTraceIDs()))
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		st.traceIDs[traceID] = batches
+		return nil, err
+	}
+	return trace, nil
+}
+
+// countBatches returns the number of batches of the trace in the storage
+func (st *persistentStorage) countBatches(ctx context.Context, traceID pcommon.TraceID) (int, error) {
+	batches := 0
+	for {
+		data, err := st.client.Get(ctx, batchKey(traceID, batches))
+		if err != nil {
+			return 0, err
+		}
+		if data == nil {
+			return batches, nil
+		}
+		batches++
+	}
+}
+
+// encodeTraceIDs returns the IDs of the traces in the storage, as stored under traceIDsKey
+func (st *persistentStorage) encodeTraceIDs() []byte {
+	data := make([]byte, 0, len(st.traceIDs)*len(pcommon.TraceID{}))
+	for traceID := range st.traceIDs {
+		data = append(data, traceID[:]...)
+	}
+	return data
+}
+
+func (st *persistentStorage) periodicMetrics() {
+	numTraces := st.count()
+	stats.Record(context.Background(), mNumTracesInMemory.M(int64(numTraces)))
+
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func (st *persistentStorage) count() int {
+	st.Lock()
+	defer st.Unlock()
+	return len(st.traceIDs)
+}
+
+// batchKey returns the key of the batch of spans of the trace with the given index
+func batchKey(traceID pcommon.TraceID, index int) string {
+	return traceKeyPrefix + hex.EncodeToString(traceID[:]) + "_" + strconv.Itoa(index)
+}
+
+func resourceSpansOf(trace ptrace.Traces) []ptrace.ResourceSpans {
+	rss := trace.ResourceSpans()
+	result := make([]ptrace.ResourceSpans, 0, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		result = append(result, rss.At(i))
+	}
+	return result
+}
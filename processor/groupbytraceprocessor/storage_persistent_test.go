@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	storageext "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newTestPersistentStorage(t *testing.T, host component.Host) *persistentStorage {
+	st := newPersistentStorage(storagetest.NewStorageID("test"), component.NewID(typeStr))
+	require.NoError(t, st.start(context.Background(), host))
+	return st
+}
+
+func TestPersistentCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+	first.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("first")
+	second := simpleTracesWithID(traceID)
+	second.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("second")
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, first))
+	require.NoError(t, st.createOrAppend(traceID, second))
+
+	// verify
+	assert.Equal(t, 1, st.count())
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assert.Equal(t, first.ResourceSpans().At(0), retrieved[0])
+	assert.Equal(t, second.ResourceSpans().At(0), retrieved[1])
+
+	missing, err := st.get(pcommon.TraceID([16]byte{2, 3, 4, 5}))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestPersistentDeleteTrace(t *testing.T) {
+	// prepare
+	st := newTestPersistentStorage(t, storagetest.NewStorageHost().WithInMemoryStorageExtension("test"))
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Len(t, deleted, 1)
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+
+	deleted, err = st.delete(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, deleted)
+}
+
+func TestPersistentRecoverTraces(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	st := newTestPersistentStorage(t, host)
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+		pcommon.TraceID([16]byte{3, 4, 5, 6}),
+	}
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+	// released before the shutdown
+	_, err := st.delete(traceIDs[2])
+	require.NoError(t, err)
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newTestPersistentStorage(t, host)
+	recovered, err := st.recover()
+
+	// verify
+	require.NoError(t, err)
+	var recoveredIDs []pcommon.TraceID
+	for _, trace := range recovered {
+		traceID, err := getTraceID(trace)
+		require.NoError(t, err)
+		recoveredIDs = append(recoveredIDs, traceID)
+	}
+	assert.ElementsMatch(t, traceIDs[:2], recoveredIDs)
+
+	// the recovered traces are removed from the storage
+	assert.Equal(t, 0, st.count())
+	recovered, err = st.recover()
+	require.NoError(t, err)
+	assert.Empty(t, recovered)
+	require.NoError(t, st.shutdown())
+}
+
+// crashStorage is a storage extension handing out the same client to all the components, so that
+// a component can be restarted without being shut down, as if the collector had crashed
+type crashStorage struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client storageext.Client
+}
+
+func (s *crashStorage) GetClient(context.Context, component.Kind, component.ID, string) (storageext.Client, error) {
+	return s.client, nil
+}
+
+func TestPersistentRecoverTracesAfterCrash(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithExtension(storagetest.NewStorageID("test"), &crashStorage{
+		client: storagetest.NewInMemoryClient(component.KindProcessor, component.NewID(typeStr), ""),
+	})
+	st := newTestPersistentStorage(t, host)
+
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+	}
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+	require.NoError(t, st.createOrAppend(traceIDs[0], simpleTracesWithID(traceIDs[0])))
+
+	// test
+	st = newTestPersistentStorage(t, host)
+	defer func() {
+		assert.NoError(t, st.shutdown())
+	}()
+	recovered, err := st.recover()
+
+	// verify
+	require.NoError(t, err)
+	spans := make(map[pcommon.TraceID]int)
+	for _, trace := range recovered {
+		traceID, err := getTraceID(trace)
+		require.NoError(t, err)
+		spans[traceID] = trace.SpanCount()
+	}
+	assert.Equal(t, map[pcommon.TraceID]int{traceIDs[0]: 2, traceIDs[1]: 1}, spans)
+	assert.Equal(t, 0, st.count())
+}
+
+func TestPersistentStorageExtensionErrors(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		host component.Host
+		err  string
+	}{
+		{
+			"missing extension",
+			storagetest.NewStorageHost(),
+			"storage extension 'test_storage/test' not found",
+		},
+		{
+			"non-storage extension",
+			storagetest.NewStorageHost().WithExtension(storagetest.NewStorageID("test"), storagetest.NewNonStorageExtension("test")),
+			"non-storage extension 'test_storage/test' found",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			st := newPersistentStorage(storagetest.NewStorageID("test"), component.NewID(typeStr))
+
+			// test
+			err := st.start(context.Background(), tt.host)
+
+			// verify
+			assert.EqualError(t, err, tt.err)
+			assert.NoError(t, st.shutdown())
+		})
+	}
+}
+
+func TestTracesReleasedAfterRestart(t *testing.T) {
+	// prepare
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+	}
+	traceIDs := []pcommon.TraceID{
+		pcommon.TraceID([16]byte{1, 2, 3, 4}),
+		pcommon.TraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	ctx := context.Background()
+	st := newPersistentStorage(storagetest.NewStorageID("test"), component.NewID(typeStr))
+	p := newGroupByTraceProcessor(zap.NewNop(), st, &mockProcessor{}, config)
+	require.NoError(t, p.Start(ctx, host))
+	for _, traceID := range traceIDs {
+		require.NoError(t, p.ConsumeTraces(ctx, simpleTracesWithID(traceID)))
+	}
+	assert.Eventually(t, func() bool {
+		return st.count() == len(traceIDs)
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(ctx))
+
+	var mu sync.Mutex
+	var released []pcommon.TraceID
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, td ptrace.Traces) error {
+			traceID, err := getTraceID(td)
+			require.NoError(t, err)
+			mu.Lock()
+			defer mu.Unlock()
+			released = append(released, traceID)
+			return nil
+		},
+	}
+
+	// test
+	config.WaitDuration = time.Millisecond
+	st = newPersistentStorage(storagetest.NewStorageID("test"), component.NewID(typeStr))
+	p = newGroupByTraceProcessor(zap.NewNop(), st, next, config)
+	require.NoError(t, p.Start(ctx, host))
+	defer func() {
+		assert.NoError(t, p.Shutdown(ctx))
+	}()
+
+	// verify
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return assert.ObjectsAreEqual(len(traceIDs), len(released))
+	}, time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.ElementsMatch(t, traceIDs, released)
+}
@@ -38,6 +38,12 @@ type Config struct {
 	// block processing of signals. (Optional field)
 	Prefetch []string `mapstructure:"prefetch"`
 
+	// SchemaFiles is a list of local schema files that are
+	// loaded at start instead of being fetched from their
+	// published schema URL. Remote schema files are only
+	// fetched for versions not covered by these. (Optional field)
+	SchemaFiles []string `mapstructure:"schema_files"`
+
 	// Targets define what schema families should be
 	// translated to, allowing older and newer formats
 	// to conform to the target schema identifier.
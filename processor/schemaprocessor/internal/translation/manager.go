@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// retryInterval is the amount of time to wait before
+// attempting to fetch a schema file that previously failed
+// so that unreachable schemas don't stall every batch processed.
+const retryInterval = time.Minute
+
+var ErrNotSupported = errors.New("version not supported by schema")
+
+// Manager caches the translations of every schema family
+// and fetches new schema files when a requested version
+// is not yet known.
+type Manager struct {
+	log      *zap.Logger
+	provider Provider
+	now      func() time.Time
+
+	mu           sync.Mutex
+	translations map[string]*Translation
+	failures     map[string]time.Time
+	pending      map[string]*pendingFetch
+}
+
+// pendingFetch is a fetch of a schema file in progress
+// that is shared by the requests of the same schema URL.
+type pendingFetch struct {
+	done chan struct{}
+	t    *Translation
+	err  error
+}
+
+// NewManager returns a manager that uses provider
+// to retrieve schema files that are not cached.
+func NewManager(provider Provider, log *zap.Logger) *Manager {
+	return &Manager{
+		log:          log,
+		provider:     provider,
+		now:          time.Now,
+		translations: make(map[string]*Translation),
+		failures:     make(map[string]time.Time),
+		pending:      make(map[string]*pendingFetch),
+	}
+}
+
+// Register stores the translation so that it can be used for its family,
+// a translation is only replaced by a translation of a newer version
+// since newer schema files contain all the previous versions.
+func (m *Manager) Register(t *Translation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.register(t)
+}
+
+// Prefetch downloads and caches the schema file published at schemaURL.
+func (m *Manager) Prefetch(ctx context.Context, schemaURL string) error {
+	_, err := m.fetchShared(ctx, schemaURL)
+	return err
+}
+
+// RequestTranslation returns a translation of the family that is able
+// to convert signals between the two versions, fetching the schema
+// file of the most recent version of the two if required.
+func (m *Manager) RequestTranslation(ctx context.Context, family string, from, to *Version) (*Translation, error) {
+	latest := from
+	if to.GreaterThan(from) {
+		latest = to
+	}
+	schemaURL := family + "/" + latest.String()
+
+	m.mu.Lock()
+	if t, ok := m.translations[family]; ok && t.SupportsVersion(from) && t.SupportsVersion(to) {
+		m.mu.Unlock()
+		return t, nil
+	}
+	if failed, ok := m.failures[schemaURL]; ok && m.now().Sub(failed) < retryInterval {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("skipping %s due to a recent failure", schemaURL)
+	}
+	m.mu.Unlock()
+
+	t, err := m.fetchShared(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	if t.Family() != family {
+		return nil, fmt.Errorf("schema %s belongs to family %s: %w", schemaURL, t.Family(), ErrInvalidSchema)
+	}
+	if !t.SupportsVersion(from) || !t.SupportsVersion(to) {
+		return nil, fmt.Errorf("translating %s to %s with %s: %w", from, to, schemaURL, ErrNotSupported)
+	}
+	return t, nil
+}
+
+// fetchShared fetches the schema file published at schemaURL without holding
+// the lock so that requests of other schemas are not blocked by slow downloads,
+// joining the fetch already in progress for the same schema URL if there is one.
+func (m *Manager) fetchShared(ctx context.Context, schemaURL string) (*Translation, error) {
+	m.mu.Lock()
+	if p, ok := m.pending[schemaURL]; ok {
+		m.mu.Unlock()
+		select {
+		case <-p.done:
+			return p.t, p.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	p := &pendingFetch{done: make(chan struct{})}
+	m.pending[schemaURL] = p
+	m.mu.Unlock()
+
+	p.t, p.err = m.fetch(ctx, schemaURL)
+
+	m.mu.Lock()
+	delete(m.pending, schemaURL)
+	if p.err != nil {
+		m.failures[schemaURL] = m.now()
+	} else {
+		delete(m.failures, schemaURL)
+		m.register(p.t)
+	}
+	m.mu.Unlock()
+	close(p.done)
+	return p.t, p.err
+}
+
+func (m *Manager) fetch(ctx context.Context, schemaURL string) (*Translation, error) {
+	m.log.Debug("Fetching schema", zap.String("schema-url", schemaURL))
+	content, err := m.provider.Retrieve(ctx, schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	return NewTranslation(content)
+}
+
+func (m *Manager) register(t *Translation) {
+	if existing, ok := m.translations[t.Family()]; ok && !t.Version().GreaterThan(existing.Version()) {
+		return
+	}
+	m.translations[t.Family()] = t
+}
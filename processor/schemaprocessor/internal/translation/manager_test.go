@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type testProvider struct {
+	schemas  map[string]string
+	requests []string
+}
+
+func (tp *testProvider) Retrieve(_ context.Context, schemaURL string) (io.Reader, error) {
+	tp.requests = append(tp.requests, schemaURL)
+	content, ok := tp.schemas[schemaURL]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return strings.NewReader(content), nil
+}
+
+func testSchema(version string, versions ...string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "file_format: 1.1.0\nschema_url: https://example.com/schemas/%s\nversions:\n", version)
+	for _, v := range versions {
+		fmt.Fprintf(&sb, "  %s:\n", v)
+	}
+	return sb.String()
+}
+
+func TestManagerRequestTranslation(t *testing.T) {
+	t.Parallel()
+
+	provider := &testProvider{schemas: map[string]string{
+		"https://example.com/schemas/1.1.0": testSchema("1.1.0", "1.0.0", "1.1.0"),
+		"https://example.com/schemas/1.2.0": testSchema("1.2.0", "1.0.0", "1.1.0", "1.2.0"),
+	}}
+	m := NewManager(provider, zaptest.NewLogger(t))
+
+	tr, err := m.RequestTranslation(context.Background(), "https://example.com/schemas", v110, v100)
+	require.NoError(t, err)
+	assert.Equal(t, v110, tr.Version())
+	assert.Equal(t, []string{"https://example.com/schemas/1.1.0"}, provider.requests)
+
+	_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas", v100, v110)
+	require.NoError(t, err)
+	assert.Len(t, provider.requests, 1, "Must use the cached translation")
+
+	tr, err = m.RequestTranslation(context.Background(), "https://example.com/schemas", &Version{1, 2, 0}, v100)
+	require.NoError(t, err)
+	assert.Equal(t, &Version{1, 2, 0}, tr.Version(), "Must fetch the newer schema")
+	assert.Len(t, provider.requests, 2)
+
+	_, err = m.RequestTranslation(context.Background(), "https://example.com/schemas", v110, v100)
+	require.NoError(t, err)
+	assert.Len(t, provider.requests, 2, "Newer translation must support older versions")
+}
+
+func TestManagerRetriesFailures(t *testing.T) {
+	t.Parallel()
+
+	provider := &testProvider{}
+	m := NewManager(provider, zaptest.NewLogger(t))
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		_, err := m.RequestTranslation(context.Background(), "https://example.com/schemas", v110, v100)
+		assert.Error(t, err)
+	}
+	assert.Len(t, provider.requests, 1, "Must not retry failed schemas straight away")
+
+	now = now.Add(retryInterval)
+	provider.schemas = map[string]string{
+		"https://example.com/schemas/1.1.0": testSchema("1.1.0", "1.0.0", "1.1.0"),
+	}
+	_, err := m.RequestTranslation(context.Background(), "https://example.com/schemas", v110, v100)
+	assert.NoError(t, err)
+	assert.Len(t, provider.requests, 2)
+}
+
+func TestManagerUnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	provider := &testProvider{schemas: map[string]string{
+		"https://example.com/schemas/1.1.0": testSchema("1.1.0", "1.1.0"),
+	}}
+	m := NewManager(provider, zaptest.NewLogger(t))
+
+	_, err := m.RequestTranslation(context.Background(), "https://example.com/schemas", v110, v100)
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
+
+func TestManagerRegister(t *testing.T) {
+	t.Parallel()
+
+	provider := &testProvider{}
+	m := NewManager(provider, zaptest.NewLogger(t))
+
+	for _, content := range []string{
+		testSchema("1.1.0", "1.0.0", "1.1.0"),
+		testSchema("1.0.0", "1.0.0"),
+	} {
+		tr, err := NewTranslation(strings.NewReader(content))
+		require.NoError(t, err)
+		m.Register(tr)
+	}
+
+	tr, err := m.RequestTranslation(context.Background(), "https://example.com/schemas", v100, v110)
+	require.NoError(t, err)
+	assert.Equal(t, v110, tr.Version(), "Must keep the most recent translation")
+	assert.Empty(t, provider.requests, "Must not fetch registered schemas")
+}
+
+// blockingProvider holds the retrieval of schemas until release is closed.
+type blockingProvider struct {
+	schemas  map[string]string
+	mu       sync.Mutex
+	started  chan struct{}
+	release  chan struct{}
+	requests int
+}
+
+func (bp *blockingProvider) Retrieve(_ context.Context, schemaURL string) (io.Reader, error) {
+	bp.mu.Lock()
+	bp.requests++
+	bp.mu.Unlock()
+	bp.started <- struct{}{}
+	<-bp.release
+	return strings.NewReader(bp.schemas[schemaURL]), nil
+}
+
+func TestManagerFetchesOutsideLock(t *testing.T) {
+	t.Parallel()
+
+	provider := &blockingProvider{
+		schemas: map[string]string{
+			"https://example.com/schemas/1.1.0": testSchema("1.1.0", "1.0.0", "1.1.0"),
+		},
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+	m := NewManager(provider, zaptest.NewLogger(t))
+	cached, err := NewTranslation(strings.NewReader(
+		"file_format: 1.1.0\nschema_url: https://other.com/schemas/1.0.0\nversions:\n  1.0.0:\n"))
+	require.NoError(t, err)
+	m.Register(cached)
+
+	var wg sync.WaitGroup
+	results := make([]*Translation, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tr, err := m.RequestTranslation(context.Background(), "https://example.com/schemas", v110, v100)
+			assert.NoError(t, err)
+			results[i] = tr
+		}(i)
+	}
+	<-provider.started
+
+	tr, err := m.RequestTranslation(context.Background(), "https://other.com/schemas", v100, v100)
+	require.NoError(t, err, "Must not wait for the fetch of another schema")
+	assert.Same(t, cached, tr)
+
+	close(provider.release)
+	wg.Wait()
+	for _, tr := range results {
+		assert.Equal(t, v110, tr.Version())
+	}
+	assert.Equal(t, 1, provider.requests, "Must share the fetch of the same schema")
+}
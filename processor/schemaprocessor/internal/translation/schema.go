@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// schema is the decoded form of a schema file as described by
+// https://opentelemetry.io/docs/reference/specification/schemas/file_format_v1.1.0/
+type schema struct {
+	FileFormat string                   `yaml:"file_format"`
+	SchemaURL  string                   `yaml:"schema_url"`
+	Versions   map[string]schemaVersion `yaml:"versions"`
+}
+
+// schemaVersion holds all the changes that were introduced
+// by a single version of the schema family.
+type schemaVersion struct {
+	All        changeSet `yaml:"all"`
+	Resources  changeSet `yaml:"resources"`
+	Spans      changeSet `yaml:"spans"`
+	SpanEvents changeSet `yaml:"span_events"`
+	Metrics    changeSet `yaml:"metrics"`
+	Logs       changeSet `yaml:"logs"`
+}
+
+type changeSet struct {
+	Changes []change `yaml:"changes"`
+}
+
+// change is a single transformation, only one of the fields
+// is expected to be set per entry of a change set.
+type change struct {
+	RenameAttributes *attributeRenames `yaml:"rename_attributes"`
+	RenameEvents     *eventRenames     `yaml:"rename_events"`
+	RenameMetrics    map[string]string `yaml:"rename_metrics"`
+}
+
+type attributeRenames struct {
+	AttributeMap   map[string]string `yaml:"attribute_map"`
+	ApplyToSpans   []string          `yaml:"apply_to_spans"`
+	ApplyToEvents  []string          `yaml:"apply_to_events"`
+	ApplyToMetrics []string          `yaml:"apply_to_metrics"`
+}
+
+// UnmarshalYAML allows for the attribute map to be defined directly
+// under rename_attributes, as is done within the "all" and "resources"
+// sections of the example schema published by the specification.
+func (ra *attributeRenames) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.MappingNode {
+		for i := 0; i < len(value.Content); i += 2 {
+			switch value.Content[i].Value {
+			case "attribute_map", "apply_to_spans", "apply_to_events", "apply_to_metrics":
+				type plain attributeRenames
+				return value.Decode((*plain)(ra))
+			}
+		}
+	}
+	return value.Decode(&ra.AttributeMap)
+}
+
+type eventRenames struct {
+	NameMap map[string]string `yaml:"name_map"`
+}
@@ -0,0 +1,321 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+)
+
+var ErrInvalidSchema = errors.New("invalid schema file")
+
+// Translation holds the parsed content of a schema file and
+// is able to upgrade or downgrade telemetry between any of
+// the versions defined within it.
+type Translation struct {
+	family  string
+	version *Version
+	// revisions are sorted in ascending version order
+	revisions []revision
+}
+
+type revision struct {
+	version *Version
+	changes schemaVersion
+}
+
+// operation is a single change that is ready to be applied
+// in the direction of the translation being performed.
+type operation struct {
+	attributes map[string]string
+	names      map[string]string
+	// The following restrict which signals the attribute
+	// renames are applied to, an empty set matches all signals.
+	spans   map[string]struct{}
+	events  map[string]struct{}
+	metrics map[string]struct{}
+}
+
+// NewTranslation reads a schema file from r and returns
+// the translation described by it.
+func NewTranslation(r io.Reader) (*Translation, error) {
+	var s schema
+	if err := yaml.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("unable to decode schema: %w", err)
+	}
+	if !strings.HasPrefix(s.FileFormat, "1.") {
+		return nil, fmt.Errorf("unsupported file format %q: %w", s.FileFormat, ErrInvalidSchema)
+	}
+	family, version, err := GetFamilyAndVersion(s.SchemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Translation{
+		family:    family,
+		version:   version,
+		revisions: make([]revision, 0, len(s.Versions)),
+	}
+	for id, changes := range s.Versions {
+		v, err := NewVersion(id)
+		if err != nil {
+			return nil, fmt.Errorf("version %q: %w", id, err)
+		}
+		if v.GreaterThan(version) {
+			return nil, fmt.Errorf("version %s is newer than the schema url %s: %w", v, s.SchemaURL, ErrInvalidSchema)
+		}
+		t.revisions = append(t.revisions, revision{version: v, changes: changes})
+	}
+	sort.Slice(t.revisions, func(i, j int) bool {
+		return t.revisions[i].version.LessThan(t.revisions[j].version)
+	})
+	return t, nil
+}
+
+// Family returns the schema family that the translation belongs to.
+func (t *Translation) Family() string {
+	return t.family
+}
+
+// Version returns the most recent version known by the translation.
+func (t *Translation) Version() *Version {
+	return t.version
+}
+
+// SupportsVersion reports if v is defined within the schema file.
+func (t *Translation) SupportsVersion(v *Version) bool {
+	for _, rev := range t.revisions {
+		if rev.version.Equal(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyResourceChanges modifies the resource attributes
+// published with version from so that they conform to version to.
+func (t *Translation) ApplyResourceChanges(res pcommon.Resource, from, to *Version) {
+	for _, op := range t.operations(from, to, func(sv *schemaVersion) changeSet { return sv.Resources }) {
+		renameAttributes(res.Attributes(), op.attributes)
+	}
+}
+
+// ApplyScopeSpanChanges modifies the spans and span events contained within ss
+// published with version from so that they conform to version to.
+func (t *Translation) ApplyScopeSpanChanges(ss ptrace.ScopeSpans, from, to *Version) {
+	var (
+		spanOps  = t.operations(from, to, func(sv *schemaVersion) changeSet { return sv.Spans })
+		eventOps = t.operations(from, to, func(sv *schemaVersion) changeSet { return sv.SpanEvents })
+	)
+	for i := 0; i < ss.Spans().Len(); i++ {
+		span := ss.Spans().At(i)
+		for _, op := range spanOps {
+			if matches(op.spans, span.Name()) {
+				renameAttributes(span.Attributes(), op.attributes)
+			}
+		}
+		for j := 0; j < span.Events().Len(); j++ {
+			event := span.Events().At(j)
+			for _, op := range eventOps {
+				if matches(op.spans, span.Name()) && matches(op.events, event.Name()) {
+					renameAttributes(event.Attributes(), op.attributes)
+				}
+				renameSignal(event, op.names)
+			}
+		}
+	}
+}
+
+// ApplyScopeMetricChanges modifies the metrics contained within sm
+// published with version from so that they conform to version to.
+func (t *Translation) ApplyScopeMetricChanges(sm pmetric.ScopeMetrics, from, to *Version) {
+	ops := t.operations(from, to, func(sv *schemaVersion) changeSet { return sv.Metrics })
+	for i := 0; i < sm.Metrics().Len(); i++ {
+		metric := sm.Metrics().At(i)
+		for _, op := range ops {
+			if len(op.attributes) > 0 && matches(op.metrics, metric.Name()) {
+				rangeDataPointAttributes(metric, func(attrs pcommon.Map) {
+					renameAttributes(attrs, op.attributes)
+				})
+			}
+			renameSignal(metric, op.names)
+		}
+	}
+}
+
+// ApplyScopeLogChanges modifies the log records contained within sl
+// published with version from so that they conform to version to.
+func (t *Translation) ApplyScopeLogChanges(sl plog.ScopeLogs, from, to *Version) {
+	ops := t.operations(from, to, func(sv *schemaVersion) changeSet { return sv.Logs })
+	for i := 0; i < sl.LogRecords().Len(); i++ {
+		log := sl.LogRecords().At(i)
+		for _, op := range ops {
+			renameAttributes(log.Attributes(), op.attributes)
+		}
+	}
+}
+
+// operations returns the list of changes that need to be applied, in order,
+// to convert a signal from one version to another.
+// The changes defined in the "all" section are always included along with the
+// changes of the section selected.
+// When downgrading, the changes are walked in reverse and every rename is inverted.
+func (t *Translation) operations(from, to *Version, section func(sv *schemaVersion) changeSet) []operation {
+	var ops []operation
+	switch {
+	case from.LessThan(to):
+		for i := 0; i < len(t.revisions); i++ {
+			rev := &t.revisions[i]
+			if !rev.version.GreaterThan(from) || rev.version.GreaterThan(to) {
+				continue
+			}
+			for _, c := range rev.changes.All.Changes {
+				ops = append(ops, newOperation(c, false))
+			}
+			for _, c := range section(&rev.changes).Changes {
+				ops = append(ops, newOperation(c, false))
+			}
+		}
+	case from.GreaterThan(to):
+		for i := len(t.revisions) - 1; i >= 0; i-- {
+			rev := &t.revisions[i]
+			if !rev.version.GreaterThan(to) || rev.version.GreaterThan(from) {
+				continue
+			}
+			changes := section(&rev.changes).Changes
+			for j := len(changes) - 1; j >= 0; j-- {
+				ops = append(ops, newOperation(changes[j], true))
+			}
+			for j := len(rev.changes.All.Changes) - 1; j >= 0; j-- {
+				ops = append(ops, newOperation(rev.changes.All.Changes[j], true))
+			}
+		}
+	}
+	return ops
+}
+
+func newOperation(c change, downgrade bool) operation {
+	var op operation
+	if ra := c.RenameAttributes; ra != nil {
+		op.attributes = direction(ra.AttributeMap, downgrade)
+		op.spans = setOf(ra.ApplyToSpans)
+		op.events = setOf(ra.ApplyToEvents)
+		op.metrics = setOf(ra.ApplyToMetrics)
+	}
+	if re := c.RenameEvents; re != nil {
+		op.names = direction(re.NameMap, downgrade)
+	}
+	if len(c.RenameMetrics) > 0 {
+		op.names = direction(c.RenameMetrics, downgrade)
+	}
+	return op
+}
+
+func direction(names map[string]string, downgrade bool) map[string]string {
+	if !downgrade {
+		return names
+	}
+	inverted := make(map[string]string, len(names))
+	for k, v := range names {
+		inverted[v] = k
+	}
+	return inverted
+}
+
+func setOf(values []string) map[string]struct{} {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
+
+func matches(set map[string]struct{}, name string) bool {
+	if len(set) == 0 {
+		return true
+	}
+	_, ok := set[name]
+	return ok
+}
+
+// renameAttributes moves the values stored under the old key to the new key.
+// All values are removed before being written back so that renames that
+// swap keys do not overwrite each other.
+func renameAttributes(attrs pcommon.Map, names map[string]string) {
+	if len(names) == 0 || attrs.Len() == 0 {
+		return
+	}
+	moved := make(map[string]pcommon.Value)
+	for from, to := range names {
+		v, ok := attrs.Get(from)
+		if !ok {
+			continue
+		}
+		val := pcommon.NewValueEmpty()
+		v.CopyTo(val)
+		moved[to] = val
+		attrs.Remove(from)
+	}
+	for key, val := range moved {
+		val.CopyTo(attrs.PutEmpty(key))
+	}
+}
+
+func renameSignal(sig alias.Signal, names map[string]string) {
+	if name, ok := names[sig.Name()]; ok {
+		sig.SetName(name)
+	}
+}
+
+func rangeDataPointAttributes(metric pmetric.Metric, fn func(attrs pcommon.Map)) {
+	//exhaustive:enforce
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			fn(metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			fn(metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			fn(metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			fn(metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			fn(metric.Summary().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
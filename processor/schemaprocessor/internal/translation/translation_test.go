@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	v100 = &Version{1, 0, 0}
+	v110 = &Version{1, 1, 0}
+)
+
+func newExampleTranslation(t *testing.T) *Translation {
+	f, err := os.Open(filepath.Join("..", "..", "testdata", "schema.yml"))
+	require.NoError(t, err, "Must be able to open the example schema")
+	defer f.Close()
+
+	tr, err := NewTranslation(f)
+	require.NoError(t, err, "Must not error when parsing the example schema")
+	return tr
+}
+
+func TestNewTranslation(t *testing.T) {
+	t.Parallel()
+
+	tr := newExampleTranslation(t)
+	assert.Equal(t, "https://opentelemetry.io/schemas", tr.Family())
+	assert.Equal(t, v110, tr.Version())
+	assert.True(t, tr.SupportsVersion(v100))
+	assert.True(t, tr.SupportsVersion(v110))
+	assert.False(t, tr.SupportsVersion(&Version{1, 2, 0}))
+}
+
+func TestNewTranslationErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		scenario string
+		content  string
+		err      error
+	}{
+		{
+			scenario: "unsupported file format",
+			content:  "file_format: 2.0.0\nschema_url: https://example.com/schemas/1.0.0\n",
+			err:      ErrInvalidSchema,
+		},
+		{
+			scenario: "invalid schema url",
+			content:  "file_format: 1.0.0\nschema_url: https://example.com/schemas/latest\n",
+			err:      ErrInvalidVersion,
+		},
+		{
+			scenario: "version newer than schema url",
+			content:  "file_format: 1.0.0\nschema_url: https://example.com/schemas/1.0.0\nversions:\n  1.1.0:\n",
+			err:      ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.scenario, func(t *testing.T) {
+			_, err := NewTranslation(strings.NewReader(tc.content))
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestTranslationResource(t *testing.T) {
+	t.Parallel()
+
+	tr := newExampleTranslation(t)
+
+	rl := plog.NewResourceLogs()
+	attrs := rl.Resource().Attributes()
+	attrs.PutStr("k8s.pod.name", "pod-0")
+	attrs.PutStr("telemetry.auto.version", "1.2.3")
+	attrs.PutStr("service.name", "checkout")
+	original := plog.NewResourceLogs()
+	rl.CopyTo(original)
+
+	tr.ApplyResourceChanges(rl.Resource(), v100, v110)
+	assert.Equal(t, map[string]any{
+		"kubernetes.pod.name":          "pod-0",
+		"telemetry.auto_instr.version": "1.2.3",
+		"service.name":                 "checkout",
+	}, attrs.AsRaw())
+
+	tr.ApplyResourceChanges(rl.Resource(), v110, v100)
+	assert.Equal(t, original.Resource().Attributes().AsRaw(), attrs.AsRaw())
+}
+
+func TestTranslationSpans(t *testing.T) {
+	t.Parallel()
+
+	tr := newExampleTranslation(t)
+
+	ss := ptrace.NewScopeSpans()
+	get := ss.Spans().AppendEmpty()
+	get.SetName("HTTP GET")
+	get.Attributes().PutStr("peer.service", "inventory")
+	get.Attributes().PutStr("k8s.node.name", "node-1")
+	ev := get.Events().AppendEmpty()
+	ev.SetName("stacktrace")
+	ev.Attributes().PutStr("peer.service", "inventory")
+
+	post := ss.Spans().AppendEmpty()
+	post.SetName("HTTP POST")
+	post.Attributes().PutStr("peer.service", "inventory")
+	ev = post.Events().AppendEmpty()
+	ev.SetName("exception.stack_trace")
+	ev.Attributes().PutStr("peer.service", "inventory")
+
+	original := ptrace.NewScopeSpans()
+	ss.CopyTo(original)
+
+	tr.ApplyScopeSpanChanges(ss, v100, v110)
+
+	assert.Equal(t, map[string]any{
+		"peer.service.name":    "inventory",
+		"kubernetes.node.name": "node-1",
+	}, get.Attributes().AsRaw(), "Must rename attributes of matching spans")
+	assert.Equal(t, "stack_trace", get.Events().At(0).Name(), "Must rename span events")
+	assert.Equal(t, map[string]any{
+		"peer.service": "inventory",
+	}, get.Events().At(0).Attributes().AsRaw(), "Must not rename attributes of events not matched")
+
+	assert.Equal(t, map[string]any{
+		"peer.service": "inventory",
+	}, post.Attributes().AsRaw(), "Must not rename attributes of spans not matched")
+	assert.Equal(t, map[string]any{
+		"peer.service.name": "inventory",
+	}, post.Events().At(0).Attributes().AsRaw(), "Must rename attributes of matching events")
+
+	tr.ApplyScopeSpanChanges(ss, v110, v100)
+	assert.Equal(t, original, ss, "Must revert back to the original spans")
+}
+
+func TestTranslationMetrics(t *testing.T) {
+	t.Parallel()
+
+	tr := newExampleTranslation(t)
+
+	sm := pmetric.NewScopeMetrics()
+	usage := sm.Metrics().AppendEmpty()
+	usage.SetName("container.cpu.usage.total")
+	usage.SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("status", "idle")
+
+	util := sm.Metrics().AppendEmpty()
+	util.SetName("system.cpu.utilization")
+	util.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("status", "idle")
+
+	original := pmetric.NewScopeMetrics()
+	sm.CopyTo(original)
+
+	tr.ApplyScopeMetricChanges(sm, v100, v110)
+	assert.Equal(t, "cpu.usage.total", usage.Name(), "Must rename metrics")
+	assert.Equal(t, map[string]any{
+		"status": "idle",
+	}, usage.Sum().DataPoints().At(0).Attributes().AsRaw(), "Must not rename attributes of metrics not matched")
+	assert.Equal(t, map[string]any{
+		"state": "idle",
+	}, util.Gauge().DataPoints().At(0).Attributes().AsRaw(), "Must rename attributes of matching metrics")
+
+	tr.ApplyScopeMetricChanges(sm, v110, v100)
+	assert.Equal(t, original, sm, "Must revert back to the original metrics")
+}
+
+func TestTranslationLogs(t *testing.T) {
+	t.Parallel()
+
+	tr := newExampleTranslation(t)
+
+	sl := plog.NewScopeLogs()
+	lr := sl.LogRecords().AppendEmpty()
+	lr.Attributes().PutStr("process.executable_name", "otelcol")
+	lr.Attributes().PutStr("k8s.namespace.name", "default")
+
+	tr.ApplyScopeLogChanges(sl, v100, v110)
+	assert.Equal(t, map[string]any{
+		"process.executable.name":   "otelcol",
+		"kubernetes.namespace.name": "default",
+	}, lr.Attributes().AsRaw())
+
+	tr.ApplyScopeLogChanges(sl, v110, v110)
+	assert.Equal(t, map[string]any{
+		"process.executable.name":   "otelcol",
+		"kubernetes.namespace.name": "default",
+	}, lr.Attributes().AsRaw(), "Must not modify logs already at the requested version")
+}
@@ -4,7 +4,7 @@
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//      http://www.apache.org/licenses/LICENSE-2.0
+//       http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
@@ -17,6 +17,8 @@ package schemaprocessor // import "github.com/open-telemetry/opentelemetry-colle
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -24,11 +26,25 @@ import (
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/alias"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/schemaprocessor/internal/translation"
 )
 
 type transformer struct {
-	targets []string
-	log     *zap.Logger
+	cfg       *Config
+	targets   map[string]*translation.Version
+	log       *zap.Logger
+	telemetry component.TelemetrySettings
+	manager   *translation.Manager
+}
+
+// plan describes how to convert signals published with
+// one schema version to the target of the same family.
+type plan struct {
+	translation *translation.Translation
+	from, to    *translation.Version
+	targetURL   string
 }
 
 func newTransformer(
@@ -40,29 +56,161 @@ func newTransformer(
 	if !ok {
 		return nil, errors.New("invalid configuration provided")
 	}
+	targets := make(map[string]*translation.Version, len(cfg.Targets))
+	for _, target := range cfg.Targets {
+		family, version, err := translation.GetFamilyAndVersion(target)
+		if err != nil {
+			return nil, err
+		}
+		targets[family] = version
+	}
 	return &transformer{
-		log:     set.Logger,
-		targets: cfg.Targets,
+		cfg:       cfg,
+		targets:   targets,
+		log:       set.Logger,
+		telemetry: set.TelemetrySettings,
 	}, nil
 }
 
-func (t transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+func (t *transformer) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		resPlan := t.translateResource(ctx, rl)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			if p := t.scopePlan(ctx, sl.SchemaUrl(), resPlan); p != nil {
+				p.translation.ApplyScopeLogChanges(sl, p.from, p.to)
+				if sl.SchemaUrl() != "" {
+					sl.SetSchemaUrl(p.targetURL)
+				}
+			}
+		}
+	}
 	return ld, nil
 }
 
-func (t transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+func (t *transformer) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		resPlan := t.translateResource(ctx, rm)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			if p := t.scopePlan(ctx, sm.SchemaUrl(), resPlan); p != nil {
+				p.translation.ApplyScopeMetricChanges(sm, p.from, p.to)
+				if sm.SchemaUrl() != "" {
+					sm.SetSchemaUrl(p.targetURL)
+				}
+			}
+		}
+	}
 	return md, nil
 }
 
-func (t transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+func (t *transformer) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		resPlan := t.translateResource(ctx, rs)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			if p := t.scopePlan(ctx, ss.SchemaUrl(), resPlan); p != nil {
+				p.translation.ApplyScopeSpanChanges(ss, p.from, p.to)
+				if ss.SchemaUrl() != "" {
+					ss.SetSchemaUrl(p.targetURL)
+				}
+			}
+		}
+	}
 	return td, nil
 }
 
+// translateResource converts the resource to the target schema
+// and returns the plan used so it can be reused by the scopes
+// that do not define their own schema URL.
+func (t *transformer) translateResource(ctx context.Context, res alias.Resource) *plan {
+	p := t.newPlan(ctx, res.SchemaUrl())
+	if p == nil {
+		return nil
+	}
+	p.translation.ApplyResourceChanges(res.Resource(), p.from, p.to)
+	res.SetSchemaUrl(p.targetURL)
+	return p
+}
+
+// scopePlan returns the plan for the scope, a schema URL set on the
+// scope takes precedence over the one set on the resource.
+func (t *transformer) scopePlan(ctx context.Context, schemaURL string, resPlan *plan) *plan {
+	if schemaURL == "" {
+		return resPlan
+	}
+	return t.newPlan(ctx, schemaURL)
+}
+
+// newPlan returns nil when the signal does not need to be translated
+// or the translation could not be resolved, in which case the
+// signal is passed on unmodified.
+func (t *transformer) newPlan(ctx context.Context, schemaURL string) *plan {
+	if schemaURL == "" || t.manager == nil {
+		return nil
+	}
+	family, from, err := translation.GetFamilyAndVersion(schemaURL)
+	if err != nil {
+		t.log.Debug("Ignoring invalid schema url", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil
+	}
+	to, ok := t.targets[family]
+	if !ok || from.Equal(to) {
+		return nil
+	}
+	tr, err := t.manager.RequestTranslation(ctx, family, from, to)
+	if err != nil {
+		t.log.Warn("Unable to translate schema", zap.String("schema-url", schemaURL), zap.Error(err))
+		return nil
+	}
+	return &plan{
+		translation: tr,
+		from:        from,
+		to:          to,
+		targetURL:   family + "/" + to.String(),
+	}
+}
+
 // start will load the remote file definition if it isn't already cached
 // and resolve the schema translation file
 func (t *transformer) start(ctx context.Context, host component.Host) error {
-	for _, target := range t.targets {
-		t.log.Info("Fetching remote schema url", zap.String("schema-url", target))
+	client, err := t.cfg.HTTPClientSettings.ToClient(host, t.telemetry)
+	if err != nil {
+		return err
+	}
+	t.manager = translation.NewManager(translation.NewHTTPProvider(client), t.log)
+
+	for _, file := range t.cfg.SchemaFiles {
+		tr, err := loadSchemaFile(file)
+		if err != nil {
+			return err
+		}
+		t.manager.Register(tr)
+	}
+	for _, schemaURL := range t.cfg.Prefetch {
+		t.log.Info("Fetching remote schema url", zap.String("schema-url", schemaURL))
+		// Failing to prefetch is not fatal since
+		// the schema is fetched again once it is required.
+		if err := t.manager.Prefetch(ctx, schemaURL); err != nil {
+			t.log.Warn("Unable to prefetch schema", zap.String("schema-url", schemaURL), zap.Error(err))
+		}
 	}
 	return nil
 }
+
+func loadSchemaFile(path string) (*translation.Translation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tr, err := translation.NewTranslation(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load schema file %s: %w", path, err)
+	}
+	return tr, nil
+}
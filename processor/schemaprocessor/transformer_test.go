@@ -18,15 +18,20 @@ import (
 	"context"
 	_ "embed"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
 	"go.uber.org/zap/zaptest"
 )
 
@@ -55,7 +60,7 @@ func TestTransformerStart(t *testing.T) {
 	t.Parallel()
 
 	trans := newTestTransformer(t)
-	assert.NoError(t, trans.start(context.Background(), nil))
+	assert.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
 }
 
 func TestTransformerProcessing(t *testing.T) {
@@ -109,3 +114,173 @@ func TestTransformerProcessing(t *testing.T) {
 		assert.Equal(t, in, out, "Must return the same data (subject to change)")
 	})
 }
+
+func TestTransformerTranslation(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(wr http.ResponseWriter, r *http.Request) {
+		requests++
+		SchemaHandler(t)(wr, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{"https://opentelemetry.io/schemas/1.0.0"}
+	// Route all schema requests to the test server
+	// so that the published schema url can be used as is.
+	cfg.CustomRoundTripper = func(next http.RoundTripper) (http.RoundTripper, error) {
+		return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			r.URL.Scheme = "http"
+			r.URL.Host = srv.Listener.Addr().String()
+			return next.RoundTrip(r)
+		}), nil
+	}
+
+	trans, err := newTransformer(context.Background(), cfg, processor.CreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+
+	in := ptrace.NewTraces()
+	rs := in.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.1.0")
+	rs.Resource().Attributes().PutStr("kubernetes.pod.name", "pod-0")
+	s := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	s.SetName("HTTP GET")
+	s.Attributes().PutStr("peer.service.name", "inventory")
+
+	out, err := trans.processTraces(context.Background(), in)
+	require.NoError(t, err)
+
+	rs = out.ResourceSpans().At(0)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.0.0", rs.SchemaUrl(), "Must update the schema url to the target")
+	assert.Equal(t, map[string]any{"k8s.pod.name": "pod-0"}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"peer.service": "inventory"}, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+	assert.Equal(t, 1, requests, "Must fetch the schema once")
+}
+
+func TestTransformerSchemaFiles(t *testing.T) {
+	t.Parallel()
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{"https://opentelemetry.io/schemas/1.1.0"}
+	cfg.SchemaFiles = []string{filepath.Join("testdata", "schema.yml")}
+
+	trans, err := newTransformer(context.Background(), cfg, processor.CreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, trans.start(context.Background(), componenttest.NewNopHost()))
+
+	t.Run("metrics", func(t *testing.T) {
+		in := pmetric.NewMetrics()
+		rm := in.ResourceMetrics().AppendEmpty()
+		rm.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+		sm := rm.ScopeMetrics().AppendEmpty()
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("system.memory.usage")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("status", "used")
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("container.memory.usage.max")
+		m.SetEmptyGauge()
+
+		out, err := trans.processMetrics(context.Background(), in)
+		require.NoError(t, err)
+
+		metrics := out.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		assert.Equal(t, "https://opentelemetry.io/schemas/1.1.0", out.ResourceMetrics().At(0).SchemaUrl())
+		assert.Equal(t, map[string]any{"state": "used"}, metrics.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+		assert.Equal(t, "memory.usage.max", metrics.At(1).Name())
+	})
+
+	t.Run("logs with scope schema url", func(t *testing.T) {
+		in := plog.NewLogs()
+		rl := in.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("k8s.pod.name", "pod-0")
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+		sl.LogRecords().AppendEmpty().Attributes().PutStr("process.executable_name", "otelcol")
+
+		out, err := trans.processLogs(context.Background(), in)
+		require.NoError(t, err)
+
+		rl = out.ResourceLogs().At(0)
+		assert.Empty(t, rl.SchemaUrl(), "Must not set a schema url on the resource")
+		assert.Equal(t, map[string]any{"k8s.pod.name": "pod-0"}, rl.Resource().Attributes().AsRaw())
+		assert.Equal(t, "https://opentelemetry.io/schemas/1.1.0", rl.ScopeLogs().At(0).SchemaUrl())
+		assert.Equal(t, map[string]any{"process.executable.name": "otelcol"}, rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+	})
+
+	t.Run("unknown family", func(t *testing.T) {
+		in := ptrace.NewTraces()
+		rs := in.ResourceSpans().AppendEmpty()
+		rs.SetSchemaUrl("https://example.com/schemas/1.0.0")
+		rs.Resource().Attributes().PutStr("k8s.pod.name", "pod-0")
+		expect := ptrace.NewTraces()
+		in.CopyTo(expect)
+
+		out, err := trans.processTraces(context.Background(), in)
+		require.NoError(t, err)
+		assert.Equal(t, expect, out, "Must not modify signals without a target")
+	})
+}
+
+func TestTransformerFromFactory(t *testing.T) {
+	t.Parallel()
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{"https://opentelemetry.io/schemas/1.0.0"}
+	cfg.SchemaFiles = []string{filepath.Join("testdata", "schema.yml")}
+
+	sink := new(consumertest.TracesSink)
+	proc, err := NewFactory().CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, proc.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, proc.Shutdown(context.Background()))
+	})
+
+	in := ptrace.NewTraces()
+	rs := in.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.1.0")
+	rs.Resource().Attributes().PutStr("kubernetes.pod.name", "pod-0")
+	s := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	s.SetName("HTTP GET")
+	s.Attributes().PutStr("peer.service.name", "inventory")
+
+	require.NoError(t, proc.ConsumeTraces(context.Background(), in))
+	require.Len(t, sink.AllTraces(), 1)
+
+	rs = sink.AllTraces()[0].ResourceSpans().At(0)
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.0.0", rs.SchemaUrl(), "Must update the schema url to the target")
+	assert.Equal(t, map[string]any{"k8s.pod.name": "pod-0"}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"peer.service": "inventory"}, rs.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+}
+
+func TestTransformerStartInvalidSchemaFile(t *testing.T) {
+	t.Parallel()
+
+	cfg := newDefaultConfiguration().(*Config)
+	cfg.Targets = []string{"https://opentelemetry.io/schemas/1.1.0"}
+	cfg.SchemaFiles = []string{filepath.Join("testdata", "config.yml")}
+
+	trans, err := newTransformer(context.Background(), cfg, processor.CreateSettings{
+		TelemetrySettings: component.TelemetrySettings{
+			Logger: zaptest.NewLogger(t),
+		},
+	})
+	require.NoError(t, err)
+	assert.Error(t, trans.start(context.Background(), componenttest.NewNopHost()))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -39,6 +39,12 @@ type Config struct {
 
 	// Store contains the config for the in-memory store used to find requests between services by pairing spans.
 	Store StoreConfig `mapstructure:"store"`
+
+	// VirtualNodePeerAttributes is the list of client span attributes used to name the virtual node of
+	// an uninstrumented server, for client spans which expire without being paired with a server span.
+	// The attributes are tried in order, the first one found on the span is used.
+	// See defaultPeerAttributes in processor.go for the default value.
+	VirtualNodePeerAttributes []string `mapstructure:"virtual_node_peer_attributes"`
 }
 
 type StoreConfig struct {
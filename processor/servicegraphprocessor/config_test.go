@@ -47,6 +47,7 @@ func TestLoadConfig(t *testing.T) {
 				TTL:      time.Second,
 				MaxItems: 10,
 			},
+			VirtualNodePeerAttributes: []string{"db.name", "net.peer.name"},
 		},
 		cfg.Processors[component.NewID(typeStr)],
 	)
@@ -26,6 +26,7 @@ const (
 	Unknown         ConnectionType = ""
 	MessagingSystem ConnectionType = "messaging_system"
 	Database        ConnectionType = "database"
+	VirtualNode     ConnectionType = "virtual_node"
 )
 
 // Edge is an Edge between two nodes in the graph
@@ -44,6 +45,10 @@ type Edge struct {
 	// Additional dimension to add to the metrics
 	Dimensions map[string]string
 
+	// Peer attributes of the client span, used to name the virtual node
+	// of an uninstrumented server
+	Peer map[string]string
+
 	// expiration is the time at which the Edge expires, expressed as Unix time
 	expiration time.Time
 }
@@ -52,6 +57,7 @@ func newEdge(key Key, ttl time.Duration) *Edge {
 	return &Edge{
 		key:        key,
 		Dimensions: make(map[string]string),
+		Peer:       make(map[string]string),
 		expiration: time.Now().Add(ttl),
 	}
 }
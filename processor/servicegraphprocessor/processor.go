@@ -47,6 +47,10 @@ var (
 	defaultLatencyHistogramBucketsMs = []float64{
 		2, 4, 6, 8, 10, 50, 100, 200, 400, 800, 1000, 1400, 2000, 5000, 10_000, 15_000,
 	}
+
+	defaultPeerAttributes = []string{
+		semconv.AttributePeerService, semconv.AttributeDBName, semconv.AttributeNetPeerName, semconv.AttributeMessagingSystem,
+	}
 )
 
 type metricSeries struct {
@@ -74,6 +78,9 @@ type serviceGraphProcessor struct {
 	reqDurationBounds              []float64
 	reqDurationSecondsBucketCounts map[string][]uint64
 
+	// peerAttributes of the client spans naming the virtual nodes, in order of priority
+	peerAttributes []string
+
 	metricMutex sync.RWMutex
 	keyToMetric map[string]metricSeries
 
@@ -88,6 +95,11 @@ func newProcessor(logger *zap.Logger, config component.Config, nextConsumer cons
 		bounds = mapDurationsToMillis(pConfig.LatencyHistogramBuckets)
 	}
 
+	peerAttributes := defaultPeerAttributes
+	if pConfig.VirtualNodePeerAttributes != nil {
+		peerAttributes = pConfig.VirtualNodePeerAttributes
+	}
+
 	p := &serviceGraphProcessor{
 		config:                         pConfig,
 		logger:                         logger,
@@ -98,6 +110,7 @@ func newProcessor(logger *zap.Logger, config component.Config, nextConsumer cons
 		reqDurationSecondsSum:          make(map[string]float64),
 		reqDurationSecondsCount:        make(map[string]uint64),
 		reqDurationBounds:              bounds,
+		peerAttributes:                 peerAttributes,
 		reqDurationSecondsBucketCounts: make(map[string][]uint64),
 		keyToMetric:                    make(map[string]metricSeries),
 		shutdownCh:                     make(chan interface{}),
@@ -169,11 +182,6 @@ func (p *serviceGraphProcessor) ConsumeTraces(ctx context.Context, td ptrace.Tra
 }
 
 func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.Traces) (err error) {
-	var (
-		isNew             bool
-		totalDroppedSpans int
-	)
-
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
 		rSpans := rss.At(i)
@@ -202,13 +210,14 @@ func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.
 				case ptrace.SpanKindClient:
 					traceID := span.TraceID()
 					key := store.NewKey(traceID, span.SpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+					err = p.upsertEdge(ctx, key, func(e *store.Edge) {
 						e.TraceID = traceID
 						e.ConnectionType = connectionType
 						e.ClientService = serviceName
 						e.ClientLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
 						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
 						p.upsertDimensions(clientKind, e.Dimensions, rAttributes, span.Attributes())
+						p.upsertPeerAttributes(e.Peer, span.Attributes())
 
 						// A database request will only have one span, we don't wait for the server
 						// span but just copy details from the client span
@@ -219,45 +228,68 @@ func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.
 						}
 					})
 				case ptrace.SpanKindConsumer:
+					// Consumers receiving messages in batches usually link the producer spans,
+					// instead of being their child: pair the consumer span with every linked span.
+					if links := span.Links(); links.Len() > 0 {
+						for l := 0; l < links.Len() && err == nil; l++ {
+							link := links.At(l)
+							key := store.NewKey(link.TraceID(), link.SpanID())
+							err = p.upsertEdge(ctx, key, p.serverEdgeUpdate(link.TraceID(), store.MessagingSystem, serviceName, rAttributes, span))
+						}
+						break
+					}
 					// override connection type and continue processing as span kind server
 					connectionType = store.MessagingSystem
 					fallthrough
 				case ptrace.SpanKindServer:
 					traceID := span.TraceID()
 					key := store.NewKey(traceID, span.ParentSpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
-						e.TraceID = traceID
-						e.ConnectionType = connectionType
-						e.ServerService = serviceName
-						e.ServerLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
-						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
-						p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
-					})
+					err = p.upsertEdge(ctx, key, p.serverEdgeUpdate(traceID, connectionType, serviceName, rAttributes, span))
 				default:
 					// this span is not part of an edge
 					continue
 				}
 
-				if errors.Is(err, store.ErrTooManyItems) {
-					totalDroppedSpans++
-					stats.Record(ctx, statDroppedSpans.M(1))
-					continue
-				}
-
-				// UpsertEdge will only return ErrTooManyItems
 				if err != nil {
 					return err
 				}
-
-				if isNew {
-					stats.Record(ctx, statTotalEdges.M(1))
-				}
 			}
 		}
 	}
 	return nil
 }
 
+// upsertEdge upserts the edge of the given key in the store, recording the dropped spans when the store is full.
+func (p *serviceGraphProcessor) upsertEdge(ctx context.Context, key store.Key, update store.Callback) error {
+	isNew, err := p.store.UpsertEdge(key, update)
+	if errors.Is(err, store.ErrTooManyItems) {
+		stats.Record(ctx, statDroppedSpans.M(1))
+		return nil
+	}
+
+	// UpsertEdge will only return ErrTooManyItems
+	if err != nil {
+		return err
+	}
+
+	if isNew {
+		stats.Record(ctx, statTotalEdges.M(1))
+	}
+	return nil
+}
+
+// serverEdgeUpdate returns the callback updating an edge with the given server span.
+func (p *serviceGraphProcessor) serverEdgeUpdate(traceID pcommon.TraceID, connectionType store.ConnectionType, serviceName string, rAttributes pcommon.Map, span ptrace.Span) store.Callback {
+	return func(e *store.Edge) {
+		e.TraceID = traceID
+		e.ConnectionType = connectionType
+		e.ServerService = serviceName
+		e.ServerLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
+		e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
+		p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
+	}
+}
+
 func (p *serviceGraphProcessor) upsertDimensions(kind string, m map[string]string, resourceAttr pcommon.Map, spanAttr pcommon.Map) {
 	for _, dim := range p.config.Dimensions {
 		if v, ok := findAttributeValue(dim, resourceAttr, spanAttr); ok {
@@ -269,6 +301,14 @@ func (p *serviceGraphProcessor) upsertDimensions(kind string, m map[string]strin
 	}
 }
 
+func (p *serviceGraphProcessor) upsertPeerAttributes(m map[string]string, spanAttr pcommon.Map) {
+	for _, attr := range p.peerAttributes {
+		if v, ok := findAttributeValue(attr, spanAttr); ok {
+			m[attr] = v
+		}
+	}
+}
+
 func (p *serviceGraphProcessor) onComplete(e *store.Edge) {
 	p.logger.Debug(
 		"edge completed",
@@ -288,9 +328,31 @@ func (p *serviceGraphProcessor) onExpire(e *store.Edge) {
 		zap.String("connection_type", string(e.ConnectionType)),
 		zap.Stringer("trace_id", e.TraceID),
 	)
+
+	// The server of an expired client span may not be instrumented, like databases or external APIs.
+	// Complete the edge with a virtual node named after the peer of the client span, if any.
+	if len(e.ClientService) != 0 && len(e.ServerService) == 0 {
+		if peer, ok := p.virtualNodeName(e); ok {
+			e.ServerService = peer
+			e.ServerLatencySec = e.ClientLatencySec
+			e.ConnectionType = store.VirtualNode
+			p.onComplete(e)
+			return
+		}
+	}
 	stats.Record(context.Background(), statExpiredEdges.M(1))
 }
 
+// virtualNodeName returns the value of the first peer attribute of the edge, in the configured order
+func (p *serviceGraphProcessor) virtualNodeName(e *store.Edge) (string, bool) {
+	for _, attr := range p.peerAttributes {
+		if v, ok := e.Peer[attr]; ok && v != "" {
+			return v, true
+		}
+	}
+	return "", false
+}
+
 func (p *serviceGraphProcessor) aggregateMetricsForEdge(e *store.Edge) {
 	metricKey := p.buildMetricKey(e.ClientService, e.ServerService, string(e.ConnectionType), e.Dimensions)
 	dimensions := buildDimensions(e)
@@ -35,6 +35,8 @@ import (
 	"go.opentelemetry.io/collector/processor/processortest"
 	semconv "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor/internal/store"
 )
 
 func TestProcessorStart(t *testing.T) {
@@ -126,6 +128,159 @@ func TestProcessorConsume(t *testing.T) {
 	assert.NoError(t, processor.Shutdown(context.Background()))
 }
 
+func TestProcessorVirtualNode(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		configured     []string
+		peerAttributes map[string]string
+		expectedServer string
+	}{
+		{
+			name:           "peer service",
+			peerAttributes: map[string]string{semconv.AttributePeerService: "external-api", semconv.AttributeNetPeerName: "api.example.com"},
+			expectedServer: "external-api",
+		},
+		{
+			name:           "net peer name",
+			peerAttributes: map[string]string{semconv.AttributeNetPeerName: "api.example.com"},
+			expectedServer: "api.example.com",
+		},
+		{
+			name:           "messaging system",
+			peerAttributes: map[string]string{semconv.AttributeMessagingSystem: "kafka"},
+			expectedServer: "kafka",
+		},
+		{
+			name:           "configured peer attributes",
+			configured:     []string{"some-attribute"},
+			peerAttributes: map[string]string{semconv.AttributePeerService: "external-api", "some-attribute": "val"},
+			expectedServer: "val",
+		},
+		{
+			name:           "no peer attributes",
+			peerAttributes: map[string]string{"some-attribute": "val"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			cfg := createDefaultConfig().(*Config)
+			cfg.VirtualNodePeerAttributes = tc.configured
+			p := newProcessor(zaptest.NewLogger(t), cfg, consumertest.NewNop())
+			// edges expire right away
+			p.store = store.NewStore(-time.Second, cfg.Store.MaxItems, p.onComplete, p.onExpire)
+
+			td := ptrace.NewTraces()
+			rs := td.ResourceSpans().AppendEmpty()
+			rs.Resource().Attributes().PutStr(semconv.AttributeServiceName, "some-service")
+			span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetTraceID([16]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10})
+			span.SetSpanID([8]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18})
+			span.SetKind(ptrace.SpanKindClient)
+			for k, v := range tc.peerAttributes {
+				span.Attributes().PutStr(k, v)
+			}
+
+			// Test
+			require.NoError(t, p.aggregateMetrics(context.Background(), td))
+			p.store.Expire()
+			md, err := p.buildMetrics()
+			require.NoError(t, err)
+
+			// Verify
+			if tc.expectedServer == "" {
+				assert.Equal(t, 0, md.MetricCount())
+				return
+			}
+			require.Equal(t, 2, md.MetricCount())
+			attributes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Attributes()
+			verifyAttr(t, attributes, "client", "some-service")
+			verifyAttr(t, attributes, "server", tc.expectedServer)
+			verifyAttr(t, attributes, "connection_type", string(store.VirtualNode))
+		})
+	}
+}
+
+func TestProcessorMessagingEdges(t *testing.T) {
+	producerTraceID := pcommon.TraceID([16]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10})
+	consumerTraceID := pcommon.TraceID([16]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1A, 0x1B, 0x1C, 0x1D, 0x1E, 0x1F, 0x20})
+	producerSpanID := pcommon.SpanID([8]byte{0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18})
+	otherProducerSpanID := pcommon.SpanID([8]byte{0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28})
+
+	for _, tc := range []struct {
+		name          string
+		buildConsumer func(span ptrace.Span)
+		expectedCount int64
+	}{
+		{
+			name: "child of the producer span",
+			buildConsumer: func(span ptrace.Span) {
+				span.SetTraceID(producerTraceID)
+				span.SetParentSpanID(producerSpanID)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "linked to the producer span",
+			buildConsumer: func(span ptrace.Span) {
+				span.SetTraceID(consumerTraceID)
+				link := span.Links().AppendEmpty()
+				link.SetTraceID(producerTraceID)
+				link.SetSpanID(producerSpanID)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "linked to multiple producer spans",
+			buildConsumer: func(span ptrace.Span) {
+				span.SetTraceID(consumerTraceID)
+				for _, spanID := range []pcommon.SpanID{producerSpanID, otherProducerSpanID} {
+					link := span.Links().AppendEmpty()
+					link.SetTraceID(producerTraceID)
+					link.SetSpanID(spanID)
+				}
+			},
+			expectedCount: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			cfg := createDefaultConfig().(*Config)
+			p := newProcessor(zaptest.NewLogger(t), cfg, consumertest.NewNop())
+			p.store = store.NewStore(time.Hour, cfg.Store.MaxItems, p.onComplete, p.onExpire)
+
+			td := ptrace.NewTraces()
+			producerSpans := td.ResourceSpans().AppendEmpty()
+			producerSpans.Resource().Attributes().PutStr(semconv.AttributeServiceName, "producer-service")
+			for _, spanID := range []pcommon.SpanID{producerSpanID, otherProducerSpanID} {
+				span := producerSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+				span.SetTraceID(producerTraceID)
+				span.SetSpanID(spanID)
+				span.SetKind(ptrace.SpanKindProducer)
+			}
+
+			consumerSpans := td.ResourceSpans().AppendEmpty()
+			consumerSpans.Resource().Attributes().PutStr(semconv.AttributeServiceName, "consumer-service")
+			span := consumerSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetSpanID([8]byte{0x31, 0x32, 0x33, 0x34, 0x35, 0x36, 0x37, 0x38})
+			span.SetKind(ptrace.SpanKindConsumer)
+			tc.buildConsumer(span)
+
+			// Test
+			require.NoError(t, p.aggregateMetrics(context.Background(), td))
+			md, err := p.buildMetrics()
+			require.NoError(t, err)
+
+			// Verify
+			require.Equal(t, 2, md.MetricCount())
+			dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+			assert.Equal(t, tc.expectedCount, dp.IntValue())
+			verifyAttr(t, dp.Attributes(), "client", "producer-service")
+			verifyAttr(t, dp.Attributes(), "server", "consumer-service")
+			verifyAttr(t, dp.Attributes(), "connection_type", string(store.MessagingSystem))
+		})
+	}
+}
+
 func verifyMetrics(t *testing.T, md pmetric.Metrics) error {
 	assert.Equal(t, 2, md.MetricCount())
 
@@ -15,25 +15,25 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
-	"go.uber.org/zap"
+	"go.opentelemetry.io/collector/component"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
 
-func getNewAndPolicy(logger *zap.Logger, config *AndCfg) (sampling.PolicyEvaluator, error) {
+func getNewAndPolicy(settings component.TelemetrySettings, config *AndCfg) (sampling.PolicyEvaluator, error) {
 	var subPolicyEvaluators []sampling.PolicyEvaluator
 	for i := range config.SubPolicyCfg {
 		policyCfg := &config.SubPolicyCfg[i]
-		policy, err := getAndSubPolicyEvaluator(logger, policyCfg)
+		policy, err := getAndSubPolicyEvaluator(settings, policyCfg)
 		if err != nil {
 			return nil, err
 		}
 		subPolicyEvaluators = append(subPolicyEvaluators, policy)
 	}
-	return sampling.NewAnd(logger, subPolicyEvaluators), nil
+	return sampling.NewAnd(settings.Logger, subPolicyEvaluators), nil
 }
 
 // Return instance of and sub-policy
-func getAndSubPolicyEvaluator(logger *zap.Logger, cfg *AndSubPolicyCfg) (sampling.PolicyEvaluator, error) {
-	return getSharedPolicyEvaluator(logger, &cfg.sharedPolicyCfg)
+func getAndSubPolicyEvaluator(settings component.TelemetrySettings, cfg *AndSubPolicyCfg) (sampling.PolicyEvaluator, error) {
+	return getSharedPolicyEvaluator(settings, &cfg.sharedPolicyCfg)
 }
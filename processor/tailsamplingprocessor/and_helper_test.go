@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
@@ -26,7 +27,7 @@ import (
 
 func TestAndHelper(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
-		actual, err := getNewAndPolicy(zap.NewNop(), &AndCfg{
+		actual, err := getNewAndPolicy(componenttest.NewNopTelemetrySettings(), &AndCfg{
 			SubPolicyCfg: []AndSubPolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -45,8 +46,44 @@ func TestAndHelper(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	})
 
+	t.Run("span scope", func(t *testing.T) {
+		actual, err := getNewAndPolicy(componenttest.NewNopTelemetrySettings(), &AndCfg{
+			SubPolicyCfg: []AndSubPolicyCfg{
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name:                "test-and-policy-1",
+						Type:                BooleanAttribute,
+						BooleanAttributeCfg: BooleanAttributeCfg{Key: "error", Value: true},
+						SpanScopeCfg:        SpanScopeCfg{SpanKinds: []string{"server"}},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		scoped, err := sampling.NewSpanScope(zap.NewNop(), sampling.NewBooleanAttributeFilter(zap.NewNop(), "error", true), false, []string{"server"})
+		require.NoError(t, err)
+		expected := sampling.NewAnd(zap.NewNop(), []sampling.PolicyEvaluator{scoped})
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("invalid span scope", func(t *testing.T) {
+		_, err := getNewAndPolicy(componenttest.NewNopTelemetrySettings(), &AndCfg{
+			SubPolicyCfg: []AndSubPolicyCfg{
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name:         "test-and-policy-1",
+						Type:         AlwaysSample,
+						SpanScopeCfg: SpanScopeCfg{SpanKinds: []string{"remote"}},
+					},
+				},
+			},
+		})
+		require.EqualError(t, err, `unknown span kind "remote", supported: unspecified, internal, server, client, producer, consumer`)
+	})
+
 	t.Run("unsupported sampling policy type", func(t *testing.T) {
-		_, err := getNewAndPolicy(zap.NewNop(), &AndCfg{
+		_, err := getNewAndPolicy(componenttest.NewNopTelemetrySettings(), &AndCfg{
 			SubPolicyCfg: []AndSubPolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
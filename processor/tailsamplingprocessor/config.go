@@ -29,6 +29,9 @@ const (
 	// NumericAttribute sample traces that have a given numeric attribute in a specified
 	// range, e.g.: attribute "http.status_code" >= 399 and <= 999.
 	NumericAttribute PolicyType = "numeric_attribute"
+	// BooleanAttribute sample traces that have a given boolean attribute with the given value,
+	// e.g.: attribute "error" == true.
+	BooleanAttribute PolicyType = "boolean_attribute"
 	// Probabilistic samples a given percentage of traces.
 	Probabilistic PolicyType = "probabilistic"
 	// StatusCode sample traces that have a given status code.
@@ -46,6 +49,9 @@ const (
 	SpanCount PolicyType = "span_count"
 	// TraceState sample traces with specified values by the given key
 	TraceState PolicyType = "trace_state"
+	// OTTLCondition sample traces which match user provided OpenTelemetry Transformation Language
+	// conditions on any of their spans or span events.
+	OTTLCondition PolicyType = "ottl_condition"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -59,6 +65,8 @@ type sharedPolicyCfg struct {
 	LatencyCfg LatencyCfg `mapstructure:"latency"`
 	// Configs for numeric attribute filter sampling policy evaluator.
 	NumericAttributeCfg NumericAttributeCfg `mapstructure:"numeric_attribute"`
+	// Configs for boolean attribute filter sampling policy evaluator.
+	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
 	// Configs for probabilistic sampling policy evaluator.
 	ProbabilisticCfg ProbabilisticCfg `mapstructure:"probabilistic"`
 	// Configs for status code filter sampling policy evaluator.
@@ -71,6 +79,10 @@ type sharedPolicyCfg struct {
 	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
 	// Configs for defining trace_state policy
 	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for OTTL condition filter sampling policy evaluator
+	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
+	// SpanScopeCfg restricts the spans the policy is evaluated against.
+	SpanScopeCfg SpanScopeCfg `mapstructure:"span_scope"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -140,6 +152,25 @@ type NumericAttributeCfg struct {
 	MaxValue int64 `mapstructure:"max_value"`
 }
 
+// BooleanAttributeCfg holds the configurable settings to create a boolean attribute filter
+// sampling policy evaluator.
+type BooleanAttributeCfg struct {
+	// Tag that the filter is going to be matching against.
+	Key string `mapstructure:"key"`
+	// Value indicate the bool value, either true or false to use when matching against attribute values.
+	Value bool `mapstructure:"value"`
+}
+
+// SpanScopeCfg holds the configurable settings to restrict a sampling policy evaluator
+// to a subset of the spans of the trace. Traces without any span in scope are not sampled by the policy.
+type SpanScopeCfg struct {
+	// RootSpansOnly restricts the policy to the root spans of the trace, i.e. the spans without parent.
+	RootSpansOnly bool `mapstructure:"root_spans_only"`
+	// SpanKinds restricts the policy to spans of the given kinds: unspecified, internal, server,
+	// client, producer or consumer.
+	SpanKinds []string `mapstructure:"span_kinds"`
+}
+
 // ProbabilisticCfg holds the configurable settings to create a probabilistic
 // sampling policy evaluator.
 type ProbabilisticCfg struct {
@@ -158,6 +189,15 @@ type StatusCodeCfg struct {
 	StatusCodes []string `mapstructure:"status_codes"`
 }
 
+// OTTLConditionCfg holds the configurable setting to create a OTTL condition filter
+// sampling policy evaluator.
+type OTTLConditionCfg struct {
+	// SpanConditions are the OTTL conditions evaluated against every span of the trace.
+	SpanConditions []string `mapstructure:"span"`
+	// SpanEventConditions are the OTTL conditions evaluated against every span event of the trace.
+	SpanEventConditions []string `mapstructure:"spanevent"`
+}
+
 // StringAttributeCfg holds the configurable settings to create a string attribute filter
 // sampling policy evaluator.
 type StringAttributeCfg struct {
@@ -206,4 +246,17 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// DecisionCache holds the configuration of the caches of recent sampling decisions,
+	// which are applied to the spans arriving after their trace was released from memory.
+	DecisionCache DecisionCacheConfig `mapstructure:"decision_cache"`
+}
+
+// DecisionCacheConfig holds the configuration of the caches of the sampling decisions, keyed by trace ID.
+type DecisionCacheConfig struct {
+	// SampledCacheSize is the maximum number of trace IDs of sampled traces kept in the cache.
+	// The cache is disabled when set to 0.
+	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize is the maximum number of trace IDs of not sampled traces kept in the cache.
+	// The cache is disabled when set to 0.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
 }
@@ -43,6 +43,7 @@ func TestLoadConfig(t *testing.T) {
 		&Config{
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
+			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 1000, NonSampledCacheSize: 10000},
 			ExpectedNewTracesPerSec: 10,
 			PolicyCfgs: []PolicyCfg{
 				{
@@ -107,6 +108,24 @@ func TestLoadConfig(t *testing.T) {
 						TraceStateCfg: TraceStateCfg{Key: "key3", Values: []string{"value1", "value2"}},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-10",
+						Type: OTTLCondition,
+						OTTLConditionCfg: OTTLConditionCfg{
+							SpanConditions:      []string{`attributes["http.status_code"] >= 500`, `name == "checkout"`},
+							SpanEventConditions: []string{`name == "exception"`},
+						},
+					},
+				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name:                "test-policy-11",
+						Type:                BooleanAttribute,
+						BooleanAttributeCfg: BooleanAttributeCfg{Key: "key4", Value: true},
+						SpanScopeCfg:        SpanScopeCfg{RootSpansOnly: true, SpanKinds: []string{"server"}},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache defines the caches of the sampling decisions, keyed by trace ID.
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// Cache is a cache using a pcommon.TraceID for the key and any generic type for the value.
+type Cache[V any] interface {
+	// Get returns the value for the given id, and a boolean to indicate whether the key was found.
+	// If the key is not present, the zero value is returned.
+	Get(id pcommon.TraceID) (V, bool)
+	// Put sets the value for a given id
+	Put(id pcommon.TraceID, v V)
+	// Delete deletes the value for the given id
+	Delete(id pcommon.TraceID)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/golang/groupcache/lru"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ErrInvalidSize occurs when an invalid cache size is specified.
+var ErrInvalidSize = errors.New("invalid cache size, it must be greater than zero")
+
+// lruDecisionCache implements Cache as a bounded LRU cache, safe for concurrent use
+type lruDecisionCache[V any] struct {
+	mu  sync.Mutex
+	lru *lru.Cache
+}
+
+var _ Cache[bool] = (*lruDecisionCache[bool])(nil)
+
+// NewLRUDecisionCache returns a new lruDecisionCache holding up to size entries,
+// evicting the least recently used ones first.
+func NewLRUDecisionCache[V any](size int) (Cache[V], error) {
+	if size <= 0 {
+		return nil, ErrInvalidSize
+	}
+	return &lruDecisionCache[V]{lru: lru.New(size)}, nil
+}
+
+func (c *lruDecisionCache[V]) Get(id pcommon.TraceID) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.lru.Get(id); ok {
+		return v.(V), true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *lruDecisionCache[V]) Put(id pcommon.TraceID, v V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Add(id, v)
+}
+
+func (c *lruDecisionCache[V]) Delete(id pcommon.TraceID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Remove(id)
+}
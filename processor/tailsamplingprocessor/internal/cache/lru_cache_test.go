@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestLRUDecisionCache(t *testing.T) {
+	c, err := NewLRUDecisionCache[bool](2)
+	require.NoError(t, err)
+
+	id1 := pcommon.TraceID([16]byte{1})
+	id2 := pcommon.TraceID([16]byte{2})
+	id3 := pcommon.TraceID([16]byte{3})
+
+	c.Put(id1, true)
+	v, ok := c.Get(id1)
+	assert.True(t, ok)
+	assert.True(t, v)
+
+	// id1 is the most recently used, so id2 gets evicted first
+	c.Put(id2, true)
+	_, ok = c.Get(id1)
+	assert.True(t, ok)
+	c.Put(id3, true)
+	_, ok = c.Get(id2)
+	assert.False(t, ok)
+	_, ok = c.Get(id1)
+	assert.True(t, ok)
+	_, ok = c.Get(id3)
+	assert.True(t, ok)
+
+	c.Delete(id1)
+	v, ok = c.Get(id1)
+	assert.False(t, ok)
+	assert.False(t, v)
+}
+
+func TestLRUDecisionCacheInvalidSize(t *testing.T) {
+	c, err := NewLRUDecisionCache[bool](0)
+	assert.Nil(t, c)
+	assert.Equal(t, ErrInvalidSize, err)
+}
+
+func TestNopDecisionCache(t *testing.T) {
+	c := NewNopDecisionCache[bool]()
+	id := pcommon.TraceID([16]byte{1})
+
+	c.Put(id, true)
+	_, ok := c.Get(id)
+	assert.False(t, ok)
+	c.Delete(id)
+}
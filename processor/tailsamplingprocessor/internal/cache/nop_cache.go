@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import "go.opentelemetry.io/collector/pdata/pcommon"
+
+// nopDecisionCache implements Cache without holding any entry, used when the cache is disabled
+type nopDecisionCache[V any] struct{}
+
+var _ Cache[bool] = (*nopDecisionCache[bool])(nil)
+
+// NewNopDecisionCache returns a Cache which never holds any entry.
+func NewNopDecisionCache[V any]() Cache[V] {
+	return &nopDecisionCache[V]{}
+}
+
+func (n *nopDecisionCache[V]) Get(pcommon.TraceID) (V, bool) {
+	var zero V
+	return zero, false
+}
+
+func (n *nopDecisionCache[V]) Put(pcommon.TraceID, V) {}
+
+func (n *nopDecisionCache[V]) Delete(pcommon.TraceID) {}
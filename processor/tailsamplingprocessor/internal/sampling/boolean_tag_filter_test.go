@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func TestBooleanTagFilter(t *testing.T) {
+
+	var empty = map[string]interface{}{}
+	filter := NewBooleanAttributeFilter(zap.NewNop(), "example", true)
+
+	resAttr := map[string]interface{}{}
+	resAttr["example"] = true
+
+	cases := []struct {
+		Desc     string
+		Trace    *TraceData
+		Decision Decision
+	}{
+		{
+			Desc:     "nonmatching span attribute key",
+			Trace:    newTraceBoolAttrs(empty, "non_matching", true),
+			Decision: NotSampled,
+		},
+		{
+			Desc:     "nonmatching span attribute value",
+			Trace:    newTraceBoolAttrs(empty, "example", false),
+			Decision: NotSampled,
+		},
+		{
+			Desc:     "matching span attribute",
+			Trace:    newTraceBoolAttrs(empty, "example", true),
+			Decision: Sampled,
+		},
+		{
+			Desc:     "matching resource attribute",
+			Trace:    newTraceBoolAttrs(resAttr, "non_matching", false),
+			Decision: Sampled,
+		},
+		{
+			Desc:     "nonmatching attribute type",
+			Trace:    newTraceStringAttrs(map[string]interface{}{"example": "true"}, "example", "true"),
+			Decision: NotSampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			u, _ := uuid.NewRandom()
+			decision, err := filter.Evaluate(pcommon.TraceID(u), c.Trace)
+			assert.NoError(t, err)
+			assert.Equal(t, decision, c.Decision)
+		})
+	}
+}
+
+func TestBooleanTagFilterFalseValue(t *testing.T) {
+	filter := NewBooleanAttributeFilter(zap.NewNop(), "example", false)
+
+	u, _ := uuid.NewRandom()
+	decision, err := filter.Evaluate(pcommon.TraceID(u), newTraceBoolAttrs(map[string]interface{}{}, "example", false))
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+
+	decision, err = filter.Evaluate(pcommon.TraceID(u), newTraceBoolAttrs(map[string]interface{}{}, "example", true))
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func newTraceBoolAttrs(nodeAttrs map[string]interface{}, spanAttrKey string, spanAttrValue bool) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	//nolint:errcheck
+	rs.Resource().Attributes().FromRaw(nodeAttrs)
+	ils := rs.ScopeSpans().AppendEmpty()
+	span := ils.Spans().AppendEmpty()
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.Attributes().PutBool(spanAttrKey, spanAttrValue)
+	return &TraceData{
+		ReceivedBatches: traces,
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestOTTLConditionFilterConfig(t *testing.T) {
+	_, err := NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), nil, nil)
+	assert.Error(t, err)
+
+	_, err = NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), []string{`attributes["key"] ==`}, nil)
+	assert.Error(t, err)
+
+	_, err = NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), nil, []string{`unknown == "value"`})
+	assert.Error(t, err)
+}
+
+func TestEvaluate_OTTLConditionFilter(t *testing.T) {
+	cases := []struct {
+		Desc                string
+		SpanConditions      []string
+		SpanEventConditions []string
+		Spans               []ottlTestSpan
+		Decision            Decision
+	}{
+		{
+			Desc:           "matching span attribute",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Spans:          []ottlTestSpan{{SpanAttributes: map[string]interface{}{"http.status_code": 503}}},
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "nonmatching span attribute",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Spans:          []ottlTestSpan{{SpanAttributes: map[string]interface{}{"http.status_code": 200}}},
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "missing span attribute",
+			SpanConditions: []string{`attributes["http.status_code"] >= 500`},
+			Spans:          []ottlTestSpan{{SpanAttributes: map[string]interface{}{"other": "value"}}},
+			Decision:       NotSampled,
+		},
+		{
+			Desc:           "matching any of the span conditions",
+			SpanConditions: []string{`name == "other"`, `IsMatch(attributes["url"], ".*/checkout$") == true`},
+			Spans:          []ottlTestSpan{{SpanAttributes: map[string]interface{}{"url": "https://shop/checkout"}}},
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "matching resource attribute",
+			SpanConditions: []string{`resource.attributes["service.name"] == "checkout"`},
+			Spans:          []ottlTestSpan{{ResourceAttributes: map[string]interface{}{"service.name": "checkout"}}},
+			Decision:       Sampled,
+		},
+		{
+			Desc:           "matching second span of the trace",
+			SpanConditions: []string{`attributes["error"] == true`},
+			Spans: []ottlTestSpan{
+				{SpanAttributes: map[string]interface{}{"error": false}},
+				{SpanAttributes: map[string]interface{}{"error": true}},
+			},
+			Decision: Sampled,
+		},
+		{
+			Desc:                "matching span event",
+			SpanEventConditions: []string{`name == "exception"`},
+			Spans:               []ottlTestSpan{{EventName: "exception"}},
+			Decision:            Sampled,
+		},
+		{
+			Desc:                "matching span event attribute",
+			SpanEventConditions: []string{`attributes["exception.type"] == "NullPointerException"`},
+			Spans: []ottlTestSpan{{
+				EventName:       "exception",
+				EventAttributes: map[string]interface{}{"exception.type": "NullPointerException"},
+			}},
+			Decision: Sampled,
+		},
+		{
+			Desc:                "nonmatching span event",
+			SpanEventConditions: []string{`name == "exception"`},
+			Spans:               []ottlTestSpan{{EventName: "message"}},
+			Decision:            NotSampled,
+		},
+		{
+			Desc:                "span event conditions without span events",
+			SpanEventConditions: []string{`name == "exception"`},
+			Spans:               []ottlTestSpan{{}},
+			Decision:            NotSampled,
+		},
+		{
+			Desc:                "matching span event with nonmatching span condition",
+			SpanConditions:      []string{`name == "other"`},
+			SpanEventConditions: []string{`name == "exception"`},
+			Spans:               []ottlTestSpan{{EventName: "exception"}},
+			Decision:            Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Desc, func(t *testing.T) {
+			filter, err := NewOTTLConditionFilter(componenttest.NewNopTelemetrySettings(), c.SpanConditions, c.SpanEventConditions)
+			require.NoError(t, err)
+
+			decision, err := filter.Evaluate(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}), newTraceWithOTTLSpans(c.Spans))
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+type ottlTestSpan struct {
+	ResourceAttributes map[string]interface{}
+	SpanAttributes     map[string]interface{}
+	EventName          string
+	EventAttributes    map[string]interface{}
+}
+
+func newTraceWithOTTLSpans(spans []ottlTestSpan) *TraceData {
+	traces := ptrace.NewTraces()
+	for _, s := range spans {
+		rs := traces.ResourceSpans().AppendEmpty()
+		//nolint:errcheck
+		rs.Resource().Attributes().FromRaw(s.ResourceAttributes)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+		span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+		//nolint:errcheck
+		span.Attributes().FromRaw(s.SpanAttributes)
+		if s.EventName != "" {
+			event := span.Events().AppendEmpty()
+			event.SetName(s.EventName)
+			//nolint:errcheck
+			event.Attributes().FromRaw(s.EventAttributes)
+		}
+	}
+	return &TraceData{
+		ReceivedBatches: traces,
+	}
+}
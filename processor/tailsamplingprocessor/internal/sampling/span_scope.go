@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+var spanKinds = map[string]ptrace.SpanKind{
+	"unspecified": ptrace.SpanKindUnspecified,
+	"internal":    ptrace.SpanKindInternal,
+	"server":      ptrace.SpanKindServer,
+	"client":      ptrace.SpanKindClient,
+	"producer":    ptrace.SpanKindProducer,
+	"consumer":    ptrace.SpanKindConsumer,
+}
+
+type spanScope struct {
+	evaluator     PolicyEvaluator
+	rootSpansOnly bool
+	spanKinds     map[ptrace.SpanKind]struct{}
+	logger        *zap.Logger
+}
+
+var _ PolicyEvaluator = (*spanScope)(nil)
+
+// NewSpanScope creates a policy evaluator that evaluates the given policy evaluator only against
+// the spans in scope: the root spans if rootSpansOnly is set, and the spans of the given kinds,
+// if any. Traces without any span in scope are not sampled.
+func NewSpanScope(logger *zap.Logger, evaluator PolicyEvaluator, rootSpansOnly bool, kinds []string) (PolicyEvaluator, error) {
+	kindsMap := make(map[ptrace.SpanKind]struct{}, len(kinds))
+	for _, kind := range kinds {
+		spanKind, ok := spanKinds[strings.ToLower(kind)]
+		if !ok {
+			return nil, fmt.Errorf("unknown span kind %q, supported: unspecified, internal, server, client, producer, consumer", kind)
+		}
+		kindsMap[spanKind] = struct{}{}
+	}
+
+	return &spanScope{
+		evaluator:     evaluator,
+		rootSpansOnly: rootSpansOnly,
+		spanKinds:     kindsMap,
+		logger:        logger,
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+// The SamplingDecision is made by the wrapped evaluator, on a copy of the trace holding only the spans in scope.
+func (ss *spanScope) Evaluate(traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	scoped := &TraceData{
+		ArrivalTime:  trace.ArrivalTime,
+		DecisionTime: trace.DecisionTime,
+	}
+	trace.Unlock()
+
+	var spanCount int64
+	scoped.ReceivedBatches, spanCount = ss.scopeSpans(batches)
+	if spanCount == 0 {
+		ss.logger.Debug("No spans in scope of the policy")
+		return NotSampled, nil
+	}
+	scoped.SpanCount = atomic.NewInt64(spanCount)
+
+	return ss.evaluator.Evaluate(traceID, scoped)
+}
+
+// scopeSpans returns a copy of the traces holding only the spans in scope, along with their number
+func (ss *spanScope) scopeSpans(td ptrace.Traces) (ptrace.Traces, int64) {
+	scoped := ptrace.NewTraces()
+	var count int64
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		scopedRs := ptrace.NewResourceSpans()
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ils := rs.ScopeSpans().At(j)
+			scopedIls := ptrace.NewScopeSpans()
+			for k := 0; k < ils.Spans().Len(); k++ {
+				span := ils.Spans().At(k)
+				if ss.inScope(span) {
+					span.CopyTo(scopedIls.Spans().AppendEmpty())
+				}
+			}
+			if scopedIls.Spans().Len() > 0 {
+				ils.Scope().CopyTo(scopedIls.Scope())
+				scopedIls.SetSchemaUrl(ils.SchemaUrl())
+				count += int64(scopedIls.Spans().Len())
+				scopedIls.MoveTo(scopedRs.ScopeSpans().AppendEmpty())
+			}
+		}
+		if scopedRs.ScopeSpans().Len() > 0 {
+			rs.Resource().CopyTo(scopedRs.Resource())
+			scopedRs.SetSchemaUrl(rs.SchemaUrl())
+			scopedRs.MoveTo(scoped.ResourceSpans().AppendEmpty())
+		}
+	}
+	return scoped, count
+}
+
+func (ss *spanScope) inScope(span ptrace.Span) bool {
+	if ss.rootSpansOnly && !span.ParentSpanID().IsEmpty() {
+		return false
+	}
+	if len(ss.spanKinds) == 0 {
+		return true
+	}
+	_, ok := ss.spanKinds[span.Kind()]
+	return ok
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+func TestSpanScope(t *testing.T) {
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	errorFilter := NewBooleanAttributeFilter(zap.NewNop(), "error", true)
+
+	cases := []struct {
+		Desc          string
+		Evaluator     PolicyEvaluator
+		RootSpansOnly bool
+		SpanKinds     []string
+		Decision      Decision
+	}{
+		{
+			Desc:      "all spans in scope",
+			Evaluator: errorFilter,
+			SpanKinds: []string{"server", "client"},
+			Decision:  Sampled,
+		},
+		{
+			Desc:          "root spans only",
+			Evaluator:     errorFilter,
+			RootSpansOnly: true,
+			Decision:      NotSampled,
+		},
+		{
+			Desc:      "matching span kind",
+			Evaluator: errorFilter,
+			SpanKinds: []string{"CLIENT"},
+			Decision:  Sampled,
+		},
+		{
+			Desc:      "nonmatching span kind",
+			Evaluator: errorFilter,
+			SpanKinds: []string{"server", "consumer"},
+			Decision:  NotSampled,
+		},
+		{
+			Desc:          "no spans in scope",
+			Evaluator:     NewAlwaysSample(zap.NewNop()),
+			RootSpansOnly: true,
+			SpanKinds:     []string{"client"},
+			Decision:      NotSampled,
+		},
+		{
+			Desc:      "span count of spans in scope",
+			Evaluator: NewSpanCount(zap.NewNop(), 2),
+			SpanKinds: []string{"client"},
+			Decision:  NotSampled,
+		},
+		{
+			Desc:      "span count of all spans",
+			Evaluator: NewSpanCount(zap.NewNop(), 2),
+			SpanKinds: []string{"client", "server"},
+			Decision:  Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Desc, func(t *testing.T) {
+			scope, err := NewSpanScope(zap.NewNop(), c.Evaluator, c.RootSpansOnly, c.SpanKinds)
+			require.NoError(t, err)
+
+			trace := newTraceWithKinds()
+			decision, err := scope.Evaluate(traceID, trace)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+
+			// the trace itself is left untouched
+			assert.Equal(t, 2, trace.ReceivedBatches.SpanCount())
+		})
+	}
+}
+
+func TestSpanScopeUnknownSpanKind(t *testing.T) {
+	scope, err := NewSpanScope(zap.NewNop(), NewAlwaysSample(zap.NewNop()), false, []string{"server", "unknown"})
+	assert.Nil(t, scope)
+	assert.EqualError(t, err, `unknown span kind "unknown", supported: unspecified, internal, server, client, producer, consumer`)
+}
+
+// newTraceWithKinds returns a trace with a root server span and an erroneous client child span
+func newTraceWithKinds() *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "frontend")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+
+	root := spans.AppendEmpty()
+	root.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	root.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	root.SetKind(ptrace.SpanKindServer)
+	root.Attributes().PutBool("error", false)
+
+	child := spans.AppendEmpty()
+	child.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	child.SetSpanID([8]byte{2, 2, 3, 4, 5, 6, 7, 8})
+	child.SetParentSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	child.SetKind(ptrace.SpanKindClient)
+	child.Attributes().PutBool("error", true)
+
+	return &TraceData{
+		ReceivedBatches: traces,
+		SpanCount:       atomic.NewInt64(2),
+	}
+}
@@ -33,6 +33,7 @@ var (
 
 	statTraceRemovalAgeSec           = stats.Int64("sampling_trace_removal_age", "Time (in seconds) from arrival of a new trace until its removal from memory", "s")
 	statLateSpanArrivalAfterDecision = stats.Int64("sampling_late_span_age", "Time (in seconds) from the sampling decision was taken and the arrival of a late span", "s")
+	statLateSpanCachedDecisionCount  = stats.Int64("sampling_late_span_cached_decision", "Count of late spans which inherited the cached decision of their trace", stats.UnitDimensionless)
 
 	statPolicyEvaluationErrorCount = stats.Int64("sampling_policy_evaluation_error", "Count of sampling policy evaluation errors", stats.UnitDimensionless)
 
@@ -81,6 +82,13 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: ageDistributionAggregation,
 	}
 
+	lateSpanCachedDecisionView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statLateSpanCachedDecisionCount.Name()),
+		Measure:     statLateSpanCachedDecisionCount,
+		Description: statLateSpanCachedDecisionCount.Description(),
+		Aggregation: view.Sum(),
+	}
+
 	countPolicyEvaluationErrorView := &view.View{
 		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statPolicyEvaluationErrorCount.Name()),
 		Measure:     statPolicyEvaluationErrorCount,
@@ -122,6 +130,7 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 
 		traceRemovalAgeView,
 		lateSpanArrivalView,
+		lateSpanCachedDecisionView,
 
 		countPolicyEvaluationErrorView,
 
@@ -32,6 +32,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -61,6 +62,10 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pcommon.TraceID
 	numTracesOnMap  *atomic.Uint64
+
+	// decision caches of the traces released from memory, so late spans inherit their decision
+	sampledIDCache    cache.Cache[bool]
+	nonSampledIDCache cache.Cache[bool]
 }
 
 const (
@@ -69,7 +74,7 @@ const (
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform tail sampling according to the given
 // configuration.
-func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Config) (processor.Traces, error) {
+func newTracesProcessor(settings component.TelemetrySettings, nextConsumer consumer.Traces, cfg Config) (processor.Traces, error) {
 	if nextConsumer == nil {
 		return nil, component.ErrNilNextConsumer
 	}
@@ -88,7 +93,7 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		if err != nil {
 			return nil, err
 		}
-		eval, err := getPolicyEvaluator(logger, policyCfg)
+		eval, err := getPolicyEvaluator(settings, policyCfg)
 		if err != nil {
 			return nil, err
 		}
@@ -100,15 +105,27 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		policies = append(policies, p)
 	}
 
+	sampledIDCache, err := newDecisionCache(cfg.DecisionCache.SampledCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decision_cache.sampled_cache_size: %w", err)
+	}
+	nonSampledIDCache, err := newDecisionCache(cfg.DecisionCache.NonSampledCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decision_cache.non_sampled_cache_size: %w", err)
+	}
+
 	tsp := &tailSamplingSpanProcessor{
 		ctx:             ctx,
 		nextConsumer:    nextConsumer,
 		maxNumTraces:    cfg.NumTraces,
-		logger:          logger,
+		logger:          settings.Logger,
 		decisionBatcher: inBatcher,
 		policies:        policies,
 		tickerFrequency: time.Second,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    sampledIDCache,
+		nonSampledIDCache: nonSampledIDCache,
 	}
 
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
@@ -117,18 +134,49 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 	return tsp, nil
 }
 
-func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
+// newDecisionCache returns a cache of decisions holding up to size trace IDs, or a noop one if size is 0
+func newDecisionCache(size int) (cache.Cache[bool], error) {
+	if size == 0 {
+		return cache.NewNopDecisionCache[bool](), nil
+	}
+	return cache.NewLRUDecisionCache[bool](size)
+}
+
+func getPolicyEvaluator(settings component.TelemetrySettings, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
+	var evaluator sampling.PolicyEvaluator
+	var err error
 	switch cfg.Type {
 	case Composite:
-		return getNewCompositePolicy(logger, &cfg.CompositeCfg)
+		evaluator, err = getNewCompositePolicy(settings, &cfg.CompositeCfg)
 	case And:
-		return getNewAndPolicy(logger, &cfg.AndCfg)
+		evaluator, err = getNewAndPolicy(settings, &cfg.AndCfg)
 	default:
-		return getSharedPolicyEvaluator(logger, &cfg.sharedPolicyCfg)
+		return getSharedPolicyEvaluator(settings, &cfg.sharedPolicyCfg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return withSpanScope(settings, cfg.SpanScopeCfg, evaluator)
+}
+
+func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedPolicyCfg) (sampling.PolicyEvaluator, error) {
+	evaluator, err := getSharedPolicyTypeEvaluator(settings, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return withSpanScope(settings, cfg.SpanScopeCfg, evaluator)
+}
+
+// withSpanScope restricts the evaluator to the configured span scope, if any
+func withSpanScope(settings component.TelemetrySettings, cfg SpanScopeCfg, evaluator sampling.PolicyEvaluator) (sampling.PolicyEvaluator, error) {
+	if !cfg.RootSpansOnly && len(cfg.SpanKinds) == 0 {
+		return evaluator, nil
 	}
+	return sampling.NewSpanScope(settings.Logger, evaluator, cfg.RootSpansOnly, cfg.SpanKinds)
 }
 
-func getSharedPolicyEvaluator(logger *zap.Logger, cfg *sharedPolicyCfg) (sampling.PolicyEvaluator, error) {
+func getSharedPolicyTypeEvaluator(settings component.TelemetrySettings, cfg *sharedPolicyCfg) (sampling.PolicyEvaluator, error) {
+	logger := settings.Logger
 	switch cfg.Type {
 	case AlwaysSample:
 		return sampling.NewAlwaysSample(logger), nil
@@ -138,6 +186,9 @@ func getSharedPolicyEvaluator(logger *zap.Logger, cfg *sharedPolicyCfg) (samplin
 	case NumericAttribute:
 		nafCfg := cfg.NumericAttributeCfg
 		return sampling.NewNumericAttributeFilter(logger, nafCfg.Key, nafCfg.MinValue, nafCfg.MaxValue), nil
+	case BooleanAttribute:
+		bafCfg := cfg.BooleanAttributeCfg
+		return sampling.NewBooleanAttributeFilter(logger, bafCfg.Key, bafCfg.Value), nil
 	case Probabilistic:
 		pCfg := cfg.ProbabilisticCfg
 		return sampling.NewProbabilisticSampler(logger, pCfg.HashSalt, pCfg.SamplingPercentage), nil
@@ -156,6 +207,9 @@ func getSharedPolicyEvaluator(logger *zap.Logger, cfg *sharedPolicyCfg) (samplin
 	case TraceState:
 		tsfCfg := cfg.TraceStateCfg
 		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values), nil
+	case OTTLCondition:
+		ocfCfg := cfg.OTTLConditionCfg
+		return sampling.NewOTTLConditionFilter(settings, ocfCfg.SpanConditions, ocfCfg.SpanEventConditions)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -190,6 +244,13 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.ReceivedBatches.MoveTo(allSpans)
 		trace.Unlock()
 
+		switch decision {
+		case sampling.Sampled:
+			tsp.sampledIDCache.Put(id, true)
+		case sampling.NotSampled:
+			tsp.nonSampledIDCache.Put(id, true)
+		}
+
 		if decision == sampling.Sampled {
 			_ = tsp.nextConsumer.ConsumeTraces(policy.ctx, allSpans)
 		}
@@ -331,6 +392,17 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 		}
 		d, loaded := tsp.idToTrace.Load(id)
 		if !loaded {
+			// Spans of traces already released from memory inherit their cached decision
+			if _, ok := tsp.sampledIDCache.Get(id); ok {
+				stats.Record(tsp.ctx, statLateSpanCachedDecisionCount.M(lenSpans))
+				tsp.releaseSampledSpans(resourceSpans, spans)
+				continue
+			}
+			if _, ok := tsp.nonSampledIDCache.Get(id); ok {
+				stats.Record(tsp.ctx, statLateSpanCachedDecisionCount.M(lenSpans))
+				continue
+			}
+
 			d, loaded = tsp.idToTrace.LoadOrStore(id, &sampling.TraceData{
 				Decisions:       initialDecisions,
 				ArrivalTime:     time.Now(),
@@ -371,14 +443,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 			switch finalDecision {
 			case sampling.Sampled:
-				// Forward the spans to the policy destinations
-				traceTd := ptrace.NewTraces()
-				appendToTraces(traceTd, resourceSpans, spans)
-				if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
-					tsp.logger.Warn(
-						"Error sending late arrived spans to destination",
-						zap.Error(err))
-				}
+				tsp.releaseSampledSpans(resourceSpans, spans)
 			case sampling.NotSampled:
 				stats.Record(tsp.ctx, statLateSpanArrivalAfterDecision.M(int64(time.Since(actualData.DecisionTime)/time.Second)))
 			default:
@@ -391,6 +456,17 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	stats.Record(tsp.ctx, statNewTraceIDReceivedCount.M(newTraceIDs))
 }
 
+// releaseSampledSpans forwards the late spans of a sampled trace to the next consumer
+func (tsp *tailSamplingSpanProcessor) releaseSampledSpans(resourceSpans ptrace.ResourceSpans, spans []*ptrace.Span) {
+	traceTd := ptrace.NewTraces()
+	appendToTraces(traceTd, resourceSpans, spans)
+	if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
+		tsp.logger.Warn(
+			"Error sending late arrived spans to destination",
+			zap.Error(err))
+	}
+}
+
 func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
@@ -32,6 +32,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -50,7 +51,7 @@ func TestSequentialTraceArrival(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -81,7 +82,7 @@ func TestConcurrentTraceArrival(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -121,7 +122,7 @@ func TestSequentialTraceMapSize(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -151,7 +152,7 @@ func TestConcurrentTraceMapSize(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	sp, _ := newTracesProcessor(componenttest.NewNopTelemetrySettings(), consumertest.NewNop(), cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	tsp.tickerFrequency = 100 * time.Millisecond
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
@@ -198,6 +199,9 @@ func TestSamplingPolicyTypicalPath(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -259,6 +263,9 @@ func TestSamplingPolicyInvertSampled(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -327,6 +334,9 @@ func TestSamplingMultiplePolicies(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -390,6 +400,9 @@ func TestSamplingPolicyDecisionNotSampled(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -453,6 +466,9 @@ func TestSamplingPolicyDecisionInvertNotSampled(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -516,6 +532,9 @@ func TestLateArrivingSpansAssignedOriginalDecision(t *testing.T) {
 		policyTicker:    &manualTTicker{},
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -564,6 +583,78 @@ func TestLateArrivingSpansAssignedOriginalDecision(t *testing.T) {
 	require.EqualValues(t, 0, nextConsumer.SpanCount(), "original final decision not honored")
 }
 
+func TestLateSpansInheritCachedDecision(t *testing.T) {
+	for name, decision := range map[string]sampling.Decision{"sampled": sampling.Sampled, "not sampled": sampling.NotSampled} {
+		decision := decision
+		t.Run(name, func(t *testing.T) {
+			const maxSize = 100
+			nextConsumer := new(consumertest.TracesSink)
+			mpe := &mockPolicyEvaluator{NextDecision: decision}
+			sampledIDCache, err := cache.NewLRUDecisionCache[bool](10)
+			require.NoError(t, err)
+			nonSampledIDCache, err := cache.NewLRUDecisionCache[bool](10)
+			require.NoError(t, err)
+			tsp := &tailSamplingSpanProcessor{
+				ctx:             context.Background(),
+				nextConsumer:    nextConsumer,
+				maxNumTraces:    maxSize,
+				logger:          zap.NewNop(),
+				decisionBatcher: newSyncIDBatcher(1),
+				policies:        []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+				deleteChan:      make(chan pcommon.TraceID, maxSize),
+				policyTicker:    &manualTTicker{},
+				tickerFrequency: 100 * time.Millisecond,
+				numTracesOnMap:  atomic.NewUint64(0),
+
+				sampledIDCache:    sampledIDCache,
+				nonSampledIDCache: nonSampledIDCache,
+			}
+			require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, tsp.Shutdown(context.Background()))
+			}()
+
+			traceID := uInt64ToTraceID(1)
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+			tsp.samplingPolicyOnTick()
+			tsp.samplingPolicyOnTick()
+			require.EqualValues(t, 1, mpe.EvaluationCount)
+
+			expectedSpans := 0
+			if decision == sampling.Sampled {
+				expectedSpans = 1
+			}
+			require.EqualValues(t, expectedSpans, nextConsumer.SpanCount())
+
+			// Release the trace from memory, as if it was evicted by newer traces
+			tsp.dropTrace(traceID, time.Now())
+			require.EqualValues(t, 0, tsp.numTracesOnMap.Load())
+
+			// The late span inherits the cached decision, without evaluating the policies again
+			require.NoError(t, tsp.ConsumeTraces(context.Background(), simpleTracesWithID(traceID)))
+			if decision == sampling.Sampled {
+				expectedSpans = 2
+			}
+			require.EqualValues(t, expectedSpans, nextConsumer.SpanCount())
+			require.EqualValues(t, 0, tsp.numTracesOnMap.Load(), "late span should not be kept in memory")
+			tsp.samplingPolicyOnTick()
+			tsp.samplingPolicyOnTick()
+			require.EqualValues(t, 1, mpe.EvaluationCount)
+		})
+	}
+}
+
+func TestNewDecisionCache(t *testing.T) {
+	c, err := newDecisionCache(0)
+	require.NoError(t, err)
+	c.Put(uInt64ToTraceID(1), true)
+	_, ok := c.Get(uInt64ToTraceID(1))
+	require.False(t, ok, "disabled cache should not hold any decision")
+
+	_, err = newDecisionCache(-1)
+	require.ErrorIs(t, err, cache.ErrInvalidSize)
+}
+
 func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 	const maxSize = 100
 	const decisionWaitSeconds = 1
@@ -583,6 +674,9 @@ func TestMultipleBatchesAreCombinedIntoOne(t *testing.T) {
 		policyTicker:    mtt,
 		tickerFrequency: 100 * time.Millisecond,
 		numTracesOnMap:  atomic.NewUint64(0),
+
+		sampledIDCache:    cache.NewNopDecisionCache[bool](),
+		nonSampledIDCache: cache.NewNopDecisionCache[bool](),
 	}
 	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
 	defer func() {
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -28,7 +29,9 @@ import (
 
 // Predefined error responses for configuration validation failures
 var (
-	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>:<port>`)
+	errInvalidEndpoint   = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>:<port>`)
+	errInvalidStatusCode = errors.New(`"expected_status_codes" must be between 100 and 599`)
+	errInvalidBodyRegex  = errors.New(`"body_regex" must be a valid regular expression`)
 )
 
 const defaultEndpoint = "http://localhost:80"
@@ -39,17 +42,77 @@ type Config struct {
 	confighttp.HTTPClientSettings           `mapstructure:",squash"`
 	Metrics                                 metadata.MetricsSettings `mapstructure:"metrics"`
 	Method                                  string                   `mapstructure:"method"`
+
+	// Targets is the list of endpoints to check concurrently. When it is set, the
+	// endpoint configured at the top level is not checked.
+	Targets []*TargetConfig `mapstructure:"targets"`
+}
+
+// TargetConfig defines the configuration of an endpoint to check.
+// Its method and timeout default to the top level ones.
+type TargetConfig struct {
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+	Method                        string `mapstructure:"method"`
+
+	// ExpectedStatusCodes is the list of status codes expected from the endpoint.
+	// Any status code is expected when it is empty.
+	ExpectedStatusCodes []int `mapstructure:"expected_status_codes"`
+	// BodyContains is a string expected in the body of the response.
+	BodyContains string `mapstructure:"body_contains"`
+	// BodyRegex is a regular expression expected to match the body of the response.
+	BodyRegex string `mapstructure:"body_regex"`
 }
 
 // Validate validates the configuration by checking for missing or invalid fields
 func (cfg *Config) Validate() error {
 	var err error
+	for _, target := range cfg.targets() {
+		err = multierr.Append(err, target.validate())
+	}
+	return err
+}
+
+// targets returns the targets to check, which default to the top level endpoint
+func (cfg *Config) targets() []*TargetConfig {
+	if len(cfg.Targets) == 0 {
+		return []*TargetConfig{{
+			HTTPClientSettings: cfg.HTTPClientSettings,
+			Method:             cfg.Method,
+		}}
+	}
+
+	targets := make([]*TargetConfig, 0, len(cfg.Targets))
+	for _, target := range cfg.Targets {
+		withDefaults := *target
+		if withDefaults.Method == "" {
+			withDefaults.Method = cfg.Method
+		}
+		if withDefaults.Timeout == 0 {
+			withDefaults.Timeout = cfg.Timeout
+		}
+		targets = append(targets, &withDefaults)
+	}
+	return targets
+}
+
+func (tc *TargetConfig) validate() error {
+	var err error
 
-	_, parseErr := url.Parse(cfg.Endpoint)
+	_, parseErr := url.Parse(tc.Endpoint)
 	if parseErr != nil {
 		wrappedErr := fmt.Errorf("%s: %w", errInvalidEndpoint.Error(), parseErr)
 		err = multierr.Append(err, wrappedErr)
 	}
 
+	for _, statusCode := range tc.ExpectedStatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			err = multierr.Append(err, fmt.Errorf("%s: %d", errInvalidStatusCode.Error(), statusCode))
+		}
+	}
+
+	if _, regexErr := regexp.Compile(tc.BodyRegex); regexErr != nil {
+		err = multierr.Append(err, fmt.Errorf("%s: %w", errInvalidBodyRegex.Error(), regexErr))
+	}
+
 	return err
 }
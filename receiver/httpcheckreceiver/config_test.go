@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -50,6 +51,48 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			desc: "invalid targets",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "invalid://endpoint:  12efg",
+				},
+				Targets: []*TargetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: defaultEndpoint,
+						},
+						ExpectedStatusCodes: []int{200, 600},
+					},
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: defaultEndpoint,
+						},
+						BodyRegex: "(",
+					},
+				},
+			},
+			expectedErr: multierr.Combine(
+				fmt.Errorf("%s: %d", errInvalidStatusCode, 600),
+				fmt.Errorf("%s: %w", errInvalidBodyRegex, errors.New("error parsing regexp: missing closing ): `(`")),
+			),
+		},
+		{
+			desc: "valid targets",
+			cfg: &Config{
+				Targets: []*TargetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: defaultEndpoint,
+						},
+						ExpectedStatusCodes: []int{200, 204},
+						BodyContains:        "ok",
+						BodyRegex:           `"status":\s*"up"`,
+					},
+				},
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -64,3 +107,34 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestTargetsDefaults(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.Equal(t, []*TargetConfig{{
+		HTTPClientSettings: cfg.HTTPClientSettings,
+		Method:             "GET",
+	}}, cfg.targets())
+
+	cfg.Targets = []*TargetConfig{
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: "http://localhost:8080",
+			},
+		},
+		{
+			HTTPClientSettings: confighttp.HTTPClientSettings{
+				Endpoint: "http://localhost:8081",
+				Timeout:  time.Second,
+			},
+			Method: "HEAD",
+		},
+	}
+	targets := cfg.targets()
+	require.Len(t, targets, 2)
+	require.Equal(t, "http://localhost:8080", targets[0].Endpoint)
+	require.Equal(t, "GET", targets[0].Method)
+	require.Equal(t, 10*time.Second, targets[0].Timeout)
+	require.Equal(t, "HEAD", targets[1].Method)
+	require.Equal(t, time.Second, targets[1].Timeout)
+	require.Empty(t, cfg.Targets[0].Method, "configured targets must not be modified")
+}
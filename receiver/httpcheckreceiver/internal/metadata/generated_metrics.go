@@ -33,9 +33,11 @@ func (ms *MetricSettings) Unmarshal(parser *confmap.Conf) error {
 
 // MetricsSettings provides settings for httpcheckreceiver metrics.
 type MetricsSettings struct {
-	HttpcheckDuration MetricSettings `mapstructure:"httpcheck.duration"`
-	HttpcheckError    MetricSettings `mapstructure:"httpcheck.error"`
-	HttpcheckStatus   MetricSettings `mapstructure:"httpcheck.status"`
+	HttpcheckDuration         MetricSettings `mapstructure:"httpcheck.duration"`
+	HttpcheckError            MetricSettings `mapstructure:"httpcheck.error"`
+	HttpcheckPhaseDuration    MetricSettings `mapstructure:"httpcheck.phase.duration"`
+	HttpcheckStatus           MetricSettings `mapstructure:"httpcheck.status"`
+	HttpcheckTLSCertRemaining MetricSettings `mapstructure:"httpcheck.tls.cert_remaining"`
 }
 
 func DefaultMetricsSettings() MetricsSettings {
@@ -46,10 +48,50 @@ func DefaultMetricsSettings() MetricsSettings {
 		HttpcheckError: MetricSettings{
 			Enabled: true,
 		},
+		HttpcheckPhaseDuration: MetricSettings{
+			Enabled: false,
+		},
 		HttpcheckStatus: MetricSettings{
 			Enabled: true,
 		},
+		HttpcheckTLSCertRemaining: MetricSettings{
+			Enabled: false,
+		},
+	}
+}
+
+// AttributeHTTPPhase specifies the a value http.phase attribute.
+type AttributeHTTPPhase int
+
+const (
+	_ AttributeHTTPPhase = iota
+	AttributeHTTPPhaseDns
+	AttributeHTTPPhaseConnect
+	AttributeHTTPPhaseTls
+	AttributeHTTPPhaseTtfb
+)
+
+// String returns the string representation of the AttributeHTTPPhase.
+func (av AttributeHTTPPhase) String() string {
+	switch av {
+	case AttributeHTTPPhaseDns:
+		return "dns"
+	case AttributeHTTPPhaseConnect:
+		return "connect"
+	case AttributeHTTPPhaseTls:
+		return "tls"
+	case AttributeHTTPPhaseTtfb:
+		return "ttfb"
 	}
+	return ""
+}
+
+// MapAttributeHTTPPhase is a helper map of string to AttributeHTTPPhase attribute value.
+var MapAttributeHTTPPhase = map[string]AttributeHTTPPhase{
+	"dns":     AttributeHTTPPhaseDns,
+	"connect": AttributeHTTPPhaseConnect,
+	"tls":     AttributeHTTPPhaseTls,
+	"ttfb":    AttributeHTTPPhaseTtfb,
 }
 
 type metricHttpcheckDuration struct {
@@ -157,6 +199,58 @@ func newMetricHttpcheckError(settings MetricSettings) metricHttpcheckError {
 	return m
 }
 
+type metricHttpcheckPhaseDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.phase.duration metric with initial data.
+func (m *metricHttpcheckPhaseDuration) init() {
+	m.data.SetName("httpcheck.phase.duration")
+	m.data.SetDescription("Measures the duration of each phase of the HTTP check. Phases are only recorded when they occur, so DNS, connect and TLS are missing when a connection is reused.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckPhaseDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpPhaseAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("http.phase", httpPhaseAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckPhaseDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckPhaseDuration) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckPhaseDuration(settings MetricSettings) metricHttpcheckPhaseDuration {
+	m := metricHttpcheckPhaseDuration{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricHttpcheckStatus struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	settings MetricSettings // metric settings provided by user.
@@ -213,17 +307,70 @@ func newMetricHttpcheckStatus(settings MetricSettings) metricHttpcheckStatus {
 	return m
 }
 
+type metricHttpcheckTLSCertRemaining struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.tls.cert_remaining metric with initial data.
+func (m *metricHttpcheckTLSCertRemaining) init() {
+	m.data.SetName("httpcheck.tls.cert_remaining")
+	m.data.SetDescription("Time until the TLS certificate presented by the endpoint expires, which is negative once it has expired.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckTLSCertRemaining) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckTLSCertRemaining) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckTLSCertRemaining) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckTLSCertRemaining(settings MetricSettings) metricHttpcheckTLSCertRemaining {
+	m := metricHttpcheckTLSCertRemaining{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 // MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
 // required to produce metric representation defined in metadata and user settings.
 type MetricsBuilder struct {
-	startTime               pcommon.Timestamp   // start time that will be applied to all recorded data points.
-	metricsCapacity         int                 // maximum observed number of metrics per resource.
-	resourceCapacity        int                 // maximum observed number of resource attributes.
-	metricsBuffer           pmetric.Metrics     // accumulates metrics data before emitting.
-	buildInfo               component.BuildInfo // contains version information
-	metricHttpcheckDuration metricHttpcheckDuration
-	metricHttpcheckError    metricHttpcheckError
-	metricHttpcheckStatus   metricHttpcheckStatus
+	startTime                       pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity                 int                 // maximum observed number of metrics per resource.
+	resourceCapacity                int                 // maximum observed number of resource attributes.
+	metricsBuffer                   pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                       component.BuildInfo // contains version information
+	metricHttpcheckDuration         metricHttpcheckDuration
+	metricHttpcheckError            metricHttpcheckError
+	metricHttpcheckPhaseDuration    metricHttpcheckPhaseDuration
+	metricHttpcheckStatus           metricHttpcheckStatus
+	metricHttpcheckTLSCertRemaining metricHttpcheckTLSCertRemaining
 }
 
 // metricBuilderOption applies changes to default metrics builder.
@@ -238,12 +385,14 @@ func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
 
 func NewMetricsBuilder(ms MetricsSettings, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
 	mb := &MetricsBuilder{
-		startTime:               pcommon.NewTimestampFromTime(time.Now()),
-		metricsBuffer:           pmetric.NewMetrics(),
-		buildInfo:               settings.BuildInfo,
-		metricHttpcheckDuration: newMetricHttpcheckDuration(ms.HttpcheckDuration),
-		metricHttpcheckError:    newMetricHttpcheckError(ms.HttpcheckError),
-		metricHttpcheckStatus:   newMetricHttpcheckStatus(ms.HttpcheckStatus),
+		startTime:                       pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                   pmetric.NewMetrics(),
+		buildInfo:                       settings.BuildInfo,
+		metricHttpcheckDuration:         newMetricHttpcheckDuration(ms.HttpcheckDuration),
+		metricHttpcheckError:            newMetricHttpcheckError(ms.HttpcheckError),
+		metricHttpcheckPhaseDuration:    newMetricHttpcheckPhaseDuration(ms.HttpcheckPhaseDuration),
+		metricHttpcheckStatus:           newMetricHttpcheckStatus(ms.HttpcheckStatus),
+		metricHttpcheckTLSCertRemaining: newMetricHttpcheckTLSCertRemaining(ms.HttpcheckTLSCertRemaining),
 	}
 	for _, op := range options {
 		op(mb)
@@ -298,7 +447,9 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
 	mb.metricHttpcheckDuration.emit(ils.Metrics())
 	mb.metricHttpcheckError.emit(ils.Metrics())
+	mb.metricHttpcheckPhaseDuration.emit(ils.Metrics())
 	mb.metricHttpcheckStatus.emit(ils.Metrics())
+	mb.metricHttpcheckTLSCertRemaining.emit(ils.Metrics())
 	for _, op := range rmo {
 		op(rm)
 	}
@@ -328,11 +479,21 @@ func (mb *MetricsBuilder) RecordHttpcheckErrorDataPoint(ts pcommon.Timestamp, va
 	mb.metricHttpcheckError.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, errorMessageAttributeValue)
 }
 
+// RecordHttpcheckPhaseDurationDataPoint adds a data point to httpcheck.phase.duration metric.
+func (mb *MetricsBuilder) RecordHttpcheckPhaseDurationDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpPhaseAttributeValue AttributeHTTPPhase) {
+	mb.metricHttpcheckPhaseDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpPhaseAttributeValue.String())
+}
+
 // RecordHttpcheckStatusDataPoint adds a data point to httpcheck.status metric.
 func (mb *MetricsBuilder) RecordHttpcheckStatusDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpStatusCodeAttributeValue int64, httpMethodAttributeValue string, httpStatusClassAttributeValue string) {
 	mb.metricHttpcheckStatus.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpStatusCodeAttributeValue, httpMethodAttributeValue, httpStatusClassAttributeValue)
 }
 
+// RecordHttpcheckTLSCertRemainingDataPoint adds a data point to httpcheck.tls.cert_remaining metric.
+func (mb *MetricsBuilder) RecordHttpcheckTLSCertRemainingDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	mb.metricHttpcheckTLSCertRemaining.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
+}
+
 // Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
 // and metrics builder should update its startTime and reset it's internal state accordingly.
 func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
@@ -15,71 +15,237 @@
 package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
 )
 
+// maxBodySize is the maximum size of the response body read to check the body assertions
+const maxBodySize = 1 << 20
+
 var (
 	errClientNotInit    = errors.New("client not initialized")
 	httpResponseClasses = map[string]int{"1xx": 1, "2xx": 2, "3xx": 3, "4xx": 4, "5xx": 5}
 )
 
 type httpcheckScraper struct {
-	client   *http.Client
+	targets  []*httpcheckTarget
 	cfg      *Config
 	settings component.TelemetrySettings
-	mb       *metadata.MetricsBuilder
+
+	// mbLock guards the metrics builder, which is used by the concurrent checks
+	mbLock sync.Mutex
+	mb     *metadata.MetricsBuilder
 }
 
-// start starts the scraper by creating a new HTTP Client on the scraper
-func (h *httpcheckScraper) start(ctx context.Context, host component.Host) (err error) {
-	h.client, err = h.cfg.ToClient(host, h.settings)
-	return
+// httpcheckTarget is a target along with the client used to check it
+type httpcheckTarget struct {
+	*TargetConfig
+	client    *http.Client
+	bodyRegex *regexp.Regexp
 }
 
-// scrape connects to the endpoint and produces metrics based on the response
+// start starts the scraper by creating a new HTTP Client for each target
+func (h *httpcheckScraper) start(ctx context.Context, host component.Host) error {
+	var targets []*httpcheckTarget
+	for _, targetCfg := range h.cfg.targets() {
+		client, err := targetCfg.ToClient(host, h.settings)
+		if err != nil {
+			return err
+		}
+
+		target := &httpcheckTarget{
+			TargetConfig: targetCfg,
+			client:       client,
+		}
+		if targetCfg.BodyRegex != "" {
+			target.bodyRegex, err = regexp.Compile(targetCfg.BodyRegex)
+			if err != nil {
+				return err
+			}
+		}
+		targets = append(targets, target)
+	}
+
+	h.targets = targets
+	return nil
+}
+
+// scrape checks all the targets concurrently and produces metrics based on the responses
 func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
-	if h.client == nil {
+	if len(h.targets) == 0 {
 		return pmetric.NewMetrics(), errClientNotInit
 	}
 
 	now := pcommon.NewTimestampFromTime(time.Now())
 
-	req, err := http.NewRequestWithContext(ctx, h.cfg.Method, h.cfg.Endpoint, http.NoBody)
+	var wg sync.WaitGroup
+	errs := make([]error, len(h.targets))
+	for i, target := range h.targets {
+		wg.Add(1)
+		go func(i int, target *httpcheckTarget) {
+			defer wg.Done()
+			errs[i] = h.check(ctx, now, target)
+		}(i, target)
+	}
+	wg.Wait()
+
+	var scrapeErrs scrapererror.ScrapeErrors
+	for _, err := range errs {
+		if err != nil {
+			scrapeErrs.AddPartial(1, err)
+		}
+	}
+
+	return h.mb.Emit(), scrapeErrs.Combine()
+}
+
+// check sends the request of the target and records the metrics of the response.
+// An error is only returned if the request cannot be created.
+func (h *httpcheckScraper) check(ctx context.Context, now pcommon.Timestamp, target *httpcheckTarget) error {
+	timings := &phaseTimings{}
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, timings.clientTrace()), target.Method, target.Endpoint, http.NoBody)
 	if err != nil {
-		return pmetric.Metrics{}, err
+		return err
 	}
 
 	start := time.Now()
-	resp, err := h.client.Do(req)
-	h.mb.RecordHttpcheckDurationDataPoint(now, time.Since(start).Milliseconds(), h.cfg.Endpoint)
+	resp, err := target.client.Do(req)
+	duration := time.Since(start)
 
 	statusCode := 0
-	if err != nil {
-		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), h.cfg.Endpoint, err.Error())
-	} else {
+	var state *tls.ConnectionState
+	if err == nil {
 		statusCode = resp.StatusCode
+		state = resp.TLS
+		err = target.checkResponse(resp)
+	}
+
+	h.mbLock.Lock()
+	defer h.mbLock.Unlock()
+
+	h.mb.RecordHttpcheckDurationDataPoint(now, duration.Milliseconds(), target.Endpoint)
+	timings.record(h.mb, now, start, target.Endpoint)
+
+	if state != nil && len(state.PeerCertificates) > 0 {
+		remaining := state.PeerCertificates[0].NotAfter.Sub(now.AsTime())
+		h.mb.RecordHttpcheckTLSCertRemainingDataPoint(now, int64(remaining.Seconds()), target.Endpoint)
+	}
+
+	if err != nil {
+		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), target.Endpoint, err.Error())
 	}
 
 	for class, intVal := range httpResponseClasses {
 		if statusCode/100 == intVal {
-			h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), h.cfg.Endpoint, int64(statusCode), req.Method, class)
+			h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), target.Endpoint, int64(statusCode), req.Method, class)
 		} else {
-			h.mb.RecordHttpcheckStatusDataPoint(now, int64(0), h.cfg.Endpoint, int64(statusCode), req.Method, class)
+			h.mb.RecordHttpcheckStatusDataPoint(now, int64(0), target.Endpoint, int64(statusCode), req.Method, class)
+		}
+	}
+
+	return nil
+}
+
+// checkResponse reads and closes the body of the response, and returns an error
+// if the response does not match the expectations of the target
+func (t *httpcheckTarget) checkResponse(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if len(t.ExpectedStatusCodes) > 0 && !containsStatusCode(t.ExpectedStatusCodes, resp.StatusCode) {
+		return fmt.Errorf("unexpected status code %d, expected one of %v", resp.StatusCode, t.ExpectedStatusCodes)
+	}
+	if t.BodyContains != "" && !bytes.Contains(body, []byte(t.BodyContains)) {
+		return fmt.Errorf("response body does not contain %q", t.BodyContains)
+	}
+	if t.bodyRegex != nil && !t.bodyRegex.Match(body) {
+		return fmt.Errorf("response body does not match %q", t.BodyRegex)
+	}
+
+	return nil
+}
+
+func containsStatusCode(statusCodes []int, statusCode int) bool {
+	for _, code := range statusCodes {
+		if code == statusCode {
+			return true
 		}
+	}
+	return false
+}
+
+// phaseTimings records the time of the phases of a request through an httptrace.ClientTrace
+type phaseTimings struct {
+	mu sync.Mutex
 
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	firstByte                 time.Time
+}
+
+func (p *phaseTimings) clientTrace() *httptrace.ClientTrace {
+	// set stores the time in the field, keeping the first one for the start of the phases
+	set := func(field *time.Time, keepFirst bool) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if !keepFirst || field.IsZero() {
+			*field = time.Now()
+		}
 	}
 
-	return h.mb.Emit(), nil
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { set(&p.dnsStart, true) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { set(&p.dnsDone, false) },
+		ConnectStart: func(string, string) {
+			set(&p.connectStart, true)
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				set(&p.connectDone, false)
+			}
+		},
+		TLSHandshakeStart:    func() { set(&p.tlsStart, true) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { set(&p.tlsDone, false) },
+		GotFirstResponseByte: func() { set(&p.firstByte, false) },
+	}
+}
+
+// record records the duration of the phases which occurred during the request started at start
+func (p *phaseTimings) record(mb *metadata.MetricsBuilder, now pcommon.Timestamp, start time.Time, endpoint string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	recordPhase := func(phaseStart, phaseDone time.Time, phase metadata.AttributeHTTPPhase) {
+		if !phaseStart.IsZero() && !phaseDone.IsZero() {
+			mb.RecordHttpcheckPhaseDurationDataPoint(now, phaseDone.Sub(phaseStart).Milliseconds(), endpoint, phase)
+		}
+	}
+	recordPhase(p.dnsStart, p.dnsDone, metadata.AttributeHTTPPhaseDns)
+	recordPhase(p.connectStart, p.connectDone, metadata.AttributeHTTPPhaseConnect)
+	recordPhase(p.tlsStart, p.tlsDone, metadata.AttributeHTTPPhaseTls)
+	recordPhase(start, p.firstByte, metadata.AttributeHTTPPhaseTtfb)
 }
 
 func newScraper(conf *Config, settings receiver.CreateSettings) *httpcheckScraper {
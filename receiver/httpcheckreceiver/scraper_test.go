@@ -20,6 +20,7 @@ import (
 	"net/http/httptest"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -182,3 +183,121 @@ func TestNilClient(t *testing.T) {
 	require.NoError(t, comparetest.CompareMetrics(pmetric.NewMetrics(), actualMetrics))
 
 }
+
+// dataPointsByURL returns the data points of the metric, indexed by the http.url attribute
+func dataPointsByURL(metrics pmetric.Metrics, name string) map[string][]pmetric.NumberDataPoint {
+	dataPoints := map[string][]pmetric.NumberDataPoint{}
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		metric := ms.At(i)
+		if metric.Name() != name {
+			continue
+		}
+		var dps pmetric.NumberDataPointSlice
+		if metric.Type() == pmetric.MetricTypeSum {
+			dps = metric.Sum().DataPoints()
+		} else {
+			dps = metric.Gauge().DataPoints()
+		}
+		for j := 0; j < dps.Len(); j++ {
+			url, _ := dps.At(j).Attributes().Get("http.url")
+			dataPoints[url.Str()] = append(dataPoints[url.Str()], dps.At(j))
+		}
+	}
+	return dataPoints
+}
+
+func TestScraperScrapeTargets(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := rw.Write([]byte(`{"status": "up"}`))
+		require.NoError(t, err)
+	}))
+	defer ok.Close()
+	unavailable := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	target := func(endpoint string) *TargetConfig {
+		return &TargetConfig{HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: endpoint}}
+	}
+	expectedOK := target(ok.URL + "/expected")
+	expectedOK.ExpectedStatusCodes = []int{200}
+	expectedOK.BodyContains = "up"
+	expectedOK.BodyRegex = `"status":\s*"up"`
+	unexpectedBody := target(ok.URL + "/contains")
+	unexpectedBody.BodyContains = "down"
+	unmatchedBody := target(ok.URL + "/regex")
+	unmatchedBody.BodyRegex = `"status":\s*"down"`
+	unexpectedStatus := target(unavailable.URL)
+	unexpectedStatus.ExpectedStatusCodes = []int{200, 204}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*TargetConfig{expectedOK, unexpectedBody, unmatchedBody, unexpectedStatus}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	durations := dataPointsByURL(actualMetrics, "httpcheck.duration")
+	statuses := dataPointsByURL(actualMetrics, "httpcheck.status")
+	for _, target := range cfg.Targets {
+		require.Len(t, durations[target.Endpoint], 1)
+		require.Len(t, statuses[target.Endpoint], len(httpResponseClasses))
+	}
+
+	errs := dataPointsByURL(actualMetrics, "httpcheck.error")
+	require.NotContains(t, errs, expectedOK.Endpoint)
+	expectedErrs := map[string]string{
+		unexpectedBody.Endpoint:   `response body does not contain "down"`,
+		unmatchedBody.Endpoint:    `response body does not match "\"status\":\\s*\"down\""`,
+		unexpectedStatus.Endpoint: "unexpected status code 503, expected one of [200 204]",
+	}
+	for endpoint, expectedErr := range expectedErrs {
+		require.Len(t, errs[endpoint], 1)
+		message, _ := errs[endpoint][0].Attributes().Get("error.message")
+		require.Equal(t, expectedErr, message.Str())
+	}
+}
+
+func TestScraperScrapeTLSAndPhases(t *testing.T) {
+	ms := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer ms.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ms.URL
+	cfg.TLSSetting.InsecureSkipVerify = true
+	cfg.Metrics.HttpcheckPhaseDuration.Enabled = true
+	cfg.Metrics.HttpcheckTLSCertRemaining.Enabled = true
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	certRemaining := dataPointsByURL(actualMetrics, "httpcheck.tls.cert_remaining")[ms.URL]
+	require.Len(t, certRemaining, 1)
+	expectedRemaining := time.Until(ms.Certificate().NotAfter).Seconds()
+	require.InDelta(t, expectedRemaining, certRemaining[0].IntValue(), 60)
+
+	var phases []string
+	for _, dp := range dataPointsByURL(actualMetrics, "httpcheck.phase.duration")[ms.URL] {
+		phase, _ := dp.Attributes().Get("http.phase")
+		phases = append(phases, phase.Str())
+	}
+	// The endpoint is an IP address, so there is no DNS phase
+	require.ElementsMatch(t, []string{"connect", "tls", "ttfb"}, phases)
+
+	// The connection is reused by the next checks
+	actualMetrics, err = scraper.scrape(context.Background())
+	require.NoError(t, err)
+	phases = nil
+	for _, dp := range dataPointsByURL(actualMetrics, "httpcheck.phase.duration")[ms.URL] {
+		phase, _ := dp.Attributes().Get("http.phase")
+		phases = append(phases, phase.Str())
+	}
+	require.ElementsMatch(t, []string{"ttfb"}, phases)
+}
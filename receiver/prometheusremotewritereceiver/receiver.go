@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+)
+
+const (
+	writePath          = "/api/v1/write"
+	snappyEncoding     = "snappy"
+	contentEncodingKey = "Content-Encoding"
+
+	// maxDecodedSize is the maximum size of the write requests once decompressed
+	maxDecodedSize = 256 << 20
+)
+
+var errRequestTooLarge = errors.New("request too large")
+
+type metricsReceiver struct {
+	settings     receiver.CreateSettings
+	config       *Config
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+
+	server *http.Server
+	wg     sync.WaitGroup
+}
+
+func newMetricsReceiver(config *Config, settings receiver.CreateSettings, nextConsumer consumer.Metrics) (*metricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
+	transport := "http"
+	if config.TLSSetting != nil {
+		transport = "https"
+	}
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             settings.ID,
+		Transport:              transport,
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricsReceiver{
+		settings:     settings,
+		config:       config,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsrecv,
+	}, nil
+}
+
+func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+
+	router := http.NewServeMux()
+	router.HandleFunc(writePath, r.handleWrite)
+
+	r.server, err = r.config.HTTPServerSettings.ToServer(host, r.settings.TelemetrySettings, router)
+	if err != nil {
+		return err
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (r *metricsReceiver) Shutdown(_ context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	if err := r.server.Close(); err != nil {
+		return err
+	}
+	r.wg.Wait()
+	return nil
+}
+
+// handleWrite converts the snappy compressed remote write request into metrics
+// and passes them to the next consumer
+func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	if req.Method != http.MethodPost {
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if encoding := req.Header.Get(contentEncodingKey); encoding != "" && encoding != snappyEncoding {
+		http.Error(w, fmt.Sprintf("unsupported content encoding %q", encoding), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	writeReq, err := readWriteRequest(req.Body, w, r.config.MaxRequestBodySize)
+	if err != nil {
+		r.obsrecv.EndMetricsOp(ctx, typeStr, 0, err)
+		if errors.Is(err, errRequestTooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	md, err := prometheusremotewrite.ToMetrics(writeReq)
+	if err != nil {
+		// the whole request is refused so that the sender knows that some of its series are invalid
+		droppedSeries := len(multierr.Errors(err))
+		r.settings.Logger.Debug("failed to convert the time series",
+			zap.Int("dropped_series", droppedSeries), zap.Error(err))
+		r.obsrecv.EndMetricsOp(ctx, typeStr, md.DataPointCount()+droppedSeries, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dataPointCount := md.DataPointCount()
+	if dataPointCount == 0 {
+		r.obsrecv.EndMetricsOp(ctx, typeStr, 0, nil)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, typeStr, dataPointCount, err)
+	if err != nil {
+		r.settings.Logger.Debug("failed to pass metrics to next consumer", zap.Error(err))
+		if consumererror.IsPermanent(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readWriteRequest decompresses and unmarshals the remote write request,
+// whose body is limited to maxBodySize bytes unless maxBodySize is not positive
+func readWriteRequest(body io.ReadCloser, w http.ResponseWriter, maxBodySize int64) (*prompb.WriteRequest, error) {
+	if maxBodySize > 0 {
+		body = http.MaxBytesReader(w, body, maxBodySize)
+	}
+	compressed, err := io.ReadAll(body)
+	if err != nil {
+		if maxBodySize > 0 && int64(len(compressed)) >= maxBodySize {
+			return nil, fmt.Errorf("%w: the request body exceeds %d bytes", errRequestTooLarge, maxBodySize)
+		}
+		return nil, fmt.Errorf("failed to read the request body: %w", err)
+	}
+	// check the size claimed by the snappy header before allocating the buffer
+	decodedLen, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the request body: %w", err)
+	}
+	if decodedLen > maxDecodedSize {
+		return nil, fmt.Errorf("%w: the decompressed request body exceeds %d bytes", errRequestTooLarge, maxDecodedSize)
+	}
+	buf, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the request body: %w", err)
+	}
+	var writeReq prompb.WriteRequest
+	if err = writeReq.Unmarshal(buf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the write request: %w", err)
+	}
+	return &writeReq, nil
+}
@@ -0,0 +1,263 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func newTestWriteRequest() *prompb.WriteRequest {
+	return &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "requests_total"},
+					{Name: "job", Value: "app"},
+					{Name: "instance", Value: "localhost:8080"},
+					{Name: "code", Value: "200"},
+				},
+				Samples: []prompb.Sample{{Value: 10, Timestamp: 1672531200000}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "up"},
+					{Name: "job", Value: "app"},
+					{Name: "instance", Value: "localhost:8080"},
+				},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1672531200000}},
+			},
+		},
+	}
+}
+
+func encodeWriteRequest(t *testing.T, req *prompb.WriteRequest) []byte {
+	buf, err := req.Marshal()
+	require.NoError(t, err)
+	return snappy.Encode(nil, buf)
+}
+
+func newTestReceiver(t *testing.T, nextConsumer consumer.Metrics) *metricsReceiver {
+	r, err := newMetricsReceiver(createDefaultConfig().(*Config), receivertest.NewNopCreateSettings(), nextConsumer)
+	require.NoError(t, err)
+	return r
+}
+
+func TestHandleWrite(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		encoding       string
+		body           []byte
+		consumer       consumer.Metrics
+		expectedStatus int
+		expectedPoints int
+	}{
+		{
+			name:           "success",
+			method:         http.MethodPost,
+			encoding:       snappyEncoding,
+			body:           encodeWriteRequest(t, newTestWriteRequest()),
+			expectedStatus: http.StatusNoContent,
+			expectedPoints: 2,
+		},
+		{
+			name:           "empty request",
+			method:         http.MethodPost,
+			encoding:       snappyEncoding,
+			body:           encodeWriteRequest(t, &prompb.WriteRequest{}),
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "invalid method",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "unsupported encoding",
+			method:         http.MethodPost,
+			encoding:       "gzip",
+			body:           encodeWriteRequest(t, newTestWriteRequest()),
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "uncompressed body",
+			method:         http.MethodPost,
+			encoding:       snappyEncoding,
+			body:           []byte("not snappy"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "body too large",
+			method:         http.MethodPost,
+			encoding:       snappyEncoding,
+			body:           make([]byte, defaultMaxRequestBodySize+1),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "decompressed body too large",
+			method:   http.MethodPost,
+			encoding: snappyEncoding,
+			// a snappy header claiming almost 4 GiB of data, as a varint
+			body:           []byte{0xff, 0xff, 0xff, 0xff, 0x0f},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "invalid write request",
+			method:         http.MethodPost,
+			encoding:       snappyEncoding,
+			body:           snappy.Encode(nil, []byte("not protobuf")),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "permanent consumer error",
+			method:         http.MethodPost,
+			encoding:       snappyEncoding,
+			body:           encodeWriteRequest(t, newTestWriteRequest()),
+			consumer:       consumertest.NewErr(consumererror.NewPermanent(assert.AnError)),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "retryable consumer error",
+			method:         http.MethodPost,
+			encoding:       snappyEncoding,
+			body:           encodeWriteRequest(t, newTestWriteRequest()),
+			consumer:       consumertest.NewErr(assert.AnError),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			nextConsumer := tt.consumer
+			if nextConsumer == nil {
+				nextConsumer = sink
+			}
+			r := newTestReceiver(t, nextConsumer)
+
+			req := httptest.NewRequest(tt.method, writePath, bytes.NewReader(tt.body))
+			if tt.encoding != "" {
+				req.Header.Set(contentEncodingKey, tt.encoding)
+			}
+			w := httptest.NewRecorder()
+			r.handleWrite(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedPoints, sink.DataPointCount())
+		})
+	}
+}
+
+func TestHandleWriteMaxRequestBodySize(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	body := encodeWriteRequest(t, newTestWriteRequest())
+	cfg.MaxRequestBodySize = int64(len(body)) - 1
+	sink := new(consumertest.MetricsSink)
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, writePath, bytes.NewReader(body))
+	req.Header.Set(contentEncodingKey, snappyEncoding)
+	w := httptest.NewRecorder()
+	r.handleWrite(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Zero(t, sink.DataPointCount())
+}
+
+func TestHandleWriteConversionError(t *testing.T) {
+	tt, err := obsreporttest.SetupTelemetry(component.NewID(typeStr))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, tt.Shutdown(context.Background()))
+	}()
+
+	sink := new(consumertest.MetricsSink)
+	r, err := newMetricsReceiver(createDefaultConfig().(*Config), tt.ToReceiverCreateSettings(), sink)
+	require.NoError(t, err)
+
+	writeReq := newTestWriteRequest()
+	writeReq.Timeseries = append(writeReq.Timeseries, prompb.TimeSeries{
+		Labels:  []prompb.Label{{Name: "job", Value: "app"}},
+		Samples: []prompb.Sample{{Value: 1, Timestamp: 1672531200000}},
+	})
+	req := httptest.NewRequest(http.MethodPost, writePath, bytes.NewReader(encodeWriteRequest(t, writeReq)))
+	req.Header.Set(contentEncodingKey, snappyEncoding)
+	w := httptest.NewRecorder()
+	r.handleWrite(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Zero(t, sink.DataPointCount())
+	require.NoError(t, tt.CheckReceiverMetrics("http", 0, 3))
+}
+
+func TestReceiverEndToEnd(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = addr
+	sink := new(consumertest.MetricsSink)
+	r, err := newMetricsReceiver(cfg, receivertest.NewNopCreateSettings(), sink)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	}()
+
+	req, err := http.NewRequest(http.MethodPost, "http://"+addr+writePath,
+		bytes.NewReader(encodeWriteRequest(t, newTestWriteRequest())))
+	require.NoError(t, err)
+	req.Header.Set(contentEncodingKey, snappyEncoding)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	serviceName, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "app", serviceName.Str())
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "requests_total", metrics.At(0).Name())
+	assert.Equal(t, pmetric.MetricTypeSum, metrics.At(0).Type())
+	assert.Equal(t, "up", metrics.At(1).Name())
+	assert.Equal(t, pmetric.MetricTypeGauge, metrics.At(1).Type())
+}
@@ -33,6 +33,7 @@ const (
 	defaultSecurityLevel      = "no_auth_no_priv"
 	defaultAuthType           = "MD5"
 	defaultPrivacyType        = "DES"
+	defaultTrapsEndpoint      = "udp://0.0.0.0:162"
 )
 
 var (
@@ -74,6 +75,8 @@ var (
 	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
 	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
 	errMetricRequired       = errors.New("must have at least one config under metrics")
+	errTrapsRequired        = errors.New("traps must be configured to receive logs")
+	errTrapsBadScheme       = errors.New("traps endpoint scheme must be udp")
 )
 
 // Config defines the configuration for the various elements of the receiver.
@@ -137,6 +140,23 @@ type Config struct {
 	// Metrics defines what SNMP metrics will be collected for this receiver and is composed of metric
 	// names along with their metric configurations
 	Metrics map[string]*MetricConfig `mapstructure:"metrics"`
+
+	// Traps defines how SNMP traps and informs are received and turned into logs.
+	// Only used by the logs receiver. Traps are only accepted if they match the Version, Community
+	// or v3 security configs above.
+	Traps *TrapsConfig `mapstructure:"traps"`
+}
+
+// TrapsConfig contains the configs for receiving SNMP traps and informs
+type TrapsConfig struct {
+	// Endpoint is the address to listen on for traps and informs. Must be formatted as udp://{host}:{port}.
+	// Default: udp://0.0.0.0:162
+	Endpoint string `mapstructure:"endpoint"`
+
+	// MIBNameFiles is a list of files mapping OIDs to MIB object names, which are used on top of a set of
+	// well-known names to resolve the names of the traps and their variables. Each line of a file holds a
+	// quoted name and OID, as printed by `snmptranslate -Tz`
+	MIBNameFiles []string `mapstructure:"mib_name_files"`
 }
 
 // ResourceAttributeConfig contains config info about all of the resource attributes that will be used by this receiver.
@@ -249,11 +269,38 @@ func (cfg *Config) Validate() error {
 	if strings.ToUpper(cfg.Version) == "V3" {
 		combinedErr = multierr.Append(combinedErr, validateSecurity(cfg))
 	}
-	combinedErr = multierr.Append(combinedErr, validateMetricConfigs(cfg))
+	if cfg.Traps != nil {
+		combinedErr = multierr.Append(combinedErr, validateTraps(cfg.Traps))
+	}
+	// Metrics are optional when the receiver is only used for traps
+	if cfg.Traps == nil || len(cfg.Metrics) > 0 {
+		combinedErr = multierr.Append(combinedErr, validateMetricConfigs(cfg))
+	}
 
 	return combinedErr
 }
 
+// validateTraps validates the Traps endpoint
+func validateTraps(traps *TrapsConfig) error {
+	// Default is used if empty
+	if traps.Endpoint == "" {
+		return nil
+	}
+
+	u, err := url.Parse(traps.Endpoint)
+	if err != nil {
+		return fmt.Errorf(errMsgInvalidEndpointWError, traps.Endpoint, err)
+	}
+	if u.Host == "" || u.Port() == "" {
+		return fmt.Errorf(errMsgInvalidEndpoint, traps.Endpoint)
+	}
+	if strings.ToUpper(u.Scheme) != "UDP" {
+		return errTrapsBadScheme
+	}
+
+	return nil
+}
+
 // validateEndpoint validates the Endpoint
 func validateEndpoint(cfg *Config) error {
 	if cfg.Endpoint == "" {
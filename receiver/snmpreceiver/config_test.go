@@ -139,6 +139,21 @@ func TestLoadConfigConnectionConfigs(t *testing.T) {
 	expectedConfigV3NoPrivacyPassword.AuthPassword = "p"
 	expectedConfigV3NoPrivacyPassword.Metrics = metrics
 
+	expectedConfigTraps := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTraps.Community = "private"
+	expectedConfigTraps.Traps = &TrapsConfig{
+		Endpoint:     "udp://0.0.0.0:1162",
+		MIBNameFiles: []string{"testdata/mib_names.txt"},
+	}
+
+	expectedConfigTrapsNoEndpoint := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsNoEndpoint.Traps = &TrapsConfig{}
+
+	expectedConfigTrapsBadEndpointScheme := factory.CreateDefaultConfig().(*Config)
+	expectedConfigTrapsBadEndpointScheme.Traps = &TrapsConfig{
+		Endpoint: "tcp://0.0.0.0:162",
+	}
+
 	testCases := []testCase{
 		{
 			name:        "NoEndpointUsesDefault",
@@ -254,6 +269,24 @@ func TestLoadConfigConnectionConfigs(t *testing.T) {
 			expectedCfg: expectedConfigV3Simple,
 			expectedErr: "",
 		},
+		{
+			name:        "GoodTrapsNoMetricsNoErrors",
+			nameVal:     "traps_good",
+			expectedCfg: expectedConfigTraps,
+			expectedErr: "",
+		},
+		{
+			name:        "TrapsNoEndpointUsesDefault",
+			nameVal:     "traps_no_endpoint",
+			expectedCfg: expectedConfigTrapsNoEndpoint,
+			expectedErr: "",
+		},
+		{
+			name:        "TrapsBadEndpointSchemeErrors",
+			nameVal:     "traps_bad_endpoint_scheme",
+			expectedCfg: expectedConfigTrapsBadEndpointScheme,
+			expectedErr: errTrapsBadScheme.Error(),
+		},
 	}
 
 	for _, test := range testCases {
@@ -39,7 +39,8 @@ func NewFactory() receiver.Factory {
 	return receiver.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiver.WithMetrics(createMetricsReceiver, stability))
+		receiver.WithMetrics(createMetricsReceiver, stability),
+		receiver.WithLogs(createLogsReceiver, stability))
 }
 
 // createDefaultConfig creates a config for SNMP with as many default values as possible
@@ -73,6 +74,11 @@ func createMetricsReceiver(
 		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
 	}
 
+	// Metrics are only optional in the config when traps are received
+	if len(snmpConfig.Metrics) == 0 {
+		return nil, errMetricRequired
+	}
+
 	snmpScraper := newScraper(params.Logger, snmpConfig, params)
 	scraper, err := scraperhelper.NewScraper(typeStr, snmpScraper.scrape, scraperhelper.WithStart(snmpScraper.start))
 	if err != nil {
@@ -82,6 +88,29 @@ func createMetricsReceiver(
 	return scraperhelper.NewScraperControllerReceiver(&snmpConfig.ScraperControllerSettings, params, consumer, scraperhelper.AddScraper(scraper))
 }
 
+// createLogsReceiver creates the log receiver for SNMP traps
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.CreateSettings,
+	config component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	snmpConfig, ok := config.(*Config)
+	if !ok {
+		return nil, errConfigNotSNMP
+	}
+
+	if snmpConfig.Traps == nil {
+		return nil, errTrapsRequired
+	}
+
+	if err := addMissingConfigDefaults(snmpConfig); err != nil {
+		return nil, fmt.Errorf("failed to validate added config defaults: %w", err)
+	}
+
+	return newTrapReceiver(params, snmpConfig, consumer)
+}
+
 // addMissingConfigDefaults adds any missing comfig parameters that have defaults
 func addMissingConfigDefaults(cfg *Config) error {
 	// Add the schema prefix to the endpoint if it doesn't contain one
@@ -99,6 +128,18 @@ func addMissingConfigDefaults(cfg *Config) error {
 		cfg.Endpoint += portSuffix
 	}
 
+	// Set default traps endpoint
+	if cfg.Traps != nil && cfg.Traps.Endpoint == "" {
+		cfg.Traps.Endpoint = defaultTrapsEndpoint
+	}
+
+	// Lowercase the traps endpoint scheme, as the trap listener only recognizes lowercase schemes
+	if cfg.Traps != nil {
+		if scheme, address, ok := strings.Cut(cfg.Traps.Endpoint, "://"); ok {
+			cfg.Traps.Endpoint = strings.ToLower(scheme) + "://" + address
+		}
+	}
+
 	// Set defaults for metric configs
 	for _, metricCfg := range cfg.Metrics {
 		if metricCfg.Unit == "" {
@@ -268,6 +268,87 @@ func TestNewFactory(t *testing.T) {
 				require.Equal(t, "1", snmpCfg.Metrics["m1"].Unit)
 			},
 		},
+		{
+			desc: "CreateMetricsReceiver returns error without metrics",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{}
+				_, err := factory.CreateMetricsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errMetricRequired)
+			},
+		},
+		{
+			desc: "creates a new factory and CreateLogsReceiver returns no error",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{}
+				_, err := factory.CreateLogsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Equal(t, defaultTrapsEndpoint, snmpCfg.Traps.Endpoint)
+			},
+		},
+		{
+			desc: "CreateLogsReceiver lowercases the traps endpoint scheme",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{Endpoint: "UDP://localhost:162"}
+				_, err := factory.CreateLogsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.NoError(t, err)
+				require.Equal(t, "udp://localhost:162", snmpCfg.Traps.Endpoint)
+			},
+		},
+		{
+			desc: "CreateLogsReceiver returns error without traps",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				_, err := factory.CreateLogsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					factory.CreateDefaultConfig(),
+					consumertest.NewNop(),
+				)
+				require.ErrorIs(t, err, errTrapsRequired)
+			},
+		},
+		{
+			desc: "CreateLogsReceiver returns error with missing MIB name file",
+			testFunc: func(t *testing.T) {
+				factory := NewFactory()
+				cfg := factory.CreateDefaultConfig()
+				snmpCfg := cfg.(*Config)
+				snmpCfg.Traps = &TrapsConfig{
+					MIBNameFiles: []string{"testdata/missing.txt"},
+				}
+				_, err := factory.CreateLogsReceiver(
+					context.Background(),
+					receivertest.NewNopCreateSettings(),
+					cfg,
+					consumertest.NewNop(),
+				)
+				require.ErrorContains(t, err, "failed to open MIB name file")
+			},
+		},
 	}
 
 	for _, tc := range testCases {
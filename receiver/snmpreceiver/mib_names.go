@@ -0,0 +1,120 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// wellKnownMIBNames are the names of the OIDs commonly found in traps, which are
+// resolved even without any MIB name files
+var wellKnownMIBNames = map[string]string{
+	"1.3.6.1.2.1.1.3":        "sysUpTime",
+	"1.3.6.1.2.1.1.5":        "sysName",
+	"1.3.6.1.2.1.2.2.1.1":    "ifIndex",
+	"1.3.6.1.2.1.2.2.1.2":    "ifDescr",
+	"1.3.6.1.2.1.2.2.1.7":    "ifAdminStatus",
+	"1.3.6.1.2.1.2.2.1.8":    "ifOperStatus",
+	"1.3.6.1.2.1.31.1.1.1.1": "ifName",
+	"1.3.6.1.6.3.1.1.4.1":    "snmpTrapOID",
+	"1.3.6.1.6.3.1.1.4.3":    "snmpTrapEnterprise",
+	"1.3.6.1.6.3.1.1.5.1":    "coldStart",
+	"1.3.6.1.6.3.1.1.5.2":    "warmStart",
+	"1.3.6.1.6.3.1.1.5.3":    "linkDown",
+	"1.3.6.1.6.3.1.1.5.4":    "linkUp",
+	"1.3.6.1.6.3.1.1.5.5":    "authenticationFailure",
+	"1.3.6.1.6.3.1.1.5.6":    "egpNeighborLoss",
+	"1.3.6.1.6.3.18.1.3":     "snmpTrapAddress",
+	"1.3.6.1.6.3.18.1.4":     "snmpTrapCommunity",
+}
+
+// mibNames resolves OIDs into MIB object names
+type mibNames struct {
+	names map[string]string
+}
+
+// newMIBNames creates a mibNames from the well-known names and the given MIB name files.
+// Each line of a file holds a quoted name and OID, as printed by `snmptranslate -Tz`.
+func newMIBNames(files []string) (*mibNames, error) {
+	names := make(map[string]string, len(wellKnownMIBNames))
+	for oid, name := range wellKnownMIBNames {
+		names[oid] = name
+	}
+
+	for _, file := range files {
+		if err := loadMIBNameFile(file, names); err != nil {
+			return nil, err
+		}
+	}
+
+	return &mibNames{names: names}, nil
+}
+
+// loadMIBNameFile adds the names from the given file to names
+func loadMIBNameFile(file string, names map[string]string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to open MIB name file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return fmt.Errorf("invalid line %d in MIB name file '%s': must contain a name and an OID", lineNum, file)
+		}
+		name := strings.Trim(fields[0], `"`)
+		oid := normalizeOID(strings.Trim(fields[1], `"`))
+		names[oid] = name
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read MIB name file '%s': %w", file, err)
+	}
+
+	return nil
+}
+
+// resolve returns the name of the OID, made of the name of its longest known prefix
+// followed by the remaining suffix, as in "ifOperStatus.3"
+func (m *mibNames) resolve(oid string) (string, bool) {
+	oid = normalizeOID(oid)
+	for prefix := oid; prefix != ""; {
+		if name, ok := m.names[prefix]; ok {
+			return name + oid[len(prefix):], true
+		}
+
+		index := strings.LastIndexByte(prefix, '.')
+		if index < 0 {
+			break
+		}
+		prefix = prefix[:index]
+	}
+
+	return "", false
+}
+
+// normalizeOID removes the leading dot of the OID, if any
+func normalizeOID(oid string) string {
+	return strings.TrimPrefix(oid, ".")
+}
@@ -0,0 +1,88 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmpreceiver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMIBNamesResolve(t *testing.T) {
+	names, err := newMIBNames([]string{filepath.Join("testdata", "mib_names.txt")})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		oid      string
+		expected string
+	}{
+		{
+			desc:     "Resolves well-known names",
+			oid:      "1.3.6.1.6.3.1.1.5.3",
+			expected: "linkDown",
+		},
+		{
+			desc:     "Resolves names with leading dot",
+			oid:      ".1.3.6.1.2.1.1.3.0",
+			expected: "sysUpTime.0",
+		},
+		{
+			desc:     "Resolves names from files",
+			oid:      "1.3.6.1.4.1.9.9.43.2.0.1",
+			expected: "ciscoConfigManEvent",
+		},
+		{
+			desc:     "Resolves indexes with longest prefix",
+			oid:      "1.3.6.1.2.1.2.2.1.3.12",
+			expected: "ifType.12",
+		},
+		{
+			desc:     "Resolves unknown OIDs with known prefix",
+			oid:      "1.3.6.1.2.1.2.2.1.99.1",
+			expected: "ifEntry.99.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			name, ok := names.resolve(tc.oid)
+			require.True(t, ok)
+			require.Equal(t, tc.expected, name)
+		})
+	}
+}
+
+func TestMIBNamesUnresolved(t *testing.T) {
+	names, err := newMIBNames(nil)
+	require.NoError(t, err)
+
+	_, ok := names.resolve("1.3.6.1.4.1.9.9.43.2.0.1")
+	require.False(t, ok)
+	_, ok = names.resolve("")
+	require.False(t, ok)
+}
+
+func TestMIBNamesFileErrors(t *testing.T) {
+	_, err := newMIBNames([]string{filepath.Join("testdata", "missing.txt")})
+	require.ErrorContains(t, err, "failed to open MIB name file")
+
+	file := filepath.Join(t.TempDir(), "mib_names.txt")
+	require.NoError(t, os.WriteFile(file, []byte("\"sysDescr\"\t\"1.3.6.1.2.1.1.1\"\n\"sysObjectID\"\n"), 0600))
+	_, err = newMIBNames([]string{file})
+	require.ErrorContains(t, err, "invalid line 2 in MIB name file")
+}
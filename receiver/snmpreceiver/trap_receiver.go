@@ -0,0 +1,303 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmpreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+	"go.uber.org/zap"
+)
+
+// Attributes set on the log records of the traps
+const (
+	attributeVersion           = "snmp.version"
+	attributePDUType           = "snmp.pdu_type"
+	attributeUser              = "snmp.user"
+	attributeTrapOID           = "snmp.trap.oid"
+	attributeTrapName          = "snmp.trap.name"
+	attributeTrapEnterprise    = "snmp.trap.enterprise"
+	attributeTrapAgentAddress  = "snmp.trap.agent_address"
+	attributeTrapGeneric       = "snmp.trap.generic"
+	attributeTrapSpecific      = "snmp.trap.specific"
+	attributeTrapUptime        = "snmp.trap.uptime"
+	attributeVarbinds          = "snmp.varbinds"
+	attributeVarbindOID        = "oid"
+	attributeVarbindName       = "name"
+	attributeVarbindType       = "type"
+	attributeVarbindValue      = "value"
+	pduTypeTrap                = "trap"
+	pduTypeInform              = "inform"
+	snmpTrapOIDVariable        = "1.3.6.1.6.3.1.1.4.1.0"
+	genericTrapOIDPrefix       = "1.3.6.1.6.3.1.1.5."
+	enterpriseSpecificTrapType = 6
+)
+
+// snmpTrapReceiver listens for SNMP traps and informs and turns them into logs
+type snmpTrapReceiver struct {
+	settings receiver.CreateSettings
+	config   *Config
+	consumer consumer.Logs
+	obsrecv  *obsreport.Receiver
+	names    *mibNames
+	params   *gosnmp.GoSNMP
+	listener *gosnmp.TrapListener
+}
+
+// Verify snmpTrapReceiver implements receiver.Logs interface
+var _ receiver.Logs = (*snmpTrapReceiver)(nil)
+
+// newTrapReceiver creates an initialized snmpTrapReceiver
+// Relies on config being validated thoroughly
+func newTrapReceiver(settings receiver.CreateSettings, cfg *Config, consumer consumer.Logs) (*snmpTrapReceiver, error) {
+	names, err := newMIBNames(cfg.Traps.MIBNameFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             settings.ID,
+		Transport:              "udp",
+		ReceiverCreateSettings: settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &snmpTrapReceiver{
+		settings: settings,
+		config:   cfg,
+		consumer: consumer,
+		obsrecv:  obsrecv,
+		names:    names,
+		params:   newTrapParams(cfg),
+	}, nil
+}
+
+// newTrapParams creates the gosnmp parameters used to decode and authenticate the traps
+func newTrapParams(cfg *Config) *gosnmp.GoSNMP {
+	params := &otelGoSNMPWrapper{
+		gosnmp.GoSNMP{
+			MaxOids: gosnmp.Default.MaxOids,
+		},
+	}
+
+	switch cfg.Version {
+	case "v3":
+		params.SetVersion(gosnmp.Version3)
+		setV3ClientConfigs(params, cfg)
+	case "v1":
+		params.SetVersion(gosnmp.Version1)
+		params.SetCommunity(cfg.Community)
+	default:
+		params.SetVersion(gosnmp.Version2c)
+		params.SetCommunity(cfg.Community)
+	}
+
+	return &params.GoSNMP
+}
+
+// Start starts listening for traps, returning once the listener is ready
+func (r *snmpTrapReceiver) Start(_ context.Context, _ component.Host) error {
+	r.listener = gosnmp.NewTrapListener()
+	r.listener.Params = r.params
+	r.listener.OnNewTrap = r.handleTrap
+
+	errs := make(chan error, 1)
+	go func() {
+		if err := r.listener.Listen(r.config.Traps.Endpoint); err != nil {
+			errs <- err
+		}
+	}()
+
+	select {
+	case <-r.listener.Listening():
+		r.settings.Logger.Info("Listening for SNMP traps", zap.String("endpoint", r.config.Traps.Endpoint))
+		return nil
+	case err := <-errs:
+		return fmt.Errorf("failed to listen for SNMP traps on '%s': %w", r.config.Traps.Endpoint, err)
+	}
+}
+
+// Shutdown stops listening for traps
+func (r *snmpTrapReceiver) Shutdown(_ context.Context) error {
+	if r.listener != nil {
+		r.listener.Close()
+	}
+	return nil
+}
+
+// handleTrap turns an accepted trap into a log record and passes it to the consumer.
+// The informs are acknowledged by the listener once handleTrap returns, whether they were accepted or not.
+func (r *snmpTrapReceiver) handleTrap(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+	if !r.accept(packet) {
+		r.settings.Logger.Debug("Dropping SNMP trap not matching the configured version or credentials",
+			zap.String("address", addr.String()))
+		return
+	}
+
+	logs := r.trapToLogs(packet, addr, time.Now())
+
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	err := r.consumer.ConsumeLogs(ctx, logs)
+	r.obsrecv.EndLogsOp(ctx, typeStr, logs.LogRecordCount(), err)
+	if err != nil {
+		r.settings.Logger.Error("Failed to consume SNMP trap", zap.Error(err))
+	}
+}
+
+// accept returns true if the trap matches the configured version and community,
+// or the configured user and security level for v3.
+// The v3 authentication and decryption is already done by gosnmp.
+func (r *snmpTrapReceiver) accept(packet *gosnmp.SnmpPacket) bool {
+	if packet.Version != r.params.Version {
+		return false
+	}
+
+	if packet.Version != gosnmp.Version3 {
+		return packet.Community == r.params.Community
+	}
+
+	securityParams, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	if !ok || securityParams.UserName != r.config.User {
+		return false
+	}
+	return packet.MsgFlags&gosnmp.AuthPriv >= r.params.MsgFlags&gosnmp.AuthPriv
+}
+
+// trapToLogs creates the logs holding the log record of the trap
+func (r *snmpTrapReceiver) trapToLogs(packet *gosnmp.SnmpPacket, addr *net.UDPAddr, now time.Time) plog.Logs {
+	logs := plog.NewLogs()
+	logRecord := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	logRecord.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	logRecord.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+
+	attributes := logRecord.Attributes()
+	attributes.PutStr(conventions.AttributeNetPeerIP, addr.IP.String())
+	attributes.PutInt(conventions.AttributeNetPeerPort, int64(addr.Port))
+	attributes.PutStr(attributeVersion, packet.Version.String())
+
+	pduType := pduTypeTrap
+	if packet.PDUType == gosnmp.InformRequest {
+		pduType = pduTypeInform
+	}
+	attributes.PutStr(attributePDUType, pduType)
+
+	if securityParams, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters); ok && packet.Version == gosnmp.Version3 {
+		attributes.PutStr(attributeUser, securityParams.UserName)
+	}
+
+	var trapOID string
+	if packet.Version == gosnmp.Version1 {
+		trapOID = v1TrapOID(packet)
+		attributes.PutStr(attributeTrapEnterprise, normalizeOID(packet.Enterprise))
+		attributes.PutStr(attributeTrapAgentAddress, packet.AgentAddress)
+		attributes.PutInt(attributeTrapGeneric, int64(packet.GenericTrap))
+		attributes.PutInt(attributeTrapSpecific, int64(packet.SpecificTrap))
+		attributes.PutInt(attributeTrapUptime, int64(packet.Timestamp))
+	} else {
+		for _, variable := range packet.Variables {
+			if normalizeOID(variable.Name) == snmpTrapOIDVariable {
+				trapOID = normalizeOID(toString(variable.Value))
+				break
+			}
+		}
+	}
+
+	logRecord.Body().SetStr(trapOID)
+	if trapOID != "" {
+		attributes.PutStr(attributeTrapOID, trapOID)
+		if name, ok := r.names.resolve(trapOID); ok {
+			attributes.PutStr(attributeTrapName, name)
+			logRecord.Body().SetStr(name)
+		}
+	}
+
+	varbinds := attributes.PutEmptySlice(attributeVarbinds)
+	varbinds.EnsureCapacity(len(packet.Variables))
+	for _, variable := range packet.Variables {
+		varbind := varbinds.AppendEmpty().SetEmptyMap()
+		oid := normalizeOID(variable.Name)
+		varbind.PutStr(attributeVarbindOID, oid)
+		if name, ok := r.names.resolve(oid); ok {
+			varbind.PutStr(attributeVarbindName, name)
+		}
+		varbind.PutStr(attributeVarbindType, variable.Type.String())
+		setVarbindValue(variable, varbind.PutEmpty(attributeVarbindValue))
+	}
+
+	return logs
+}
+
+// v1TrapOID translates the generic and specific traps of a v1 trap into the
+// equivalent v2 trap OID, as described in RFC 3584 section 3.1
+func v1TrapOID(packet *gosnmp.SnmpPacket) string {
+	if packet.GenericTrap == enterpriseSpecificTrapType {
+		return normalizeOID(packet.Enterprise) + ".0." + strconv.Itoa(packet.SpecificTrap)
+	}
+	return genericTrapOIDPrefix + strconv.Itoa(packet.GenericTrap+1)
+}
+
+// setVarbindValue sets the value of the variable on dest, leaving it empty for
+// types without a value
+func setVarbindValue(variable gosnmp.SnmpPDU, dest pcommon.Value) {
+	switch variable.Type {
+	// Integer types, Counter64 may not fit in an int64
+	case gosnmp.Integer, gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Uinteger32, gosnmp.Counter64:
+		value := gosnmp.ToBigInt(variable.Value)
+		if value.IsInt64() {
+			dest.SetInt(value.Int64())
+		} else {
+			dest.SetStr(value.String())
+		}
+
+	// Octet strings may hold binary data
+	case gosnmp.OctetString:
+		value, ok := variable.Value.([]byte)
+		if ok && !utf8.Valid(value) {
+			dest.SetEmptyBytes().FromRaw(value)
+		} else {
+			dest.SetStr(toString(variable.Value))
+		}
+
+	case gosnmp.ObjectIdentifier:
+		dest.SetStr(normalizeOID(toString(variable.Value)))
+
+	case gosnmp.OpaqueFloat, gosnmp.OpaqueDouble:
+		switch value := variable.Value.(type) {
+		case float32:
+			dest.SetDouble(float64(value))
+		case float64:
+			dest.SetDouble(value)
+		}
+
+	case gosnmp.Null, gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+
+	default:
+		dest.SetStr(toString(variable.Value))
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmpreceiver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// startTrapReceiver starts a trap receiver listening on a free local port, returning the port
+func startTrapReceiver(t *testing.T, cfg *Config) (*consumertest.LogsSink, uint16) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	require.NoError(t, conn.Close())
+
+	cfg.Traps = &TrapsConfig{Endpoint: fmt.Sprintf("udp://127.0.0.1:%d", port)}
+	sink := new(consumertest.LogsSink)
+	rcvr, err := newTrapReceiver(receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	})
+	return sink, uint16(port)
+}
+
+// newTrapSender connects the gosnmp client to send traps to the given port
+func newTrapSender(t *testing.T, port uint16, sender *gosnmp.GoSNMP) *gosnmp.GoSNMP {
+	sender.Target = "127.0.0.1"
+	sender.Port = port
+	sender.Transport = "udp"
+	sender.Timeout = time.Second
+	sender.Retries = 3
+	sender.MaxOids = gosnmp.MaxOids
+	require.NoError(t, sender.Connect())
+	t.Cleanup(func() {
+		require.NoError(t, sender.Conn.Close())
+	})
+	return sender
+}
+
+// linkDownTrap is a v2c linkDown trap of the interface 3
+var linkDownTrap = gosnmp.SnmpTrap{
+	Variables: []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1234)},
+		{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+		{Name: ".1.3.6.1.2.1.2.2.1.1.3", Type: gosnmp.Integer, Value: 3},
+		{Name: ".1.3.6.1.2.1.2.2.1.2.3", Type: gosnmp.OctetString, Value: "eth0"},
+		{Name: ".1.3.6.1.4.1.2021.1", Type: gosnmp.OctetString, Value: []byte{0xff, 0x00}},
+		{Name: ".1.3.6.1.4.1.2021.2", Type: gosnmp.Counter64, Value: uint64(1 << 63)},
+	},
+}
+
+// waitForLogRecords waits until count log records are received and returns them
+func waitForLogRecords(t *testing.T, sink *consumertest.LogsSink, count int) []plog.LogRecord {
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() >= count
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, count, sink.LogRecordCount())
+
+	var logRecords []plog.LogRecord
+	for _, logs := range sink.AllLogs() {
+		for i := 0; i < logs.ResourceLogs().Len(); i++ {
+			scopeLogs := logs.ResourceLogs().At(i).ScopeLogs()
+			for j := 0; j < scopeLogs.Len(); j++ {
+				for k := 0; k < scopeLogs.At(j).LogRecords().Len(); k++ {
+					logRecords = append(logRecords, scopeLogs.At(j).LogRecords().At(k))
+				}
+			}
+		}
+	}
+	return logRecords
+}
+
+func TestTrapReceiverV2CTrap(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	sink, port := startTrapReceiver(t, cfg)
+
+	sender := newTrapSender(t, port, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"})
+	_, err := sender.SendTrap(linkDownTrap)
+	require.NoError(t, err)
+
+	logRecord := waitForLogRecords(t, sink, 1)[0]
+	require.Equal(t, "linkDown", logRecord.Body().Str())
+	require.NotZero(t, logRecord.Timestamp())
+
+	attributes := logRecord.Attributes().AsRaw()
+	require.Equal(t, "127.0.0.1", attributes["net.peer.ip"])
+	require.Equal(t, int64(sender.Conn.LocalAddr().(*net.UDPAddr).Port), attributes["net.peer.port"])
+	require.Equal(t, "2c", attributes["snmp.version"])
+	require.Equal(t, "trap", attributes["snmp.pdu_type"])
+	require.Equal(t, "1.3.6.1.6.3.1.1.5.3", attributes["snmp.trap.oid"])
+	require.Equal(t, "linkDown", attributes["snmp.trap.name"])
+
+	expectedVarbinds := []interface{}{
+		map[string]interface{}{"oid": "1.3.6.1.2.1.1.3.0", "name": "sysUpTime.0", "type": "TimeTicks", "value": int64(1234)},
+		map[string]interface{}{"oid": "1.3.6.1.6.3.1.1.4.1.0", "name": "snmpTrapOID.0", "type": "ObjectIdentifier", "value": "1.3.6.1.6.3.1.1.5.3"},
+		map[string]interface{}{"oid": "1.3.6.1.2.1.2.2.1.1.3", "name": "ifIndex.3", "type": "Integer", "value": int64(3)},
+		map[string]interface{}{"oid": "1.3.6.1.2.1.2.2.1.2.3", "name": "ifDescr.3", "type": "OctetString", "value": "eth0"},
+		map[string]interface{}{"oid": "1.3.6.1.4.1.2021.1", "type": "OctetString", "value": []byte{0xff, 0x00}},
+		map[string]interface{}{"oid": "1.3.6.1.4.1.2021.2", "type": "Counter64", "value": "9223372036854775808"},
+	}
+	require.Equal(t, expectedVarbinds, attributes["snmp.varbinds"])
+}
+
+func TestTrapReceiverV2CInform(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	sink, port := startTrapReceiver(t, cfg)
+
+	inform := linkDownTrap
+	inform.IsInform = true
+	sender := newTrapSender(t, port, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"})
+	response, err := sender.SendTrap(inform)
+	require.NoError(t, err)
+	require.Equal(t, gosnmp.GetResponse, response.PDUType)
+
+	logRecord := waitForLogRecords(t, sink, 1)[0]
+	pduType, ok := logRecord.Attributes().Get("snmp.pdu_type")
+	require.True(t, ok)
+	require.Equal(t, "inform", pduType.Str())
+}
+
+func TestTrapReceiverV1Trap(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Version = "v1"
+	sink, port := startTrapReceiver(t, cfg)
+
+	sender := newTrapSender(t, port, &gosnmp.GoSNMP{Version: gosnmp.Version1, Community: "public"})
+	_, err := sender.SendTrap(gosnmp.SnmpTrap{
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.4.1.2021.1", Type: gosnmp.Integer, Value: 1},
+		},
+		Enterprise:   ".1.3.6.1.4.1.2021",
+		AgentAddress: "10.0.0.1",
+		GenericTrap:  6,
+		SpecificTrap: 42,
+		Timestamp:    300,
+	})
+	require.NoError(t, err)
+
+	logRecord := waitForLogRecords(t, sink, 1)[0]
+	require.Equal(t, "1.3.6.1.4.1.2021.0.42", logRecord.Body().Str())
+
+	attributes := logRecord.Attributes().AsRaw()
+	require.Equal(t, "1", attributes["snmp.version"])
+	require.Equal(t, "1.3.6.1.4.1.2021.0.42", attributes["snmp.trap.oid"])
+	require.NotContains(t, attributes, "snmp.trap.name")
+	require.Equal(t, "1.3.6.1.4.1.2021", attributes["snmp.trap.enterprise"])
+	require.Equal(t, "10.0.0.1", attributes["snmp.trap.agent_address"])
+	require.Equal(t, int64(6), attributes["snmp.trap.generic"])
+	require.Equal(t, int64(42), attributes["snmp.trap.specific"])
+	require.Equal(t, int64(300), attributes["snmp.trap.uptime"])
+}
+
+func TestTrapReceiverV3Trap(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Version = "v3"
+	cfg.User = "otel"
+	cfg.SecurityLevel = "auth_priv"
+	cfg.AuthType = "SHA"
+	cfg.AuthPassword = "authpassword"
+	cfg.PrivacyType = "AES"
+	cfg.PrivacyPassword = "privacypassword"
+	sink, port := startTrapReceiver(t, cfg)
+
+	newV3Sender := func(authPassphrase string) *gosnmp.GoSNMP {
+		return newTrapSender(t, port, &gosnmp.GoSNMP{
+			Version:       gosnmp.Version3,
+			SecurityModel: gosnmp.UserSecurityModel,
+			MsgFlags:      gosnmp.AuthPriv,
+			SecurityParameters: &gosnmp.UsmSecurityParameters{
+				UserName:                 "otel",
+				AuthoritativeEngineID:    "8000000001020304",
+				AuthenticationProtocol:   gosnmp.SHA,
+				AuthenticationPassphrase: authPassphrase,
+				PrivacyProtocol:          gosnmp.AES,
+				PrivacyPassphrase:        "privacypassword",
+			},
+		})
+	}
+
+	// Traps are handled in order, so only the last one must be received
+	_, err := newV3Sender("wrongpassword").SendTrap(linkDownTrap)
+	require.NoError(t, err)
+	_, err = newV3Sender("authpassword").SendTrap(linkDownTrap)
+	require.NoError(t, err)
+
+	logRecord := waitForLogRecords(t, sink, 1)[0]
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, sink.LogRecordCount())
+	require.Equal(t, "linkDown", logRecord.Body().Str())
+	attributes := logRecord.Attributes().AsRaw()
+	require.Equal(t, "3", attributes["snmp.version"])
+	require.Equal(t, "otel", attributes["snmp.user"])
+}
+
+func TestTrapReceiverDropsUnmatchedTraps(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Community = "private"
+	sink, port := startTrapReceiver(t, cfg)
+
+	// Traps are handled in order, so only the last one must be received
+	_, err := newTrapSender(t, port, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"}).SendTrap(linkDownTrap)
+	require.NoError(t, err)
+	_, err = newTrapSender(t, port, &gosnmp.GoSNMP{Version: gosnmp.Version1, Community: "private"}).SendTrap(gosnmp.SnmpTrap{
+		Variables:    linkDownTrap.Variables[2:3],
+		Enterprise:   ".1.3.6.1.4.1.2021",
+		AgentAddress: "10.0.0.1",
+		GenericTrap:  2,
+	})
+	require.NoError(t, err)
+	_, err = newTrapSender(t, port, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "private"}).SendTrap(linkDownTrap)
+	require.NoError(t, err)
+
+	logRecords := waitForLogRecords(t, sink, 1)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, sink.LogRecordCount())
+	assert.Equal(t, "linkDown", logRecords[0].Body().Str())
+}
+
+func TestSetVarbindValue(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		variable gosnmp.SnmpPDU
+		expected interface{}
+	}{
+		{
+			desc:     "Gauge32",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.Gauge32, Value: uint(7)},
+			expected: int64(7),
+		},
+		{
+			desc:     "IPAddress",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.IPAddress, Value: "10.0.0.1"},
+			expected: "10.0.0.1",
+		},
+		{
+			desc:     "OpaqueFloat",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.OpaqueFloat, Value: float32(1.5)},
+			expected: float64(1.5),
+		},
+		{
+			desc:     "NoSuchInstance",
+			variable: gosnmp.SnmpPDU{Type: gosnmp.NoSuchInstance},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			value := pcommon.NewValueEmpty()
+			setVarbindValue(tc.variable, value)
+			require.Equal(t, tc.expected, value.AsRaw())
+		})
+	}
+}